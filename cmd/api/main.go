@@ -9,14 +9,20 @@ import (
 	"syscall"
 	"time"
 
+	adminModule "music-app-backend/internal/admin"
 	analyticsModule "music-app-backend/internal/analytics"
 	authModule "music-app-backend/internal/auth"
+	earningsModule "music-app-backend/internal/earnings"
 	musicModule "music-app-backend/internal/music"
+	notificationModule "music-app-backend/internal/notification"
 	playbackModule "music-app-backend/internal/playback"
+	realtimeModule "music-app-backend/internal/realtime"
 	socialModule "music-app-backend/internal/social"
 	userModule "music-app-backend/internal/user"
+	"music-app-backend/pkg/audit"
 	ctx2 "music-app-backend/pkg/context"
 	"music-app-backend/pkg/database"
+	"music-app-backend/pkg/middleware"
 	"music-app-backend/pkg/redis"
 	"music-app-backend/pkg/storage"
 
@@ -65,30 +71,62 @@ func main() {
 	}
 
 	router := gin.Default()
+	router.Use(middleware.RequestContext())
 	api := router.Group("api")
 	v1 := api.Group("v1")
 
 	// Init Service Context
-	serviceContext := ctx2.NewServiceContext(db.GetDB(), router, generator, redisClient, storageService)
+	auditLog := audit.NewStore(db.GetDB(), generator)
+	serviceContext := ctx2.NewServiceContext(db.GetDB(), router, generator, redisClient, storageService, auditLog)
 
 	// Module registration
-	authModule := authModule.NewAuthModule(db.GetDB())
+	authModule := authModule.NewAuthModule(serviceContext)
 	authModule.RegisterRoutes(v1)
+	authModule.RegisterWellKnownRoutes(router)
 
-	musicModule := musicModule.NewMusicModule(db.GetDB(), serviceContext)
+	musicModule := musicModule.NewMusicModule(db.GetDB(), serviceContext, authModule.Middleware)
 	musicModule.RegisterRoutes(v1)
 
-	userModule := userModule.NewUserModule(serviceContext, musicModule.Service)
+	userModule := userModule.NewUserModule(serviceContext, authModule.Middleware, authModule.KratosClient, authModule.KratosWebhook, authModule.Service, musicModule.Service)
 	userModule.RegisterRoutes(v1)
+	musicModule.Service.SetContentPolicy(userModule.Service)
+	musicModule.Service.SetIdentityTraits(userModule.Service)
 
-	analyticsModule := analyticsModule.NewAnalyticsModule(db.GetDB())
+	analyticsModule := analyticsModule.NewAnalyticsModule(serviceContext)
 	analyticsModule.RegisterRoutes(v1)
 
-	playbackModule := playbackModule.NewPlaybackModule(db.GetDB())
+	realtimeModule := realtimeModule.NewRealtimeModule(serviceContext, authModule.Middleware, musicModule.Service)
+	realtimeModule.RegisterRoutes(v1)
+	musicModule.Service.SetEventPublisher(realtimeModule.Service)
+
+	notificationModule := notificationModule.NewNotificationModule(serviceContext, authModule.Middleware, musicModule.Service, analyticsModule.Service, realtimeModule.Service)
+	notificationModule.RegisterRoutes(v1)
+	musicModule.Service.AddProcessingListener(notificationModule.Service)
+	musicModule.Service.AddVerificationListener(notificationModule.Service)
+
+	playbackModule := playbackModule.NewPlaybackModule(serviceContext, authModule.Middleware, musicModule.Service, analyticsModule.Service, realtimeModule.Service)
 	playbackModule.RegisterRoutes(v1)
 
-	socialModule := socialModule.NewSocialModule(db.GetDB())
+	earningsModule := earningsModule.NewEarningsModule(serviceContext, authModule.Middleware, musicModule.Service)
+	earningsModule.RegisterRoutes(v1)
+
+	socialModule := socialModule.NewSocialModule(serviceContext, authModule.Middleware, musicModule.Service, earningsModule.Service, analyticsModule.Service, realtimeModule.Service, notificationModule.Service)
 	socialModule.RegisterRoutes(v1)
+	musicModule.Service.AddProcessingListener(socialModule.Service)
+
+	adminModule := adminModule.NewAdminModule(serviceContext, authModule.Middleware, authModule.Repository, authModule.Service, musicModule.Service)
+	adminModule.RegisterRoutes(v1)
+
+	// Background workers stop when the server shuts down
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	authModule.StartWorkers(workerCtx)
+	userModule.StartWorkers(workerCtx)
+	realtimeModule.StartWorkers(workerCtx)
+	notificationModule.StartWorkers(workerCtx)
+	earningsModule.StartWorkers(workerCtx)
+	playbackModule.StartWorkers(workerCtx)
+	socialModule.StartWorkers(workerCtx)
 
 	router.Use(gin.Recovery())
 	router.Use(gin.Logger())
@@ -116,6 +154,7 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Println("Shutting down server...")
+	stopWorkers()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -5,7 +5,9 @@ go 1.24.2
 require (
 	github.com/capy-engineer/go-flakeid v0.0.0-20250727065409-7ec499292bbe
 	github.com/gin-gonic/gin v1.10.1
+	github.com/gorilla/websocket v1.5.3
 	github.com/lib/pq v1.10.9
+	golang.org/x/image v0.25.0
 )
 
 require (
@@ -0,0 +1,232 @@
+package http
+
+import (
+	"music-app-backend/internal/admin/application"
+	model "music-app-backend/internal/admin/domain"
+	musicModel "music-app-backend/internal/music/domain"
+	"music-app-backend/pkg/audit"
+	appError "music-app-backend/pkg/error"
+	jsonResponse "music-app-backend/pkg/json"
+	"music-app-backend/pkg/pagination"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminHandler struct {
+	adminService *application.AdminService
+}
+
+func NewAdminHandler(adminService *application.AdminService) *AdminHandler {
+	return &AdminHandler{adminService: adminService}
+}
+
+func (h *AdminHandler) HandleError(c *gin.Context, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if appErr, ok := appError.GetAppError(err); ok {
+		jsonResponse.ResponseJSON(c, appErr.StatusCode, appErr.Message, appErr.Data)
+		return true
+	}
+
+	jsonResponse.ResponseInternalError(c, err)
+	return true
+}
+
+// SearchUsers filters users by ?q= (email or display name), ?user_type= and ?is_active=
+func (h *AdminHandler) SearchUsers(c *gin.Context) {
+	params := &model.UserSearchParams{
+		Query:    c.Query("q"),
+		UserType: c.Query("user_type"),
+	}
+	if raw := c.Query("is_active"); raw != "" {
+		active, err := strconv.ParseBool(raw)
+		if err != nil {
+			jsonResponse.ResponseBadRequest(c, "is_active must be true or false")
+			return
+		}
+		params.IsActive = &active
+	}
+
+	result, err := h.adminService.SearchUsers(c.Request.Context(), params, pagination.FromQuery(c))
+	if h.HandleError(c, err) {
+		return
+	}
+	jsonResponse.ResponseOK(c, result)
+}
+
+func (h *AdminHandler) GetUser(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
+	if err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid user ID")
+		return
+	}
+
+	user, err := h.adminService.GetUser(userID)
+	if h.HandleError(c, err) {
+		return
+	}
+	jsonResponse.ResponseOK(c, user)
+}
+
+func (h *AdminHandler) DeactivateUser(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
+	if err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid user ID")
+		return
+	}
+
+	request := &model.ReasonDTO{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	user, err := h.adminService.DeactivateUser(c.Request.Context(), c.GetUint64("user_id"), userID, request.Reason)
+	if h.HandleError(c, err) {
+		return
+	}
+	jsonResponse.ResponseOK(c, user)
+}
+
+func (h *AdminHandler) ReactivateUser(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
+	if err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid user ID")
+		return
+	}
+
+	// The reason is optional when reactivating
+	request := &model.ReasonDTO{}
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(request); err != nil {
+			jsonResponse.ResponseBadRequest(c, "Invalid request: "+err.Error())
+			return
+		}
+	}
+
+	user, err := h.adminService.ReactivateUser(c.Request.Context(), userID, request.Reason)
+	if h.HandleError(c, err) {
+		return
+	}
+	jsonResponse.ResponseOK(c, user)
+}
+
+// ListVerificationQueue lists artist verification requests, filtered by ?status=
+func (h *AdminHandler) ListVerificationQueue(c *gin.Context) {
+	status := musicModel.VerificationStatus(c.Query("status"))
+	result, err := h.adminService.ListVerificationQueue(c.Request.Context(), status, pagination.FromQuery(c))
+	if h.HandleError(c, err) {
+		return
+	}
+	jsonResponse.ResponseOK(c, result)
+}
+
+func (h *AdminHandler) ReviewVerificationRequest(c *gin.Context) {
+	requestID, err := strconv.ParseUint(c.Param("request_id"), 10, 64)
+	if err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid request ID")
+		return
+	}
+
+	decision := &musicModel.ReviewVerificationDTO{}
+	if err := c.ShouldBindJSON(decision); err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	verification, err := h.adminService.ReviewVerificationRequest(c.Request.Context(), c.GetUint64("user_id"), requestID, decision)
+	if h.HandleError(c, err) {
+		return
+	}
+	jsonResponse.ResponseOK(c, verification)
+}
+
+func (h *AdminHandler) TakeDownSong(c *gin.Context) {
+	songID, err := strconv.ParseUint(c.Param("song_id"), 10, 64)
+	if err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid song ID")
+		return
+	}
+
+	request := &model.ReasonDTO{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	song, err := h.adminService.TakeDownSong(c.Request.Context(), songID, request.Reason)
+	if h.HandleError(c, err) {
+		return
+	}
+	jsonResponse.ResponseOK(c, song)
+}
+
+func (h *AdminHandler) RestoreSong(c *gin.Context) {
+	songID, err := strconv.ParseUint(c.Param("song_id"), 10, 64)
+	if err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid song ID")
+		return
+	}
+
+	song, err := h.adminService.RestoreSong(c.Request.Context(), songID)
+	if h.HandleError(c, err) {
+		return
+	}
+	jsonResponse.ResponseOK(c, song)
+}
+
+func (h *AdminHandler) GetQueueStats(c *gin.Context) {
+	stats, err := h.adminService.GetQueueStats(c.Request.Context())
+	if h.HandleError(c, err) {
+		return
+	}
+	jsonResponse.ResponseOK(c, stats)
+}
+
+// ListAuditLog filters by ?actor_id=, ?action=, ?target_type=, ?target_id=, ?request_id=
+// and the RFC 3339 range ?from= to ?to=
+func (h *AdminHandler) ListAuditLog(c *gin.Context) {
+	filter := &audit.Filter{
+		Action:     c.Query("action"),
+		TargetType: c.Query("target_type"),
+		TargetID:   c.Query("target_id"),
+		RequestID:  c.Query("request_id"),
+	}
+	if raw := c.Query("actor_id"); raw != "" {
+		actorID, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			jsonResponse.ResponseBadRequest(c, "Invalid actor ID")
+			return
+		}
+		filter.ActorID = actorID
+	}
+	for param, target := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		if raw := c.Query(param); raw != "" {
+			parsed, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				jsonResponse.ResponseBadRequest(c, param+" must be an RFC 3339 time")
+				return
+			}
+			*target = &parsed
+		}
+	}
+
+	result, err := h.adminService.ListAuditLog(c.Request.Context(), filter, pagination.FromQuery(c))
+	if h.HandleError(c, err) {
+		return
+	}
+	jsonResponse.ResponseOK(c, result)
+}
+
+// VerifyAuditLog reports whether the audit log's hash chain is intact
+func (h *AdminHandler) VerifyAuditLog(c *gin.Context) {
+	result, err := h.adminService.VerifyAuditLog(c.Request.Context())
+	if h.HandleError(c, err) {
+		return
+	}
+	jsonResponse.ResponseOK(c, result)
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	model "music-app-backend/internal/admin/domain"
+	userModel "music-app-backend/internal/user/domain"
+	"music-app-backend/pkg/pagination"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type AdminRepository struct {
+	db *gorm.DB
+}
+
+func NewAdminRepository(db *gorm.DB) *AdminRepository {
+	return &AdminRepository{db: db}
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// SearchUsers matches the query against email and display name, newest accounts first
+func (r *AdminRepository) SearchUsers(ctx context.Context, params *model.UserSearchParams, page pagination.Params) ([]userModel.User, int64, error) {
+	query := r.db.WithContext(ctx).Model(&userModel.User{})
+	if params.Query != "" {
+		pattern := "%" + likeEscaper.Replace(params.Query) + "%"
+		query = query.Where("email ILIKE ? OR display_name ILIKE ?", pattern, pattern)
+	}
+	if params.UserType != "" {
+		query = query.Where("user_type = ?", params.UserType)
+	}
+	if params.IsActive != nil {
+		query = query.Where("is_active = ?", *params.IsActive)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var users []userModel.User
+	err := query.Order("created_at DESC, id DESC").
+		Offset(page.Offset()).
+		Limit(page.Limit).
+		Find(&users).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
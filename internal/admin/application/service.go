@@ -0,0 +1,179 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"music-app-backend/internal/admin/adapters/repository"
+	model "music-app-backend/internal/admin/domain"
+	authRepository "music-app-backend/internal/auth/adapters/repository"
+	authModuleSvc "music-app-backend/internal/auth/application"
+	authModel "music-app-backend/internal/auth/domain"
+	musicModuleSvc "music-app-backend/internal/music/application"
+	musicModel "music-app-backend/internal/music/domain"
+	notificationModuleSvc "music-app-backend/internal/notification/application"
+	socialModuleSvc "music-app-backend/internal/social/application"
+	userModel "music-app-backend/internal/user/domain"
+	"music-app-backend/pkg/audit"
+	appError "music-app-backend/pkg/error"
+	"music-app-backend/pkg/pagination"
+	"music-app-backend/pkg/queue"
+	"music-app-backend/pkg/redis"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// inspectedQueues are the Redis work queues shown to admins, by display name
+var inspectedQueues = []struct{ name, key string }{
+	{"audio_processing", queue.CeleryDefaultQueue},
+	{"artist_messages", socialModuleSvc.ArtistMessageQueue},
+	{"notification_emails", notificationModuleSvc.EmailQueue},
+}
+
+var userTypes = map[string]bool{"artist": true, "listener": true, "admin": true}
+
+type AdminService struct {
+	repository   *repository.AdminRepository
+	authRepo     *authRepository.AuthRepository
+	authService  *authModuleSvc.AuthService
+	musicService musicModuleSvc.IMusicService
+	redisClient  *redis.Client
+	auditLog     *audit.Store
+}
+
+func NewAdminService(repository *repository.AdminRepository, authRepo *authRepository.AuthRepository, authService *authModuleSvc.AuthService, musicService musicModuleSvc.IMusicService, redisClient *redis.Client, auditLog *audit.Store) *AdminService {
+	return &AdminService{
+		repository:   repository,
+		authRepo:     authRepo,
+		authService:  authService,
+		musicService: musicService,
+		redisClient:  redisClient,
+		auditLog:     auditLog,
+	}
+}
+
+func (s *AdminService) SearchUsers(ctx context.Context, params *model.UserSearchParams, page pagination.Params) (*model.UserSearchResult, error) {
+	params.Query = strings.TrimSpace(params.Query)
+	if params.UserType != "" && !userTypes[params.UserType] {
+		return nil, appError.NewBadRequestError(nil, "user_type must be artist, listener or admin")
+	}
+
+	users, total, err := s.repository.SearchUsers(ctx, params, page)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to search users")
+	}
+	if users == nil {
+		users = []userModel.User{}
+	}
+	return &model.UserSearchResult{Users: users, Pagination: page.Meta(total)}, nil
+}
+
+func (s *AdminService) GetUser(userID uint64) (*userModel.User, error) {
+	user, err := s.authRepo.FindUserByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, appError.NewNotFoundError(err, "user not found")
+		}
+		return nil, appError.NewInternalError(err, "failed to load user")
+	}
+	return user, nil
+}
+
+// DeactivateUser blocks the account and ends its sessions; its access tokens are revoked
+// as well, and the auth middleware checks is_active. Admins cannot lock themselves out.
+func (s *AdminService) DeactivateUser(ctx context.Context, actorID, userID uint64, reason string) (*userModel.User, error) {
+	if actorID == userID {
+		return nil, appError.NewBadRequestError(nil, "admins cannot deactivate their own account")
+	}
+	return s.setUserActive(ctx, userID, false, "user.deactivate", reason)
+}
+
+func (s *AdminService) ReactivateUser(ctx context.Context, userID uint64, reason string) (*userModel.User, error) {
+	return s.setUserActive(ctx, userID, true, "user.reactivate", reason)
+}
+
+func (s *AdminService) setUserActive(ctx context.Context, userID uint64, active bool, action, reason string) (*userModel.User, error) {
+	user, err := s.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	wasActive := user.IsActive
+
+	if active {
+		err = s.authRepo.ActivateUser(userID)
+	} else {
+		err = s.authRepo.DeactivateUser(userID)
+	}
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to update user")
+	}
+	if err := s.authService.InvalidateUserStatus(ctx, userID); err != nil {
+		return nil, err
+	}
+	if !active {
+		if err := s.authService.InvalidateUserTokens(ctx, userID, authModel.SessionRevokeDeactivation); err != nil {
+			return nil, err
+		}
+	}
+
+	audit.Write(ctx, s.auditLog, &audit.Entry{
+		Action:     action,
+		TargetType: "user",
+		TargetID:   strconv.FormatUint(userID, 10),
+		Before:     map[string]interface{}{"is_active": wasActive},
+		After:      map[string]interface{}{"is_active": active},
+		Metadata:   map[string]interface{}{"reason": strings.TrimSpace(reason)},
+	})
+	return s.GetUser(userID)
+}
+
+func (s *AdminService) ListVerificationQueue(ctx context.Context, status musicModel.VerificationStatus, page pagination.Params) (*musicModel.VerificationQueueResult, error) {
+	return s.musicService.ListVerificationQueue(ctx, status, page)
+}
+
+func (s *AdminService) ReviewVerificationRequest(ctx context.Context, actorID, requestID uint64, decision *musicModel.ReviewVerificationDTO) (*musicModel.ArtistVerificationRequest, error) {
+	return s.musicService.ReviewVerificationRequest(ctx, actorID, requestID, decision)
+}
+
+func (s *AdminService) TakeDownSong(ctx context.Context, songID uint64, reason string) (*musicModel.Song, error) {
+	return s.musicService.TakeDownSong(ctx, songID, reason)
+}
+
+func (s *AdminService) RestoreSong(ctx context.Context, songID uint64) (*musicModel.Song, error) {
+	return s.musicService.RestoreSong(ctx, songID)
+}
+
+// GetQueueStats reports the backlog of each work queue
+func (s *AdminService) GetQueueStats(ctx context.Context) ([]model.QueueStats, error) {
+	stats := make([]model.QueueStats, 0, len(inspectedQueues))
+	for _, q := range inspectedQueues {
+		pending, err := s.redisClient.LLen(ctx, q.key)
+		if err != nil {
+			return nil, appError.NewInternalError(err, "failed to read queue "+q.name)
+		}
+		delayed, err := s.redisClient.ZCard(ctx, q.key+":delayed")
+		if err != nil {
+			return nil, appError.NewInternalError(err, "failed to read queue "+q.name)
+		}
+		stats = append(stats, model.QueueStats{Name: q.name, Key: q.key, Pending: pending, Delayed: delayed})
+	}
+	return stats, nil
+}
+
+func (s *AdminService) ListAuditLog(ctx context.Context, filter *audit.Filter, page pagination.Params) (*audit.QueryResult, error) {
+	result, err := s.auditLog.Query(ctx, filter, page)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to list audit log")
+	}
+	return result, nil
+}
+
+// VerifyAuditLog checks the hash chain of the whole audit log
+func (s *AdminService) VerifyAuditLog(ctx context.Context) (*audit.VerifyResult, error) {
+	result, err := s.auditLog.Verify(ctx)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to verify audit log")
+	}
+	return result, nil
+}
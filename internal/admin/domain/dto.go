@@ -0,0 +1,32 @@
+package model
+
+import (
+	userModel "music-app-backend/internal/user/domain"
+	"music-app-backend/pkg/pagination"
+)
+
+// UserSearchParams filters the user search; empty fields match everything
+type UserSearchParams struct {
+	Query    string
+	UserType string
+	IsActive *bool
+}
+
+type UserSearchResult struct {
+	Users      []userModel.User `json:"users"`
+	Pagination pagination.Meta  `json:"pagination"`
+}
+
+// ReasonDTO carries the reason an admin gives for a deactivation or takedown
+type ReasonDTO struct {
+	Reason string `json:"reason"`
+}
+
+// QueueStats is the backlog of one Redis work queue. Delayed jobs are retries waiting
+// for their backoff to pass.
+type QueueStats struct {
+	Name    string `json:"name"`
+	Key     string `json:"key"`
+	Pending int64  `json:"pending"`
+	Delayed int64  `json:"delayed"`
+}
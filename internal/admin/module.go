@@ -0,0 +1,72 @@
+package admin
+
+import (
+	"music-app-backend/internal/admin/adapters/http"
+	"music-app-backend/internal/admin/adapters/repository"
+	"music-app-backend/internal/admin/application"
+	authRepository "music-app-backend/internal/auth/adapters/repository"
+	authModuleSvc "music-app-backend/internal/auth/application"
+	musicModuleSvc "music-app-backend/internal/music/application"
+	ctx2 "music-app-backend/pkg/context"
+	"music-app-backend/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminModule struct {
+	Repository *repository.AdminRepository
+	Service    *application.AdminService
+	Handler    *http.AdminHandler
+
+	authMiddleware *middleware.AuthMiddleware
+}
+
+func NewAdminModule(serviceContext *ctx2.ServiceContext, authMiddleware *middleware.AuthMiddleware, authRepo *authRepository.AuthRepository, authService *authModuleSvc.AuthService, musicService musicModuleSvc.IMusicService) *AdminModule {
+	adminRepo := repository.NewAdminRepository(serviceContext.GetDB())
+	adminService := application.NewAdminService(adminRepo, authRepo, authService, musicService, serviceContext.GetRedisClient(), serviceContext.GetAuditLog())
+	adminHandler := http.NewAdminHandler(adminService)
+
+	return &AdminModule{
+		Repository:     adminRepo,
+		Service:        adminService,
+		Handler:        adminHandler,
+		authMiddleware: authMiddleware,
+	}
+}
+
+func (a *AdminModule) RegisterRoutes(router *gin.RouterGroup) {
+	admin := router.Group("/admin")
+	admin.Use(a.authMiddleware.RequireAuth(), a.authMiddleware.RequireAdmin())
+	{
+		users := admin.Group("/users")
+		users.Use(a.authMiddleware.RequirePermission(middleware.PermissionManageUsers))
+		{
+			users.GET("", a.Handler.SearchUsers)
+			users.GET("/:user_id", a.Handler.GetUser)
+			users.POST("/:user_id/deactivate", a.Handler.DeactivateUser)
+			users.POST("/:user_id/reactivate", a.Handler.ReactivateUser)
+		}
+
+		verifications := admin.Group("/verifications")
+		verifications.Use(a.authMiddleware.RequirePermission(middleware.PermissionVerifyArtists))
+		{
+			verifications.GET("", a.Handler.ListVerificationQueue)
+			verifications.POST("/:request_id/review", a.Handler.ReviewVerificationRequest)
+		}
+
+		songs := admin.Group("/songs")
+		songs.Use(a.authMiddleware.RequirePermission(middleware.PermissionModerateContent))
+		{
+			songs.POST("/:song_id/takedown", a.Handler.TakeDownSong)
+			songs.DELETE("/:song_id/takedown", a.Handler.RestoreSong)
+		}
+
+		admin.GET("/queues", a.authMiddleware.RequirePermission(middleware.PermissionInspectQueues), a.Handler.GetQueueStats)
+		auditLog := admin.Group("/audit-log")
+		auditLog.Use(a.authMiddleware.RequirePermission(middleware.PermissionReadAuditLog))
+		{
+			auditLog.GET("", a.Handler.ListAuditLog)
+			auditLog.GET("/verify", a.Handler.VerifyAuditLog)
+		}
+	}
+}
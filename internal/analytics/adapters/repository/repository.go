@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"context"
+	model "music-app-backend/internal/analytics/domain"
+
 	"gorm.io/gorm"
 )
 
@@ -15,4 +18,37 @@ func NewAnalyticsRepository(db *gorm.DB) *AnalyticsRepository {
 	}
 }
 
-func (r *AnalyticsRepository) IMockRepository() {}
+// IncrementArtistStats adds delta to the artist's row for date (YYYY-MM-DD), creating it on first use
+func (r *AnalyticsRepository) IncrementArtistStats(ctx context.Context, id uint64, artistID uint64, date string, delta *model.ArtistStatsDelta) error {
+	return r.db.WithContext(ctx).Exec(`
+		INSERT INTO daily_artist_stats (id, artist_id, date, total_plays, unique_listeners,
+			total_duration_played, new_followers, tips_received_cents, tip_count)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (artist_id, date) DO UPDATE SET
+			total_plays = daily_artist_stats.total_plays + EXCLUDED.total_plays,
+			unique_listeners = daily_artist_stats.unique_listeners + EXCLUDED.unique_listeners,
+			total_duration_played = daily_artist_stats.total_duration_played + EXCLUDED.total_duration_played,
+			new_followers = daily_artist_stats.new_followers + EXCLUDED.new_followers,
+			tips_received_cents = daily_artist_stats.tips_received_cents + EXCLUDED.tips_received_cents,
+			tip_count = daily_artist_stats.tip_count + EXCLUDED.tip_count`,
+		id, artistID, date, delta.TotalPlays, delta.UniqueListeners, delta.TotalDurationPlayed,
+		delta.NewFollowers, delta.TipsReceivedCents, delta.TipCount,
+	).Error
+}
+
+// SumArtistStats pages through artists with stats between from and to (YYYY-MM-DD, both
+// inclusive), ordered by artist ID. Unique listeners are summed per day, so a listener
+// who came back on several days counts once per day.
+func (r *AnalyticsRepository) SumArtistStats(ctx context.Context, from, to string, afterArtistID uint64, limit int) ([]model.ArtistStatsTotals, error) {
+	var totals []model.ArtistStatsTotals
+	err := r.db.WithContext(ctx).Table("daily_artist_stats").
+		Select(`artist_id, SUM(total_plays) AS total_plays, SUM(unique_listeners) AS unique_listeners,
+			SUM(total_duration_played) AS total_duration_played, SUM(new_followers) AS new_followers,
+			SUM(tips_received_cents) AS tips_received_cents, SUM(tip_count) AS tip_count`).
+		Where("date BETWEEN ? AND ? AND artist_id > ?", from, to, afterArtistID).
+		Group("artist_id").
+		Order("artist_id").
+		Limit(limit).
+		Scan(&totals).Error
+	return totals, err
+}
@@ -1,13 +1,42 @@
 package application
 
-import "music-app-backend/internal/analytics/adapters/repository"
+import (
+	"context"
+	"music-app-backend/internal/analytics/adapters/repository"
+	model "music-app-backend/internal/analytics/domain"
+	"time"
+
+	goflakeid "github.com/capy-engineer/go-flakeid"
+)
+
+// IAnalyticsService is used by other modules to feed the daily stats tables
+type IAnalyticsService interface {
+	RecordArtistStats(ctx context.Context, artistID uint64, day time.Time, delta *model.ArtistStatsDelta) error
+	SumArtistStats(ctx context.Context, from, to time.Time, afterArtistID uint64, limit int) ([]model.ArtistStatsTotals, error)
+}
 
 type AnalyticsService struct {
 	repository *repository.AnalyticsRepository
+	generator  *goflakeid.Generator
 }
 
-func NewAnalyticsService(repository *repository.AnalyticsRepository) *AnalyticsService {
-	return &AnalyticsService{repository: repository}
-}	
+func NewAnalyticsService(repository *repository.AnalyticsRepository, generator *goflakeid.Generator) *AnalyticsService {
+	return &AnalyticsService{
+		repository: repository,
+		generator:  generator,
+	}
+}
 
-func (s *AnalyticsService) IMockService() {}
+// RecordArtistStats applies delta to the artist's stats for the UTC day containing day
+func (s *AnalyticsService) RecordArtistStats(ctx context.Context, artistID uint64, day time.Time, delta *model.ArtistStatsDelta) error {
+	id, err := s.generator.Generate()
+	if err != nil {
+		return err
+	}
+	return s.repository.IncrementArtistStats(ctx, id, artistID, day.UTC().Format("2006-01-02"), delta)
+}
+
+// SumArtistStats totals the UTC days from..to (inclusive) for the next page of artists
+func (s *AnalyticsService) SumArtistStats(ctx context.Context, from, to time.Time, afterArtistID uint64, limit int) ([]model.ArtistStatsTotals, error) {
+	return s.repository.SumArtistStats(ctx, from.UTC().Format("2006-01-02"), to.UTC().Format("2006-01-02"), afterArtistID, limit)
+}
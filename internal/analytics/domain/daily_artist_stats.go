@@ -15,3 +15,25 @@ type DailyArtistStats struct {
 	TipsReceivedCents   int    `json:"tips_received_cents" gorm:"default:0"`
 	TipCount            int    `json:"tip_count" gorm:"default:0"`
 }
+
+// ArtistStatsDelta is added to an artist's row for one day. Negative values reverse
+// earlier increments, e.g. when a tip is refunded.
+type ArtistStatsDelta struct {
+	TotalPlays          int
+	UniqueListeners     int
+	TotalDurationPlayed int
+	NewFollowers        int
+	TipsReceivedCents   int
+	TipCount            int
+}
+
+// ArtistStatsTotals sums an artist's daily rows over a date range
+type ArtistStatsTotals struct {
+	ArtistID            uint64
+	TotalPlays          int
+	UniqueListeners     int
+	TotalDurationPlayed int
+	NewFollowers        int
+	TipsReceivedCents   int
+	TipCount            int
+}
@@ -3,9 +3,9 @@ package analytics
 import (
 	"music-app-backend/internal/analytics/adapters/repository"
 	"music-app-backend/internal/analytics/application"
+	ctx2 "music-app-backend/pkg/context"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
 type AnalyticsModule struct {
@@ -13,9 +13,9 @@ type AnalyticsModule struct {
 	Service    *application.AnalyticsService
 }
 
-func NewAnalyticsModule(db *gorm.DB) *AnalyticsModule {
-	analyticsRepo := repository.NewAnalyticsRepository(db)
-	analyticsService := application.NewAnalyticsService(analyticsRepo)
+func NewAnalyticsModule(serviceContext *ctx2.ServiceContext) *AnalyticsModule {
+	analyticsRepo := repository.NewAnalyticsRepository(serviceContext.GetDB())
+	analyticsService := application.NewAnalyticsService(analyticsRepo, serviceContext.GetIDGenerator())
 
 	return &AnalyticsModule{
 		Repository: analyticsRepo,
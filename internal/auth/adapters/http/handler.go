@@ -3,9 +3,12 @@ package http
 
 import (
 	"music-app-backend/internal/auth/application"
+	model "music-app-backend/internal/auth/domain"
 	appError "music-app-backend/pkg/error"
 	jsonResponse "music-app-backend/pkg/json"
 	"music-app-backend/pkg/jwt"
+	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -17,12 +20,17 @@ type AuthHandler struct {
 
 type LoginRequest struct {
 	SessionToken string `json:"session_token" binding:"required"`
+	DeviceName   string `json:"device_name"` // Shown in the session list; defaults to the user agent
 }
 
-// UPDATED: RefreshTokenRequest now requires session token
+// RefreshTokenRequest carries the opaque refresh token from the last login or refresh
 type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
-	SessionToken string `json:"session_token" binding:"required"` // NEW: Required for security
+}
+
+// PasswordChangedHook is the body of the Kratos after-settings password hook
+type PasswordChangedHook struct {
+	IdentityID string `json:"identity_id" binding:"required"`
 }
 
 func NewAuthHandler(authService *application.AuthService) *AuthHandler {
@@ -53,7 +61,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	response, err := h.authService.VerifySessionAndIssueJWT(request.SessionToken)
+	response, err := h.authService.VerifySessionAndIssueJWT(c.Request.Context(), request.SessionToken, deviceInfo(c, request.DeviceName))
 	if err != nil {
 		h.HandleError(c, err)
 		return
@@ -71,7 +79,7 @@ func (h *AuthHandler) LoginWithCookie(c *gin.Context) {
 		return
 	}
 
-	response, err := h.authService.VerifySessionAndIssueJWT(sessionToken)
+	response, err := h.authService.VerifySessionAndIssueJWT(c.Request.Context(), sessionToken, deviceInfo(c, c.Query("device_name")))
 	if err != nil {
 		h.HandleError(c, err)
 		return
@@ -80,7 +88,7 @@ func (h *AuthHandler) LoginWithCookie(c *gin.Context) {
 	jsonResponse.ResponseOK(c, response)
 }
 
-// FIXED: RefreshToken now validates with Kratos session
+// RefreshToken rotates the refresh token and returns a new access token
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	var request RefreshTokenRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
@@ -88,8 +96,7 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	// SECURITY FIX: Pass both the current JWT and session token for validation
-	response, err := h.authService.RefreshToken(request.RefreshToken, request.SessionToken)
+	response, err := h.authService.RefreshToken(c.Request.Context(), request.RefreshToken, deviceInfo(c, ""))
 	if err != nil {
 		h.HandleError(c, err)
 		return
@@ -98,56 +105,135 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	jsonResponse.ResponseOK(c, response)
 }
 
-// Alternative: RefreshTokenWithCookie for cookie-based session validation
-func (h *AuthHandler) RefreshTokenWithCookie(c *gin.Context) {
-	// Extract refresh token from request body
-	var refreshRequest struct {
-		RefreshToken string `json:"refresh_token" binding:"required"`
+// Me returns current user information from JWT
+func (h *AuthHandler) Me(c *gin.Context) {
+	// Get claims from middleware context
+	claims, exists := c.Get("user_claims")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	userInfo, err := h.authService.GetCurrentUser(claims.(*jwt.Claims))
+	if err != nil {
+		h.HandleError(c, err)
+		return
+	}
+
+	jsonResponse.ResponseOK(c, userInfo)
+}
+
+// Logout ends the current session; its refresh token can no longer be used
+func (h *AuthHandler) Logout(c *gin.Context) {
+	claims, exists := c.Get("user_claims")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	if err := h.authService.Logout(c.Request.Context(), claims.(*jwt.Claims)); err != nil {
+		h.HandleError(c, err)
+		return
+	}
+
+	jsonResponse.ResponseOK(c, gin.H{"message": "Successfully logged out"})
+}
+
+// LogoutAll ends every session of the user and revokes all of their access tokens
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	if err := h.authService.LogoutAll(c.Request.Context(), userID.(uint64)); err != nil {
+		h.HandleError(c, err)
+		return
 	}
-	if err := c.ShouldBindJSON(&refreshRequest); err != nil {
+
+	jsonResponse.ResponseOK(c, gin.H{"message": "Logged out of all sessions"})
+}
+
+// AfterPasswordChange signs the identity out everywhere once Kratos has changed its password
+func (h *AuthHandler) AfterPasswordChange(c *gin.Context) {
+	var request PasswordChangedHook
+	if err := c.ShouldBindJSON(&request); err != nil {
 		jsonResponse.ResponseBadRequest(c, "Invalid request: "+err.Error())
 		return
 	}
 
-	// Extract session token from cookie
-	sessionToken, err := c.Cookie("ory_kratos_session")
+	if err := h.authService.InvalidateIdentityTokens(c.Request.Context(), request.IdentityID, model.SessionRevokePassword); err != nil {
+		h.HandleError(c, err)
+		return
+	}
+
+	jsonResponse.ResponseOK(c, gin.H{"revoked": true})
+}
+
+// ListSessions returns the devices the user is signed in on
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	claims, exists := c.Get("user_claims")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+	current := claims.(*jwt.Claims)
+
+	sessions, err := h.authService.ListSessions(c.Request.Context(), current.UserID, current.SessionID)
 	if err != nil {
-		jsonResponse.ResponseBadRequest(c, "Session cookie required for refresh")
+		h.HandleError(c, err)
+		return
+	}
+
+	jsonResponse.ResponseOK(c, sessions)
+}
+
+// RevokeSession signs one device out
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
 		return
 	}
 
-	response, err := h.authService.RefreshToken(refreshRequest.RefreshToken, sessionToken)
+	sessionID, err := strconv.ParseUint(c.Param("session_id"), 10, 64)
 	if err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid session ID")
+		return
+	}
+
+	if err := h.authService.RevokeSession(c.Request.Context(), userID.(uint64), sessionID); err != nil {
 		h.HandleError(c, err)
 		return
 	}
 
-	jsonResponse.ResponseOK(c, response)
+	jsonResponse.ResponseOK(c, gin.H{"message": "Session revoked"})
 }
 
-// Me returns current user information from JWT
-func (h *AuthHandler) Me(c *gin.Context) {
-	// Get claims from middleware context
+// RevokeOtherSessions signs every device out except the current one
+func (h *AuthHandler) RevokeOtherSessions(c *gin.Context) {
 	claims, exists := c.Get("user_claims")
 	if !exists {
 		jsonResponse.ResponseUnauthorized(c)
 		return
 	}
+	current := claims.(*jwt.Claims)
 
-	userInfo, err := h.authService.GetCurrentUser(claims.(*jwt.Claims))
+	revoked, err := h.authService.RevokeOtherSessions(c.Request.Context(), current.UserID, current.SessionID)
 	if err != nil {
 		h.HandleError(c, err)
 		return
 	}
 
-	jsonResponse.ResponseOK(c, userInfo)
+	jsonResponse.ResponseOK(c, gin.H{"revoked": revoked})
 }
 
-// Logout invalidates the current session (optional - mainly handled by frontend)
-func (h *AuthHandler) Logout(c *gin.Context) {
-	// Since we're using stateless JWT, logout is mainly handled client-side
-	// But we can add token blacklisting here if needed in the future
-	jsonResponse.ResponseOK(c, gin.H{"message": "Successfully logged out"})
+// JWKS publishes the keys access tokens are signed with. Keys are published an hour before
+// they sign, so caching the set for a few minutes is safe.
+func (h *AuthHandler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.authService.JWKS())
 }
 
 // ValidateToken endpoint for other services to validate tokens
@@ -165,7 +251,7 @@ func (h *AuthHandler) ValidateToken(c *gin.Context) {
 		return
 	}
 
-	claims, err := h.authService.ValidateJWT(tokenString)
+	claims, err := h.authService.ValidateJWT(c.Request.Context(), tokenString)
 	if err != nil {
 		h.HandleError(c, err)
 		return
@@ -182,3 +268,11 @@ func (h *AuthHandler) ValidateToken(c *gin.Context) {
 
 	jsonResponse.ResponseOK(c, userInfo)
 }
+
+func deviceInfo(c *gin.Context, name string) *model.DeviceInfo {
+	return &model.DeviceInfo{
+		Name:      name,
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+}
@@ -53,12 +53,18 @@ func (r *AuthRepository) UpdateUserLastLogin(user *userModel.User) error {
 	return r.db.Model(user).Update("last_login_at", user.LastLoginAt).Error
 }
 
-// DeactivateUser deactivates a user account
+// DeactivateUser deactivates a user account on an admin's behalf
 func (r *AuthRepository) DeactivateUser(userID uint64) error {
-	return r.db.Model(&userModel.User{}).Where("id = ?", userID).Update("is_active", false).Error
+	return r.db.Model(&userModel.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+		"is_active":      false,
+		"deactivated_by": userModel.DeactivatedByAdmin,
+	}).Error
 }
 
 // ActivateUser activates a user account
 func (r *AuthRepository) ActivateUser(userID uint64) error {
-	return r.db.Model(&userModel.User{}).Where("id = ?", userID).Update("is_active", true).Error
+	return r.db.Model(&userModel.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+		"is_active":      true,
+		"deactivated_by": nil,
+	}).Error
 }
\ No newline at end of file
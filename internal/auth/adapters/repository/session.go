@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	model "music-app-backend/internal/auth/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Transaction runs fn with a repository bound to a single database transaction
+func (r *AuthRepository) Transaction(ctx context.Context, fn func(txRepo *AuthRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&AuthRepository{db: tx})
+	})
+}
+
+func (r *AuthRepository) CreateSession(ctx context.Context, session *model.Session, token *model.RefreshToken) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(session).Error; err != nil {
+			return err
+		}
+		return tx.Create(token).Error
+	})
+}
+
+// GetRefreshTokenForUpdate locks the token so two refreshes with it cannot both succeed
+func (r *AuthRepository) GetRefreshTokenForUpdate(ctx context.Context, tokenHash string) (*model.RefreshToken, error) {
+	var token model.RefreshToken
+	err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *AuthRepository) GetSession(ctx context.Context, sessionID uint64) (*model.Session, error) {
+	var session model.Session
+	err := r.db.WithContext(ctx).Where("id = ?", sessionID).First(&session).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+// RotateRefreshToken marks token as used and stores its successor
+func (r *AuthRepository) RotateRefreshToken(ctx context.Context, token *model.RefreshToken, next *model.RefreshToken, device *model.DeviceInfo) error {
+	now := time.Now().UTC()
+	db := r.db.WithContext(ctx)
+	if err := db.Model(&model.RefreshToken{}).Where("id = ?", token.ID).Update("rotated_at", now).Error; err != nil {
+		return err
+	}
+	if err := db.Create(next).Error; err != nil {
+		return err
+	}
+
+	updates := map[string]interface{}{
+		"last_used_at": now,
+		"expires_at":   next.ExpiresAt,
+		"updated_at":   now,
+	}
+	if device.UserAgent != "" {
+		updates["user_agent"] = device.UserAgent
+	}
+	if device.IPAddress != "" {
+		updates["ip_address"] = device.IPAddress
+	}
+	return db.Model(&model.Session{}).Where("id = ?", token.SessionID).Updates(updates).Error
+}
+
+// ListActiveSessions returns the user's usable sessions, most recently used first
+func (r *AuthRepository) ListActiveSessions(ctx context.Context, userID uint64) ([]model.Session, error) {
+	var sessions []model.Session
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now().UTC()).
+		Order("last_used_at DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+// RevokeSession revokes one of the user's sessions; it reports false when there was
+// no such active session
+func (r *AuthRepository) RevokeSession(ctx context.Context, userID, sessionID uint64, reason string) (bool, error) {
+	now := time.Now().UTC()
+	result := r.db.WithContext(ctx).Model(&model.Session{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", sessionID, userID).
+		Updates(map[string]interface{}{"revoked_at": now, "revoke_reason": reason, "updated_at": now})
+	return result.RowsAffected > 0, result.Error
+}
+
+// RevokeUserSessions revokes every active session of the user except keepSessionID (0 for
+// none) and returns the IDs of the revoked sessions
+func (r *AuthRepository) RevokeUserSessions(ctx context.Context, userID, keepSessionID uint64, reason string) ([]uint64, error) {
+	var sessionIDs []uint64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.Session{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ? AND id <> ? AND revoked_at IS NULL", userID, keepSessionID).
+			Pluck("id", &sessionIDs).Error
+		if err != nil || len(sessionIDs) == 0 {
+			return err
+		}
+
+		now := time.Now().UTC()
+		return tx.Model(&model.Session{}).Where("id IN ?", sessionIDs).
+			Updates(map[string]interface{}{"revoked_at": now, "revoke_reason": reason, "updated_at": now}).Error
+	})
+	return sessionIDs, err
+}
+
+// DeleteStaleSessions removes sessions, and their tokens, that expired or were revoked
+// before cutoff
+func (r *AuthRepository) DeleteStaleSessions(ctx context.Context, cutoff time.Time) (int64, error) {
+	var deleted int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		stale := tx.Model(&model.Session{}).Select("id").
+			Where("expires_at < ? OR revoked_at < ?", cutoff, cutoff)
+		if err := tx.Where("session_id IN (?)", stale).Delete(&model.RefreshToken{}).Error; err != nil {
+			return err
+		}
+		result := tx.Where("expires_at < ? OR revoked_at < ?", cutoff, cutoff).Delete(&model.Session{})
+		deleted = result.RowsAffected
+		return result.Error
+	})
+	return deleted, err
+}
@@ -2,27 +2,39 @@
 package application
 
 import (
+	"context"
 	"fmt"
 	"music-app-backend/internal/auth/adapters/repository"
+	model "music-app-backend/internal/auth/domain"
+	"music-app-backend/pkg/audit"
 	appError "music-app-backend/pkg/error"
 	"music-app-backend/pkg/jwt"
 	"music-app-backend/pkg/kratos"
 	"time"
 
+	goflakeid "github.com/capy-engineer/go-flakeid"
 	"github.com/google/uuid"
 )
 
 type AuthService struct {
-	authRepo     *repository.AuthRepository
-	kratosClient *kratos.Client
-	jwtService   *jwt.JWTService
+	authRepo        *repository.AuthRepository
+	kratosClient    *kratos.Client
+	jwtService      *jwt.JWTService
+	revocations     *jwt.RevocationList
+	userStatus      *UserStatusCache
+	generator       *goflakeid.Generator
+	auditLog        audit.Logger
+	refreshLifetime time.Duration
 }
 
 type LoginResponse struct {
-	AccessToken string   `json:"access_token"`
-	TokenType   string   `json:"token_type"`
-	ExpiresIn   int      `json:"expires_in"`
-	User        UserInfo `json:"user"`
+	AccessToken      string   `json:"access_token"`
+	TokenType        string   `json:"token_type"`
+	ExpiresIn        int      `json:"expires_in"`
+	RefreshToken     string   `json:"refresh_token"`
+	RefreshExpiresIn int      `json:"refresh_expires_in"`
+	SessionID        uint64   `json:"session_id"`
+	User             UserInfo `json:"user"`
 }
 
 type UserInfo struct {
@@ -34,16 +46,22 @@ type UserInfo struct {
 	IsActive         bool   `json:"is_active"`
 }
 
-func NewAuthService(authRepo *repository.AuthRepository, kratosClient *kratos.Client, jwtService *jwt.JWTService) *AuthService {
+func NewAuthService(authRepo *repository.AuthRepository, kratosClient *kratos.Client, jwtService *jwt.JWTService, revocations *jwt.RevocationList, userStatus *UserStatusCache, generator *goflakeid.Generator, auditLog audit.Logger, refreshLifetime time.Duration) *AuthService {
 	return &AuthService{
-		authRepo:     authRepo,
-		kratosClient: kratosClient,
-		jwtService:   jwtService,
+		authRepo:        authRepo,
+		kratosClient:    kratosClient,
+		jwtService:      jwtService,
+		revocations:     revocations,
+		userStatus:      userStatus,
+		generator:       generator,
+		auditLog:        auditLog,
+		refreshLifetime: refreshLifetime,
 	}
 }
 
-// VerifySessionAndIssueJWT validates Kratos session and issues Audora JWT
-func (s *AuthService) VerifySessionAndIssueJWT(sessionToken string) (*LoginResponse, error) {
+// VerifySessionAndIssueJWT validates the Kratos session and signs the device in: it
+// creates an Audora session and returns an access token with its first refresh token
+func (s *AuthService) VerifySessionAndIssueJWT(ctx context.Context, sessionToken string, device *model.DeviceInfo) (*LoginResponse, error) {
 	// Step 1: Verify session with Kratos
 	session, err := s.kratosClient.VerifySession(sessionToken)
 	if err != nil {
@@ -77,142 +95,67 @@ func (s *AuthService) VerifySessionAndIssueJWT(sessionToken string) (*LoginRespo
 		fmt.Printf("Failed to update last login time: %v\n", err)
 	}
 
-	// Step 5: Generate Audora JWT
-	tokenLifetime := 24 * time.Hour // 24 hours
-	accessToken, err := s.jwtService.GenerateToken(
-		user.ID,
-		user.KratosIdentityID.String(),
-		user.Email,
-		user.UserType,
-		user.DisplayName,
-		user.IsActive,
-	)
+	// Step 5: Start a device session
+	deviceSession, refreshToken, err := s.createSession(ctx, user.ID, device)
 	if err != nil {
-		return nil, appError.NewInternalError(err, "failed to generate access token")
+		return nil, appError.NewInternalError(err, "failed to create session")
 	}
 
-	// Step 6: Return response
-	return &LoginResponse{
-		AccessToken: accessToken,
-		TokenType:   "Bearer",
-		ExpiresIn:   int(tokenLifetime.Seconds()),
-		User: UserInfo{
-			ID:               user.ID,
-			KratosIdentityID: user.KratosIdentityID.String(),
-			Email:            user.Email,
-			DisplayName:      user.DisplayName,
-			UserType:         user.UserType,
-			IsActive:         user.IsActive,
-		},
-	}, nil
+	// Step 6: Generate Audora JWT
+	return s.issueTokens(user, deviceSession, refreshToken)
 }
 
-// ValidateJWT validates an Audora JWT token and checks user status
-func (s *AuthService) ValidateJWT(tokenString string) (*jwt.Claims, error) {
+// ValidateJWT validates an Audora JWT token and checks it was not revoked and the user is active
+func (s *AuthService) ValidateJWT(ctx context.Context, tokenString string) (*jwt.Claims, error) {
 	claims, err := s.jwtService.ValidateToken(tokenString)
 	if err != nil {
 		return nil, appError.NewUnauthorizedError(err, "invalid or expired token")
 	}
 
-	// CRITICAL: Always check if user is still active in database
-	kratosIdentityID, err := uuid.Parse(claims.KratosIdentityID)
+	// Fail closed: a token that cannot be checked against the revocation list is rejected
+	revoked, err := s.revocations.IsRevoked(ctx, claims)
 	if err != nil {
-		return nil, appError.NewUnauthorizedError(err, "invalid identity ID in token")
+		return nil, appError.NewUnauthorizedError(err, "unable to check token revocation")
+	}
+	if revoked {
+		return nil, appError.NewUnauthorizedError(nil, "token has been revoked")
 	}
 
-	user, err := s.authRepo.FindUserByKratosIdentityID(kratosIdentityID)
+	// CRITICAL: Always check the user still exists and is active. The status comes from
+	// the user status cache, which is invalidated whenever it changes.
+	status, err := s.userStatus.Get(ctx, claims.UserID)
 	if err != nil {
-		return nil, appError.NewUnauthorizedError(err, "user not found")
+		return nil, appError.NewUnauthorizedError(err, "unable to check user status")
 	}
-
-	if !user.IsActive {
+	if !status.Exists || status.KratosIdentityID != claims.KratosIdentityID {
+		return nil, appError.NewUnauthorizedError(nil, "user not found")
+	}
+	if !status.IsActive {
 		return nil, appError.NewForbiddenError(nil, "user account is deactivated")
 	}
 
+	// Role changes apply immediately rather than at the next token refresh
+	claims.UserType = status.UserType
 	return claims, nil
 }
 
-// FIXED: RefreshToken now validates Kratos session before issuing new JWT
-func (s *AuthService) RefreshToken(currentTokenString string, sessionToken string) (*LoginResponse, error) {
-	// Step 1: Validate the current JWT to get user info (but allow expired tokens for refresh)
-	claims, err := s.jwtService.ValidateToken(currentTokenString)
-	if err != nil {
-		// Allow parsing of expired tokens for refresh, but still validate signature
-		claims, err = s.parseExpiredToken(currentTokenString)
-		if err != nil {
-			return nil, appError.NewUnauthorizedError(err, "invalid token for refresh")
-		}
-	}
-
-	// Step 2: CRITICAL - Re-validate with Kratos session
-	// This ensures the Kratos session is still valid and not revoked
-	if sessionToken == "" {
-		return nil, appError.NewUnauthorizedError(nil, "session token required for refresh")
-	}
-
-	session, err := s.kratosClient.VerifySession(sessionToken)
-	if err != nil {
-		if kratosErr, ok := err.(*kratos.KratosError); ok {
-			return nil, appError.NewUnauthorizedError(err, "kratos session invalid: "+kratosErr.Message)
-		}
-		return nil, appError.NewInternalError(err, "failed to verify session with Kratos during refresh")
-	}
-
-	// Step 3: Ensure the Kratos identity matches the JWT claims
-	if session.Identity.ID != claims.KratosIdentityID {
-		return nil, appError.NewUnauthorizedError(nil, "session identity mismatch")
-	}
-
-	// Step 4: Re-fetch user from database to get latest data
-	kratosIdentityID, err := uuid.Parse(session.Identity.ID)
-	if err != nil {
-		return nil, appError.NewBadRequestError(err, "invalid kratos identity ID")
-	}
-
-	user, err := s.authRepo.FindUserByKratosIdentityID(kratosIdentityID)
-	if err != nil {
-		return nil, appError.NewNotFoundError(err, "user not found in Audora database")
-	}
-
-	// Step 5: Validate user is still active
-	if !user.IsActive {
-		return nil, appError.NewForbiddenError(nil, "user account is deactivated")
-	}
-
-	// Step 6: Generate new JWT with FRESH user data
-	tokenLifetime := 24 * time.Hour
-	newToken, err := s.jwtService.GenerateToken(
-		user.ID,
-		user.KratosIdentityID.String(),
-		user.Email,       // Fresh from DB
-		user.UserType,    // Fresh from DB
-		user.DisplayName, // Fresh from DB
-		user.IsActive,    // Fresh from DB
-	)
-	if err != nil {
-		return nil, appError.NewInternalError(err, "failed to generate new token")
+// InvalidateUserStatus makes every replica reload the user's status on its next request.
+// Call it after changing whether the user is active, their role, or deleting them.
+func (s *AuthService) InvalidateUserStatus(ctx context.Context, userID uint64) error {
+	if err := s.userStatus.Invalidate(ctx, userID); err != nil {
+		return appError.NewInternalError(err, "failed to invalidate user status")
 	}
+	return nil
+}
 
-	return &LoginResponse{
-		AccessToken: newToken,
-		TokenType:   "Bearer",
-		ExpiresIn:   int(tokenLifetime.Seconds()),
-		User: UserInfo{
-			ID:               user.ID,
-			KratosIdentityID: user.KratosIdentityID.String(),
-			Email:            user.Email,
-			DisplayName:      user.DisplayName,
-			UserType:         user.UserType,
-			IsActive:         user.IsActive,
-		},
-	}, nil
+// StartUserStatusListener keeps this replica's user status cache in sync with the others
+func (s *AuthService) StartUserStatusListener(ctx context.Context) {
+	s.userStatus.StartInvalidationListener(ctx)
 }
 
-// Helper method to parse expired tokens (for refresh only)
-func (s *AuthService) parseExpiredToken(tokenString string) (*jwt.Claims, error) {
-	// This should parse the token without validating expiration
-	// Implementation depends on your JWT library
-	return s.jwtService.ValidateTokenIgnoringExpiry(tokenString)
+// JWKS returns the public keys for validating access tokens
+func (s *AuthService) JWKS() *jwt.JWKS {
+	return s.jwtService.JWKS()
 }
 
 // GetCurrentUser returns user info from JWT claims
@@ -0,0 +1,361 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log"
+	"music-app-backend/internal/auth/adapters/repository"
+	model "music-app-backend/internal/auth/domain"
+	userModel "music-app-backend/internal/user/domain"
+	"music-app-backend/pkg/audit"
+	appError "music-app-backend/pkg/error"
+	"music-app-backend/pkg/jwt"
+	baseModel "music-app-backend/pkg/model"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	refreshTokenBytes    = 32
+	maxDeviceNameLength  = 100
+	staleSessionRetained = 30 * 24 * time.Hour // Revoked and expired sessions are kept this long for reuse detection
+)
+
+// RefreshToken exchanges a refresh token for a new access token and a new refresh token.
+// Each refresh token works once: presenting one that was already exchanged means it was
+// copied, so the whole session is revoked and both holders have to sign in again.
+func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string, device *model.DeviceInfo) (*LoginResponse, error) {
+	tokenHash := hashRefreshToken(refreshToken)
+
+	var session *model.Session
+	var nextToken string
+	reused := false
+	err := s.authRepo.Transaction(ctx, func(txRepo *repository.AuthRepository) error {
+		token, err := txRepo.GetRefreshTokenForUpdate(ctx, tokenHash)
+		if err != nil {
+			return err
+		}
+		if token == nil {
+			return appError.NewUnauthorizedError(nil, "invalid refresh token")
+		}
+
+		session, err = txRepo.GetSession(ctx, token.SessionID)
+		if err != nil {
+			return err
+		}
+		if reused, err = checkRefreshToken(token, session, time.Now().UTC()); err != nil || reused {
+			return err
+		}
+
+		var next *model.RefreshToken
+		nextToken, next, err = s.newRefreshToken(session.ID)
+		if err != nil {
+			return err
+		}
+		if err := txRepo.RotateRefreshToken(ctx, token, next, device); err != nil {
+			return err
+		}
+		session.ExpiresAt = next.ExpiresAt
+		return nil
+	})
+	if err != nil {
+		if appError.IsAppError(err) {
+			return nil, err
+		}
+		return nil, appError.NewInternalError(err, "failed to refresh session")
+	}
+
+	if reused {
+		s.revokeReusedSession(ctx, session)
+		return nil, appError.NewUnauthorizedError(nil, "refresh token was already used; session revoked")
+	}
+
+	user, err := s.authRepo.FindUserByID(session.UserID)
+	if err != nil {
+		return nil, appError.NewUnauthorizedError(err, "user not found")
+	}
+	if !user.IsActive {
+		return nil, appError.NewForbiddenError(nil, "user account is deactivated")
+	}
+	return s.issueTokens(user, session, nextToken)
+}
+
+// checkRefreshToken decides whether a stored refresh token may be exchanged. A token
+// that was already rotated is reported as reused, but only while its session is still
+// usable: once the session has ended there is nothing left to revoke.
+func checkRefreshToken(token *model.RefreshToken, session *model.Session, now time.Time) (bool, error) {
+	if session == nil || !session.IsUsable(now) {
+		return false, appError.NewUnauthorizedError(nil, "session has ended")
+	}
+	if token.RotatedAt != nil {
+		return true, nil
+	}
+	if !now.Before(token.ExpiresAt) {
+		return false, appError.NewUnauthorizedError(nil, "refresh token has expired")
+	}
+	return false, nil
+}
+
+func (s *AuthService) revokeReusedSession(ctx context.Context, session *model.Session) {
+	if _, err := s.authRepo.RevokeSession(ctx, session.UserID, session.ID, model.SessionRevokeTokenReuse); err != nil {
+		log.Printf("Failed to revoke session %d after refresh token reuse: %v", session.ID, err)
+		return
+	}
+	s.revokeSessionTokens(ctx, session.ID)
+	userID := session.UserID
+	audit.Write(ctx, s.auditLog, &audit.Entry{
+		ActorID:    &userID,
+		ActorType:  audit.ActorTypeSystem,
+		Action:     "auth.refresh_token_reuse",
+		TargetType: "session",
+		TargetID:   strconv.FormatUint(session.ID, 10),
+		Before:     map[string]interface{}{"revoked": false},
+		After:      map[string]interface{}{"revoked": true, "reason": model.SessionRevokeTokenReuse},
+	})
+}
+
+// Logout ends the session the access token was issued for: the token itself and the
+// session's refresh token stop working
+func (s *AuthService) Logout(ctx context.Context, claims *jwt.Claims) error {
+	if err := s.revocations.RevokeToken(ctx, claims); err != nil {
+		return appError.NewInternalError(err, "failed to revoke token")
+	}
+	if claims.SessionID == 0 {
+		return nil
+	}
+	if _, err := s.authRepo.RevokeSession(ctx, claims.UserID, claims.SessionID, model.SessionRevokeLogout); err != nil {
+		return appError.NewInternalError(err, "failed to end session")
+	}
+	s.revokeSessionTokens(ctx, claims.SessionID)
+	return nil
+}
+
+// LogoutAll signs the user out everywhere, including the device making the request
+func (s *AuthService) LogoutAll(ctx context.Context, userID uint64) error {
+	return s.InvalidateUserTokens(ctx, userID, model.SessionRevokeLogoutAll)
+}
+
+// InvalidateUserTokens ends every session of the user and rejects all access tokens issued
+// so far. It runs on logout-all, password changes and account deactivation.
+func (s *AuthService) InvalidateUserTokens(ctx context.Context, userID uint64, reason string) error {
+	if err := s.revocations.RevokeUserTokens(ctx, userID, time.Now()); err != nil {
+		return appError.NewInternalError(err, "failed to revoke tokens")
+	}
+	sessionIDs, err := s.authRepo.RevokeUserSessions(ctx, userID, 0, reason)
+	if err != nil {
+		return appError.NewInternalError(err, "failed to end sessions")
+	}
+
+	audit.Write(ctx, s.auditLog, &audit.Entry{
+		Action:     "auth.tokens_invalidate",
+		TargetType: "user",
+		TargetID:   strconv.FormatUint(userID, 10),
+		Metadata:   map[string]interface{}{"reason": reason, "sessions_revoked": len(sessionIDs)},
+	})
+	return nil
+}
+
+// InvalidateIdentityTokens is InvalidateUserTokens for a Kratos identity
+func (s *AuthService) InvalidateIdentityTokens(ctx context.Context, kratosIdentityID string, reason string) error {
+	identityID, err := uuid.Parse(kratosIdentityID)
+	if err != nil {
+		return appError.NewBadRequestError(err, "invalid kratos identity ID")
+	}
+	user, err := s.authRepo.FindUserByKratosIdentityID(identityID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return appError.NewNotFoundError(err, "user not found")
+		}
+		return appError.NewInternalError(err, "failed to load user")
+	}
+	return s.InvalidateUserTokens(ctx, user.ID, reason)
+}
+
+// revokeSessionTokens rejects the session's access tokens. Failures are logged only: the
+// session is already revoked, so its tokens run out within one access token lifetime.
+func (s *AuthService) revokeSessionTokens(ctx context.Context, sessionIDs ...uint64) {
+	for _, sessionID := range sessionIDs {
+		if err := s.revocations.RevokeSession(ctx, sessionID); err != nil {
+			log.Printf("Failed to revoke access tokens of session %d: %v", sessionID, err)
+		}
+	}
+}
+
+// ListSessions returns the user's signed-in devices, marking the one making the request
+func (s *AuthService) ListSessions(ctx context.Context, userID, currentSessionID uint64) ([]model.SessionView, error) {
+	sessions, err := s.authRepo.ListActiveSessions(ctx, userID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to list sessions")
+	}
+	views := make([]model.SessionView, 0, len(sessions))
+	for _, session := range sessions {
+		views = append(views, model.SessionView{Session: session, Current: session.ID == currentSessionID})
+	}
+	return views, nil
+}
+
+// RevokeSession signs one of the user's devices out
+func (s *AuthService) RevokeSession(ctx context.Context, userID, sessionID uint64) error {
+	revoked, err := s.authRepo.RevokeSession(ctx, userID, sessionID, model.SessionRevokeUser)
+	if err != nil {
+		return appError.NewInternalError(err, "failed to revoke session")
+	}
+	if !revoked {
+		return appError.NewNotFoundError(nil, "session not found")
+	}
+	s.revokeSessionTokens(ctx, sessionID)
+	audit.Write(ctx, s.auditLog, &audit.Entry{
+		Action:     "auth.session_revoke",
+		TargetType: "session",
+		TargetID:   strconv.FormatUint(sessionID, 10),
+	})
+	return nil
+}
+
+// RevokeOtherSessions signs every device out except the one making the request
+func (s *AuthService) RevokeOtherSessions(ctx context.Context, userID, currentSessionID uint64) (int64, error) {
+	sessionIDs, err := s.authRepo.RevokeUserSessions(ctx, userID, currentSessionID, model.SessionRevokeUser)
+	if err != nil {
+		return 0, appError.NewInternalError(err, "failed to revoke sessions")
+	}
+	s.revokeSessionTokens(ctx, sessionIDs...)
+
+	revoked := int64(len(sessionIDs))
+	if revoked > 0 {
+		audit.Write(ctx, s.auditLog, &audit.Entry{
+			Action:     "auth.session_revoke_others",
+			TargetType: "user",
+			TargetID:   strconv.FormatUint(userID, 10),
+			Metadata:   map[string]interface{}{"revoked": revoked},
+		})
+	}
+	return revoked, nil
+}
+
+// StartSessionCleanup deletes long-dead sessions and their tokens on every tick until ctx is cancelled
+func (s *AuthService) StartSessionCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				deleted, err := s.authRepo.DeleteStaleSessions(ctx, time.Now().UTC().Add(-staleSessionRetained))
+				if err != nil {
+					log.Printf("Session cleanup failed: %v", err)
+					continue
+				}
+				if deleted > 0 {
+					log.Printf("Session cleanup removed %d sessions", deleted)
+				}
+			}
+		}
+	}()
+}
+
+func (s *AuthService) createSession(ctx context.Context, userID uint64, device *model.DeviceInfo) (*model.Session, string, error) {
+	_base, err := baseModel.NewBaseModel(s.generator)
+	if err != nil {
+		return nil, "", err
+	}
+	refreshToken, token, err := s.newRefreshToken(_base.ID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	session := &model.Session{
+		BaseModel:  *_base,
+		UserID:     userID,
+		DeviceName: deviceName(device),
+		UserAgent:  device.UserAgent,
+		IPAddress:  device.IPAddress,
+		LastUsedAt: _base.CreatedAt,
+		ExpiresAt:  token.ExpiresAt,
+	}
+	if err := s.authRepo.CreateSession(ctx, session, token); err != nil {
+		return nil, "", err
+	}
+	return session, refreshToken, nil
+}
+
+// newRefreshToken returns a random token for the client and the hashed row to store
+func (s *AuthService) newRefreshToken(sessionID uint64) (string, *model.RefreshToken, error) {
+	id, err := s.generator.Generate()
+	if err != nil {
+		return "", nil, err
+	}
+	raw := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", nil, err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	now := time.Now().UTC()
+	return token, &model.RefreshToken{
+		ID:        id,
+		SessionID: sessionID,
+		TokenHash: hashRefreshToken(token),
+		ExpiresAt: now.Add(s.refreshLifetime),
+		CreatedAt: now,
+	}, nil
+}
+
+func (s *AuthService) issueTokens(user *userModel.User, session *model.Session, refreshToken string) (*LoginResponse, error) {
+	accessToken, err := s.jwtService.GenerateToken(
+		user.ID,
+		user.KratosIdentityID.String(),
+		user.Email,
+		user.UserType,
+		user.DisplayName,
+		user.IsActive,
+		session.ID,
+	)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to generate access token")
+	}
+
+	return &LoginResponse{
+		AccessToken:      accessToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        int(s.jwtService.TokenLifetime().Seconds()),
+		RefreshToken:     refreshToken,
+		RefreshExpiresIn: int(time.Until(session.ExpiresAt).Seconds()),
+		SessionID:        session.ID,
+		User: UserInfo{
+			ID:               user.ID,
+			KratosIdentityID: user.KratosIdentityID.String(),
+			Email:            user.Email,
+			DisplayName:      user.DisplayName,
+			UserType:         user.UserType,
+			IsActive:         user.IsActive,
+		},
+	}, nil
+}
+
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// deviceName is the client's label for the device, falling back to its user agent
+func deviceName(device *model.DeviceInfo) string {
+	name := strings.TrimSpace(device.Name)
+	if name == "" {
+		name = device.UserAgent
+	}
+	if utf8.RuneCountInString(name) > maxDeviceNameLength {
+		name = string([]rune(name)[:maxDeviceNameLength])
+	}
+	return name
+}
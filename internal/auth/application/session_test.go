@@ -0,0 +1,85 @@
+package application
+
+import (
+	model "music-app-backend/internal/auth/domain"
+	appError "music-app-backend/pkg/error"
+	"testing"
+	"time"
+)
+
+func TestCheckRefreshToken(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	earlier := now.Add(-time.Minute)
+	usableSession := &model.Session{ExpiresAt: now.Add(time.Hour)}
+
+	tests := []struct {
+		name       string
+		token      *model.RefreshToken
+		session    *model.Session
+		wantReused bool
+		wantErr    bool
+	}{
+		{
+			name:    "fresh token",
+			token:   &model.RefreshToken{ExpiresAt: now.Add(time.Hour)},
+			session: usableSession,
+		},
+		{
+			name:       "token already rotated",
+			token:      &model.RefreshToken{ExpiresAt: now.Add(time.Hour), RotatedAt: &earlier},
+			session:    usableSession,
+			wantReused: true,
+		},
+		{
+			name:       "rotated token past its expiry is still reuse",
+			token:      &model.RefreshToken{ExpiresAt: earlier, RotatedAt: &earlier},
+			session:    usableSession,
+			wantReused: true,
+		},
+		{
+			name:    "expired token",
+			token:   &model.RefreshToken{ExpiresAt: now},
+			session: usableSession,
+			wantErr: true,
+		},
+		{
+			name:    "session missing",
+			token:   &model.RefreshToken{ExpiresAt: now.Add(time.Hour)},
+			session: nil,
+			wantErr: true,
+		},
+		{
+			name:    "session revoked",
+			token:   &model.RefreshToken{ExpiresAt: now.Add(time.Hour), RotatedAt: &earlier},
+			session: &model.Session{ExpiresAt: now.Add(time.Hour), RevokedAt: &earlier},
+			wantErr: true,
+		},
+		{
+			name:    "session expired",
+			token:   &model.RefreshToken{ExpiresAt: now.Add(time.Hour)},
+			session: &model.Session{ExpiresAt: now},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reused, err := checkRefreshToken(tt.token, tt.session, now)
+			if tt.wantErr {
+				if !appError.IsAppError(err) {
+					t.Fatalf("err = %v, want an app error", err)
+				}
+				if reused {
+					t.Fatalf("reused = true alongside error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if reused != tt.wantReused {
+				t.Fatalf("reused = %t, want %t", reused, tt.wantReused)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"music-app-backend/internal/auth/adapters/repository"
+	"music-app-backend/pkg/cache"
+	"music-app-backend/pkg/redis"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	userStatusKeyPrefix      = "auth:user_status:"
+	userStatusGenKeyPrefix   = "auth:user_status_gen:"
+	userStatusInvalidateChan = "auth:user_status:invalidate"
+
+	localUserStatusTTL = 30 * time.Second
+	redisUserStatusTTL = 2 * time.Minute
+	// Outlives any load in flight; an expired generation still reads as a change
+	userStatusGenTTL = 24 * time.Hour
+)
+
+// UserStatus is what every authenticated request needs to know about its user
+type UserStatus struct {
+	Exists           bool   `json:"exists"`
+	KratosIdentityID string `json:"kratos_identity_id,omitempty"`
+	UserType         string `json:"user_type,omitempty"`
+	IsActive         bool   `json:"is_active"`
+}
+
+// UserStatusCache keeps user status in process and in Redis so token validation does not
+// query the database on every request. Changes are pushed to every replica over pub/sub;
+// the TTLs bound staleness if a message is lost.
+//
+// A status loaded before an invalidation must not be cached after it, or the change would
+// only apply once the TTLs run out. Invalidate bumps a per-user generation in Redis, which
+// guards the Redis write, and a process-wide generation, which guards the local one.
+type UserStatusCache struct {
+	load        func(userID uint64) (*UserStatus, error)
+	redisClient *redis.Client
+	local       *cache.Cache
+	generation  atomic.Uint64
+}
+
+func NewUserStatusCache(authRepo *repository.AuthRepository, redisClient *redis.Client) *UserStatusCache {
+	return &UserStatusCache{
+		load:        func(userID uint64) (*UserStatus, error) { return loadUserStatus(authRepo, userID) },
+		redisClient: redisClient,
+		local:       cache.NewCache(localUserStatusTTL),
+	}
+}
+
+// Get returns the user's status from the nearest level that has it
+func (c *UserStatusCache) Get(ctx context.Context, userID uint64) (*UserStatus, error) {
+	key := userStatusKeyPrefix + strconv.FormatUint(userID, 10)
+	if value, ok := c.local.Get(key); ok {
+		return value.(*UserStatus), nil
+	}
+	localGeneration := c.generation.Load()
+
+	genKey := userStatusGenKeyPrefix + strconv.FormatUint(userID, 10)
+	values, readErr := c.redisClient.GetMany(ctx, key, genKey)
+	if readErr == nil && values[0] != "" {
+		status := &UserStatus{}
+		if err := json.Unmarshal([]byte(values[0]), status); err == nil {
+			c.setLocal(key, status, localGeneration)
+			return status, nil
+		}
+	}
+
+	status, err := c.load(userID)
+	if err != nil {
+		return nil, err
+	}
+	c.setLocal(key, status, localGeneration)
+	// Without the generation read the write cannot be guarded, so it is skipped
+	if readErr != nil {
+		return status, nil
+	}
+	if payload, err := json.Marshal(status); err == nil {
+		if _, err := c.redisClient.SetIfUnchanged(ctx, key, payload, redisUserStatusTTL, genKey, values[1]); err != nil {
+			log.Printf("Failed to cache status of user %d: %v", userID, err)
+		}
+	}
+	return status, nil
+}
+
+// setLocal caches status unless an invalidation arrived since generation was read
+func (c *UserStatusCache) setLocal(key string, status *UserStatus, generation uint64) {
+	if c.generation.Load() == generation {
+		c.local.Set(key, status, localUserStatusTTL)
+	}
+}
+
+// dropLocal forgets the local entry and stops loads already in flight from caching theirs
+func (c *UserStatusCache) dropLocal(key string) {
+	c.generation.Add(1)
+	c.local.Delete(key)
+}
+
+func loadUserStatus(authRepo *repository.AuthRepository, userID uint64) (*UserStatus, error) {
+	user, err := authRepo.FindUserByID(userID)
+	if err != nil {
+		// Missing users are cached too, so a deleted account's tokens stay cheap to reject
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &UserStatus{}, nil
+		}
+		return nil, err
+	}
+	return &UserStatus{
+		Exists:           true,
+		KratosIdentityID: user.KratosIdentityID.String(),
+		UserType:         user.UserType,
+		IsActive:         user.IsActive,
+	}, nil
+}
+
+// Invalidate drops the user's status everywhere. Call it after deactivation, reactivation,
+// role changes and deletion.
+func (c *UserStatusCache) Invalidate(ctx context.Context, userID uint64) error {
+	id := strconv.FormatUint(userID, 10)
+	// Dropped last: a load that reads the local generation after the bump must find Redis
+	// already cleared
+	defer c.dropLocal(userStatusKeyPrefix + id)
+	if _, err := c.redisClient.IncrWithExpire(ctx, userStatusGenKeyPrefix+id, userStatusGenTTL); err != nil {
+		return err
+	}
+	if err := c.redisClient.Del(ctx, userStatusKeyPrefix+id); err != nil {
+		return err
+	}
+	return c.redisClient.Publish(ctx, userStatusInvalidateChan, id)
+}
+
+// StartInvalidationListener drops local entries other replicas invalidated until ctx is cancelled
+func (c *UserStatusCache) StartInvalidationListener(ctx context.Context) {
+	pubsub := c.redisClient.Subscribe(ctx, userStatusInvalidateChan)
+	go func() {
+		defer pubsub.Close()
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message, ok := <-messages:
+				if !ok {
+					return
+				}
+				c.dropLocal(userStatusKeyPrefix + message.Payload)
+			}
+		}
+	}()
+}
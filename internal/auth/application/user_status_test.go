@@ -0,0 +1,73 @@
+package application
+
+import (
+	"context"
+	"music-app-backend/pkg/cache"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+func newLocalUserStatusCache(load func(userID uint64) (*UserStatus, error)) *UserStatusCache {
+	return &UserStatusCache{
+		load:  load,
+		local: cache.NewCache(localUserStatusTTL),
+	}
+}
+
+func TestUserStatusCacheSetLocal(t *testing.T) {
+	tests := []struct {
+		name         string
+		invalidate   bool
+		expectCached bool
+	}{
+		{name: "no invalidation in between", invalidate: false, expectCached: true},
+		{name: "invalidated while loading", invalidate: true, expectCached: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newLocalUserStatusCache(nil)
+			key := userStatusKeyPrefix + "42"
+
+			generation := c.generation.Load()
+			if tt.invalidate {
+				c.dropLocal(key)
+			}
+			c.setLocal(key, &UserStatus{Exists: true, IsActive: true}, generation)
+
+			if _, cached := c.local.Get(key); cached != tt.expectCached {
+				t.Fatalf("cached = %t, want %t", cached, tt.expectCached)
+			}
+		})
+	}
+}
+
+// BenchmarkUserStatusCacheGet measures the path every authenticated request takes once a
+// user's status is cached: no Redis or database round trip, only a read-locked map lookup.
+func BenchmarkUserStatusCacheGet(b *testing.B) {
+	const users = 10000
+	var loads atomic.Int64
+	c := newLocalUserStatusCache(func(userID uint64) (*UserStatus, error) {
+		loads.Add(1)
+		return &UserStatus{Exists: true, IsActive: true}, nil
+	})
+	for id := uint64(0); id < users; id++ {
+		c.local.Set(userStatusKeyPrefix+strconv.FormatUint(id, 10), &UserStatus{Exists: true, IsActive: true}, localUserStatusTTL)
+	}
+
+	ctx := context.Background()
+	var next atomic.Uint64
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := c.Get(ctx, next.Add(1)%users); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	if loads.Load() != 0 {
+		b.Fatalf("cached lookups reached the loader %d times", loads.Load())
+	}
+}
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"music-app-backend/pkg/model"
+	"time"
+)
+
+const (
+	SessionRevokeLogout         = "logout"
+	SessionRevokeLogoutAll      = "logout_all"
+	SessionRevokePassword       = "password_changed"
+	SessionRevokeUser           = "revoked_by_user"
+	SessionRevokeTokenReuse     = "refresh_token_reuse"
+	SessionRevokeDeactivation   = "account_deactivated"
+	SessionRevokeIdentity       = "identity_disabled" // Disabled or deleted in Kratos
+	SessionRevokeAccountDeleted = "account_deleted"
+)
+
+// Session is a signed-in device. Its refresh tokens form one family: each refresh rotates
+// the token, and presenting a token that was already rotated revokes the whole session.
+type Session struct {
+	model.BaseModel
+	UserID       uint64     `json:"-" gorm:"not null;index"`
+	DeviceName   string     `json:"device_name" gorm:"size:100"`
+	UserAgent    string     `json:"user_agent"`
+	IPAddress    string     `json:"ip_address" gorm:"size:45"`
+	LastUsedAt   time.Time  `json:"last_used_at"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	RevokedAt    *time.Time `json:"-"`
+	RevokeReason *string    `json:"-"`
+}
+
+func (Session) TableName() string {
+	return "auth_sessions"
+}
+
+// IsUsable reports whether the session may still issue tokens
+func (s *Session) IsUsable(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// RefreshToken is stored as a SHA-256 hash; the token itself is only ever sent to the client
+type RefreshToken struct {
+	ID        uint64     `gorm:"primaryKey"`
+	SessionID uint64     `gorm:"not null;index"`
+	TokenHash string     `gorm:"not null;size:64;uniqueIndex"`
+	ExpiresAt time.Time  `gorm:"not null"`
+	RotatedAt *time.Time // Set once the token was exchanged for a new one
+	CreatedAt time.Time
+}
+
+func (RefreshToken) TableName() string {
+	return "auth_refresh_tokens"
+}
+
+// DeviceInfo describes the client a session is created or refreshed from
+type DeviceInfo struct {
+	Name      string
+	UserAgent string
+	IPAddress string
+}
+
+// SessionView is a session as listed to its owner
+type SessionView struct {
+	Session
+	Current bool `json:"current"`
+}
@@ -2,9 +2,12 @@
 package auth
 
 import (
+	"context"
+	"log"
 	"music-app-backend/internal/auth/adapters/http"
 	"music-app-backend/internal/auth/adapters/repository"
 	"music-app-backend/internal/auth/application"
+	ctx2 "music-app-backend/pkg/context"
 	"music-app-backend/pkg/jwt"
 	"music-app-backend/pkg/kratos"
 	"music-app-backend/pkg/middleware"
@@ -12,7 +15,6 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
 type AuthModule struct {
@@ -22,9 +24,13 @@ type AuthModule struct {
 	Middleware   *middleware.AuthMiddleware
 	KratosClient *kratos.Client
 	JWTService   *jwt.JWTService
+	// KratosWebhook authenticates the endpoints Kratos calls back
+	KratosWebhook gin.HandlerFunc
+
+	signingKeys *jwt.KeyManager
 }
 
-func NewAuthModule(db *gorm.DB) *AuthModule {
+func NewAuthModule(serviceContext *ctx2.ServiceContext) *AuthModule {
 	// Initialize Kratos client
 	kratosPublicURL := os.Getenv("KRATOS_PUBLIC_URL")
 	kratosAdminURL := os.Getenv("KRATOS_ADMIN_URL")
@@ -35,31 +41,50 @@ func NewAuthModule(db *gorm.DB) *AuthModule {
 		kratosAdminURL = "http://localhost:4434"
 	}
 	kratosClient := kratos.NewClient(kratosPublicURL, kratosAdminURL)
+	webhookConfig, err := kratos.WebhookConfigFromEnv()
+	if err != nil {
+		log.Fatalf("Invalid Kratos webhook configuration: %v", err)
+	}
 
-	// Initialize JWT service
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		jwtSecret = "your-jwt-secret-here" // Default for development
+	// Initialize JWT service. Access tokens are short-lived; clients keep signed in with
+	// the rotating refresh token.
+	accessLifetime := durationFromEnv("ACCESS_TOKEN_TTL", 15*time.Minute)
+	refreshLifetime := durationFromEnv("REFRESH_TOKEN_TTL", 30*24*time.Hour)
+	keyConfig, err := jwt.KeyConfigFromEnv()
+	if err != nil {
+		log.Fatalf("Invalid JWT signing configuration: %v", err)
+	}
+	signingKeys, err := jwt.NewKeyManager(context.Background(), serviceContext.GetDB(), keyConfig, accessLifetime)
+	if err != nil {
+		log.Fatalf("Failed to load JWT signing keys: %v", err)
 	}
-	jwtService := jwt.NewJWTService(jwtSecret, "audora-api", 24*time.Hour)
+	jwtService := jwt.NewJWTService(signingKeys, "audora-api", accessLifetime)
+	revocations := jwt.NewRevocationList(serviceContext.GetRedisClient(), accessLifetime)
 
 	// Initialize repository, service, handler, and middleware
-	authRepo := repository.NewAuthRepository(db)
-	authService := application.NewAuthService(authRepo, kratosClient, jwtService)
+	authRepo := repository.NewAuthRepository(serviceContext.GetDB())
+	userStatus := application.NewUserStatusCache(authRepo, serviceContext.GetRedisClient())
+	authService := application.NewAuthService(authRepo, kratosClient, jwtService, revocations, userStatus, serviceContext.GetIDGenerator(), serviceContext.GetAuditLog(), refreshLifetime)
 	authHandler := http.NewAuthHandler(authService)
 	authMiddleware := middleware.NewAuthMiddleware(authService)
+	authMiddleware.SetAuditLogger(serviceContext.GetAuditLog())
 
 	return &AuthModule{
-		Repository:   authRepo,
-		Service:      authService,
-		Handler:      authHandler,
-		Middleware:   authMiddleware,
-		KratosClient: kratosClient,
-		JWTService:   jwtService,
+		Repository:    authRepo,
+		Service:       authService,
+		Handler:       authHandler,
+		Middleware:    authMiddleware,
+		KratosClient:  kratosClient,
+		JWTService:    jwtService,
+		KratosWebhook: middleware.KratosWebhook(webhookConfig),
+		signingKeys:   signingKeys,
 	}
 }
 
 func (a *AuthModule) RegisterRoutes(router *gin.RouterGroup) {
+	// Called by Kratos after a password change
+	router.POST("/internal/hooks/after-password-change", a.KratosWebhook, a.Handler.AfterPasswordChange)
+
 	auth := router.Group("auth")
 	{
 		// Public endpoints
@@ -72,8 +97,38 @@ func (a *AuthModule) RegisterRoutes(router *gin.RouterGroup) {
 		protected := auth.Group("")
 		protected.Use(a.Middleware.RequireAuth())
 		{
-			protected.GET("/me", a.Handler.Me)          // Get current user info
-			protected.POST("/logout", a.Handler.Logout) // Logout (optional)
+			protected.GET("/me", a.Handler.Me)                           // Get current user info
+			protected.POST("/logout", a.Handler.Logout)                  // End the current session
+			protected.POST("/logout-all", a.Handler.LogoutAll)           // End every session, this one included
+			protected.GET("/sessions", a.Handler.ListSessions)           // Signed-in devices
+			protected.DELETE("/sessions", a.Handler.RevokeOtherSessions) // Sign out every other device
+			protected.DELETE("/sessions/:session_id", a.Handler.RevokeSession)
 		}
 	}
 }
+
+// StartWorkers launches signing key rotation, the user status invalidation listener and
+// the cleanup of dead sessions (SESSION_CLEANUP_INTERVAL, default 6h)
+func (a *AuthModule) StartWorkers(ctx context.Context) {
+	a.signingKeys.StartRotation(ctx)
+	a.Service.StartUserStatusListener(ctx)
+	a.Service.StartSessionCleanup(ctx, durationFromEnv("SESSION_CLEANUP_INTERVAL", 6*time.Hour))
+}
+
+// RegisterWellKnownRoutes serves the public signing keys at the root of the API host
+func (a *AuthModule) RegisterWellKnownRoutes(router *gin.Engine) {
+	router.GET("/.well-known/jwks.json", a.Handler.JWKS)
+}
+
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil || parsed <= 0 {
+		log.Printf("Ignoring invalid %s %q, using %s", key, value, fallback)
+		return fallback
+	}
+	return parsed
+}
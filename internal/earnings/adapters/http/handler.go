@@ -0,0 +1,210 @@
+package http
+
+import (
+	"music-app-backend/internal/earnings/application"
+	model "music-app-backend/internal/earnings/domain"
+	appError "music-app-backend/pkg/error"
+	jsonResponse "music-app-backend/pkg/json"
+	"music-app-backend/pkg/pagination"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EarningsHandler struct {
+	earningsService *application.EarningsService
+}
+
+type PayoutTransitionRequest struct {
+	ExternalReference string `json:"external_reference"`
+	Reason            string `json:"reason"`
+}
+
+func NewEarningsHandler(earningsService *application.EarningsService) *EarningsHandler {
+	return &EarningsHandler{
+		earningsService: earningsService,
+	}
+}
+
+func (h *EarningsHandler) HandleError(c *gin.Context, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if appErr, ok := appError.GetAppError(err); ok {
+		jsonResponse.ResponseJSON(c, appErr.StatusCode, appErr.Message, appErr.Data)
+		return true
+	}
+
+	jsonResponse.ResponseInternalError(c, err)
+	return true
+}
+
+// GetBalance returns the current artist's balances per currency
+func (h *EarningsHandler) GetBalance(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	balances, err := h.earningsService.GetBalances(c.Request.Context(), userID.(uint64))
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseOK(c, balances)
+}
+
+// GetStatement returns the earnings statement for ?from=YYYY-MM-DD&to=YYYY-MM-DD (default: current month)
+func (h *EarningsHandler) GetStatement(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	if value := c.Query("from"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			jsonResponse.ResponseBadRequest(c, "from must be a date in YYYY-MM-DD format")
+			return
+		}
+		from = parsed
+	}
+	if value := c.Query("to"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			jsonResponse.ResponseBadRequest(c, "to must be a date in YYYY-MM-DD format")
+			return
+		}
+		// The statement includes the whole 'to' day
+		to = parsed.AddDate(0, 0, 1)
+	}
+
+	statement, err := h.earningsService.GetStatement(c.Request.Context(), userID.(uint64), from, to)
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseOK(c, statement)
+}
+
+// ListPayouts returns the current artist's payouts
+func (h *EarningsHandler) ListPayouts(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	result, err := h.earningsService.ListArtistPayouts(c.Request.Context(), userID.(uint64), pagination.FromQuery(c))
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseOK(c, result)
+}
+
+// RunPayoutBatch starts a payout batch immediately instead of waiting for the scheduler
+func (h *EarningsHandler) RunPayoutBatch(c *gin.Context) {
+	result, err := h.earningsService.RunPayoutBatch(c.Request.Context())
+	if h.HandleError(c, err) {
+		return
+	}
+
+	if result == nil {
+		jsonResponse.ResponseJSON(c, 409, "A payout batch is already running", nil)
+		return
+	}
+
+	jsonResponse.ResponseCreated(c, result)
+}
+
+func (h *EarningsHandler) GetPayoutBatch(c *gin.Context) {
+	batchID, err := strconv.ParseUint(c.Param("batch_id"), 10, 64)
+	if err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid batch ID format")
+		return
+	}
+
+	result, err := h.earningsService.GetPayoutBatch(c.Request.Context(), batchID)
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseOK(c, result)
+}
+
+// UpdatePayoutStatus moves a payout to processing, paid or failed depending on the route
+func (h *EarningsHandler) UpdatePayoutStatus(status model.PayoutStatus) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		payoutID, err := strconv.ParseUint(c.Param("payout_id"), 10, 64)
+		if err != nil {
+			jsonResponse.ResponseBadRequest(c, "Invalid payout ID format")
+			return
+		}
+
+		var request PayoutTransitionRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			jsonResponse.ResponseBadRequest(c, "Invalid request: "+err.Error())
+			return
+		}
+
+		var payout *model.Payout
+		switch status {
+		case model.PayoutStatusProcessing:
+			payout, err = h.earningsService.MarkPayoutProcessing(c.Request.Context(), payoutID, request.ExternalReference)
+		case model.PayoutStatusPaid:
+			payout, err = h.earningsService.MarkPayoutPaid(c.Request.Context(), payoutID, request.ExternalReference)
+		case model.PayoutStatusFailed:
+			if request.Reason == "" {
+				jsonResponse.ResponseBadRequest(c, "reason is required")
+				return
+			}
+			payout, err = h.earningsService.MarkPayoutFailed(c.Request.Context(), payoutID, request.Reason)
+		}
+		if h.HandleError(c, err) {
+			return
+		}
+
+		jsonResponse.ResponseOK(c, payout)
+	}
+}
+
+// CreateAdjustment credits or debits an artist's available balance
+func (h *EarningsHandler) CreateAdjustment(c *gin.Context) {
+	var request model.AdjustmentInput
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	txn, err := h.earningsService.RecordAdjustment(c.Request.Context(), userID.(uint64), &request)
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseCreated(c, txn)
+}
+
+// GetReconciliation returns the ledger reconciliation report
+func (h *EarningsHandler) GetReconciliation(c *gin.Context) {
+	report, err := h.earningsService.Reconcile(c.Request.Context())
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseOK(c, report)
+}
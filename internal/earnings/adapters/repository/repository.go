@@ -0,0 +1,323 @@
+package repository
+
+import (
+	"context"
+	model "music-app-backend/internal/earnings/domain"
+	"music-app-backend/pkg/pagination"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// payoutBatchLockKey serialises payout batches across API replicas
+const payoutBatchLockKey = 727001
+
+type EarningsRepository struct {
+	db *gorm.DB
+}
+
+func NewEarningsRepository(db *gorm.DB) *EarningsRepository {
+	return &EarningsRepository{
+		db: db,
+	}
+}
+
+// Transaction runs fn against a repository bound to a single database transaction
+func (r *EarningsRepository) Transaction(ctx context.Context, fn func(txRepo *EarningsRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&EarningsRepository{db: tx})
+	})
+}
+
+// TryLockPayoutBatch takes a transaction-scoped advisory lock. It must be called inside Transaction.
+func (r *EarningsRepository) TryLockPayoutBatch(ctx context.Context) (bool, error) {
+	var locked bool
+	err := r.db.WithContext(ctx).Raw("SELECT pg_try_advisory_xact_lock(?)", payoutBatchLockKey).Scan(&locked).Error
+	return locked, err
+}
+
+// CreateLedgerTransaction inserts the transaction and its entries in one database
+// transaction, so a header never exists without its entries. It returns false without
+// writing anything when the same business reference was already posted.
+func (r *EarningsRepository) CreateLedgerTransaction(ctx context.Context, txn *model.LedgerTransaction) (bool, error) {
+	created := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Omit("Entries").
+			Create(txn)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		if len(txn.Entries) > 0 {
+			if err := tx.Create(&txn.Entries).Error; err != nil {
+				return err
+			}
+		}
+		created = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return created, nil
+}
+
+func (r *EarningsRepository) GetArtistBalances(ctx context.Context, artistID uint64) ([]model.ArtistBalance, error) {
+	var balances []model.ArtistBalance
+	err := r.db.WithContext(ctx).Model(&model.LedgerEntry{}).
+		Select(`currency,
+			COALESCE(SUM(CASE WHEN account = ? THEN amount_cents ELSE 0 END), 0) AS available_cents,
+			COALESCE(SUM(CASE WHEN account = ? THEN amount_cents ELSE 0 END), 0) AS pending_payout_cents,
+			COALESCE(SUM(CASE WHEN account = ? AND entry_type = ? THEN amount_cents ELSE 0 END), 0) AS paid_out_cents`,
+			model.AccountArtistAvailable, model.AccountArtistPayoutPending,
+			model.AccountPayoutsSettled, model.EntryTypePayout).
+		Where("artist_id = ?", artistID).
+		Group("currency").
+		Order("currency").
+		Scan(&balances).Error
+	return balances, err
+}
+
+type AvailableBalance struct {
+	ArtistID    uint64
+	Currency    string
+	AmountCents int64
+}
+
+// ListPayableBalances returns every artist/currency pair whose available balance reaches the threshold
+func (r *EarningsRepository) ListPayableBalances(ctx context.Context, thresholdCents int64) ([]AvailableBalance, error) {
+	var balances []AvailableBalance
+	err := r.db.WithContext(ctx).Model(&model.LedgerEntry{}).
+		Select("artist_id, currency, SUM(amount_cents) AS amount_cents").
+		Where("account = ? AND artist_id IS NOT NULL", model.AccountArtistAvailable).
+		Group("artist_id, currency").
+		Having("SUM(amount_cents) >= ?", thresholdCents).
+		Order("artist_id, currency").
+		Scan(&balances).Error
+	return balances, err
+}
+
+func (r *EarningsRepository) CreatePayoutBatch(ctx context.Context, batch *model.PayoutBatch) error {
+	return r.db.WithContext(ctx).Create(batch).Error
+}
+
+func (r *EarningsRepository) GetPayoutBatch(ctx context.Context, batchID uint64) (*model.PayoutBatch, error) {
+	var batch model.PayoutBatch
+	err := r.db.WithContext(ctx).Where("id = ?", batchID).First(&batch).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &batch, nil
+}
+
+func (r *EarningsRepository) CreatePayout(ctx context.Context, payout *model.Payout) error {
+	return r.db.WithContext(ctx).Create(payout).Error
+}
+
+// GetPayoutForUpdate loads a payout and locks the row for the rest of the transaction
+func (r *EarningsRepository) GetPayoutForUpdate(ctx context.Context, payoutID uint64) (*model.Payout, error) {
+	var payout model.Payout
+	err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", payoutID).
+		First(&payout).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &payout, nil
+}
+
+func (r *EarningsRepository) UpdatePayout(ctx context.Context, payout *model.Payout) error {
+	return r.db.WithContext(ctx).Save(payout).Error
+}
+
+func (r *EarningsRepository) ListPayoutsByBatch(ctx context.Context, batchID uint64) ([]model.Payout, error) {
+	var payouts []model.Payout
+	err := r.db.WithContext(ctx).Where("batch_id = ?", batchID).Order("artist_id, currency").Find(&payouts).Error
+	return payouts, err
+}
+
+func (r *EarningsRepository) ListPayoutsByArtist(ctx context.Context, artistID uint64, page pagination.Params) ([]model.Payout, int64, error) {
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&model.Payout{}).Where("artist_id = ?", artistID).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var payouts []model.Payout
+	err := r.db.WithContext(ctx).
+		Where("artist_id = ?", artistID).
+		Order("created_at DESC, id DESC").
+		Offset(page.Offset()).
+		Limit(page.Limit).
+		Find(&payouts).Error
+	return payouts, total, err
+}
+
+// SumArtistAvailableBefore returns the available balance per currency as of a point in time
+func (r *EarningsRepository) SumArtistAvailableBefore(ctx context.Context, artistID uint64, before time.Time) (map[string]int64, error) {
+	var rows []struct {
+		Currency    string
+		AmountCents int64
+	}
+	err := r.db.WithContext(ctx).Model(&model.LedgerEntry{}).
+		Select("currency, COALESCE(SUM(amount_cents), 0) AS amount_cents").
+		Where("artist_id = ? AND account = ? AND created_at < ?", artistID, model.AccountArtistAvailable, before).
+		Group("currency").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		balances[row.Currency] = row.AmountCents
+	}
+	return balances, nil
+}
+
+type StatementRow struct {
+	model.StatementLine
+	Currency string
+}
+
+// ListArtistAvailableEntries returns the artist's available-balance movements in [from, to)
+func (r *EarningsRepository) ListArtistAvailableEntries(ctx context.Context, artistID uint64, from, to time.Time) ([]StatementRow, error) {
+	var rows []StatementRow
+	err := r.db.WithContext(ctx).
+		Table("ledger_entries AS e").
+		Select(`e.transaction_id, e.entry_type, e.amount_cents, e.currency, e.created_at,
+			t.reference_type, t.reference_id, t.description`).
+		Joins("JOIN ledger_transactions AS t ON t.id = e.transaction_id").
+		Where("e.artist_id = ? AND e.account = ? AND e.created_at >= ? AND e.created_at < ?",
+			artistID, model.AccountArtistAvailable, from, to).
+		Order("e.created_at, e.id").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *EarningsRepository) SumAccounts(ctx context.Context) ([]model.AccountBalance, error) {
+	var balances []model.AccountBalance
+	err := r.db.WithContext(ctx).Model(&model.LedgerEntry{}).
+		Select("account, currency, COALESCE(SUM(amount_cents), 0) AS amount_cents").
+		Group("account, currency").
+		Order("currency, account").
+		Scan(&balances).Error
+	return balances, err
+}
+
+func (r *EarningsRepository) ListUnbalancedTransactions(ctx context.Context) ([]uint64, error) {
+	var ids []uint64
+	err := r.db.WithContext(ctx).Model(&model.LedgerEntry{}).
+		Select("transaction_id").
+		Group("transaction_id").
+		Having("SUM(amount_cents) <> 0").
+		Order("transaction_id").
+		Pluck("transaction_id", &ids).Error
+	return ids, err
+}
+
+// ListUnpostedTips finds completed tips that never reached the ledger
+func (r *EarningsRepository) ListUnpostedTips(ctx context.Context, limit int) ([]model.TipLedgerInput, error) {
+	var tips []model.TipLedgerInput
+	err := r.db.WithContext(ctx).
+		Table("tips").
+		Select(`tips.id AS tip_id, tips.to_artist_id AS artist_id, tips.currency,
+			tips.amount_cents, tips.platform_fee_cents, tips.artist_payout_cents`).
+		Joins(`LEFT JOIN ledger_transactions AS t
+			ON t.kind = ? AND t.reference_type = 'tip' AND t.reference_id = tips.id`, model.TransactionKindTip).
+		Where("tips.status IN ? AND t.id IS NULL", []string{"completed", "partially_refunded", "refunded"}).
+		Order("tips.id").
+		Limit(limit).
+		Scan(&tips).Error
+	return tips, err
+}
+
+// ListUnpostedRefunds finds tip refunds that never reached the ledger
+func (r *EarningsRepository) ListUnpostedRefunds(ctx context.Context, limit int) ([]model.RefundLedgerInput, error) {
+	var refunds []model.RefundLedgerInput
+	err := r.db.WithContext(ctx).
+		Table("tip_refunds AS rf").
+		Select(`rf.id AS refund_id, rf.tip_id, tips.to_artist_id AS artist_id, rf.currency,
+			rf.amount_cents, rf.platform_fee_cents, rf.artist_payout_cents`).
+		Joins("JOIN tips ON tips.id = rf.tip_id").
+		Joins(`LEFT JOIN ledger_transactions AS t
+			ON t.kind = ? AND t.reference_type = 'tip_refund' AND t.reference_id = rf.id`, model.TransactionKindRefund).
+		Where("rf.status = 'succeeded' AND t.id IS NULL").
+		Order("rf.id").
+		Limit(limit).
+		Scan(&refunds).Error
+	return refunds, err
+}
+
+// SumOpenPayouts totals pending and processing payouts per currency
+func (r *EarningsRepository) SumOpenPayouts(ctx context.Context) (map[string]int64, error) {
+	var rows []struct {
+		Currency    string
+		AmountCents int64
+	}
+	err := r.db.WithContext(ctx).Model(&model.Payout{}).
+		Select("currency, COALESCE(SUM(amount_cents), 0) AS amount_cents").
+		Where("status IN ?", []model.PayoutStatus{model.PayoutStatusPending, model.PayoutStatusProcessing}).
+		Group("currency").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		totals[row.Currency] = row.AmountCents
+	}
+	return totals, nil
+}
+
+// ListArtistEarningsMismatches compares, per artist and currency, the net artist payout
+// of posted tips and succeeded refunds with the ledger's net tip credits. The
+// trigger-maintained artists.total_earnings adds up every currency, so it cannot be
+// checked against the ledger directly.
+func (r *EarningsRepository) ListArtistEarningsMismatches(ctx context.Context) ([]model.ArtistEarningsMismatch, error) {
+	var mismatches []model.ArtistEarningsMismatch
+	err := r.db.WithContext(ctx).Raw(`
+		WITH source AS (
+			SELECT to_artist_id AS artist_id, currency, artist_payout_cents AS amount_cents
+			FROM tips
+			WHERE status IN ?
+			UNION ALL
+			SELECT tips.to_artist_id, rf.currency, -rf.artist_payout_cents
+			FROM tip_refunds AS rf
+			JOIN tips ON tips.id = rf.tip_id
+			WHERE rf.status = 'succeeded'
+		), tips_net AS (
+			SELECT artist_id, currency, SUM(amount_cents) AS net_cents
+			FROM source
+			GROUP BY artist_id, currency
+		), ledger_net AS (
+			SELECT artist_id, currency, SUM(amount_cents) AS net_cents
+			FROM ledger_entries
+			WHERE account = ? AND entry_type IN ?
+			GROUP BY artist_id, currency
+		)
+		SELECT COALESCE(t.artist_id, l.artist_id) AS artist_id,
+			COALESCE(t.currency, l.currency) AS currency,
+			COALESCE(t.net_cents, 0) AS tips_net_cents,
+			COALESCE(l.net_cents, 0) AS ledger_net_tips_cents
+		FROM tips_net AS t
+		FULL OUTER JOIN ledger_net AS l ON l.artist_id = t.artist_id AND l.currency = t.currency
+		WHERE COALESCE(t.net_cents, 0) <> COALESCE(l.net_cents, 0)
+		ORDER BY 1, 2`,
+		[]string{"completed", "partially_refunded", "refunded"},
+		model.AccountArtistAvailable, []model.EntryType{model.EntryTypeTipPayout, model.EntryTypeRefund},
+	).Scan(&mismatches).Error
+	return mismatches, err
+}
@@ -0,0 +1,340 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"music-app-backend/internal/earnings/adapters/repository"
+	model "music-app-backend/internal/earnings/domain"
+	"music-app-backend/pkg/audit"
+	appError "music-app-backend/pkg/error"
+	baseModel "music-app-backend/pkg/model"
+	"music-app-backend/pkg/pagination"
+	"strconv"
+	"time"
+)
+
+const unpostedTipBackfillLimit = 500
+
+type PayoutBatchResult struct {
+	Batch   *model.PayoutBatch `json:"batch"`
+	Payouts []model.Payout     `json:"payouts"`
+}
+
+type PayoutListResult struct {
+	Payouts    []model.Payout  `json:"payouts"`
+	Pagination pagination.Meta `json:"pagination"`
+}
+
+// RunPayoutBatch moves every artist balance at or above the threshold into a pending payout.
+// Only one replica can run a batch at a time; the others return (nil, nil).
+func (s *EarningsService) RunPayoutBatch(ctx context.Context) (*PayoutBatchResult, error) {
+	// Catch up on tips and refunds whose ledger posting failed so the balances are current
+	if err := s.PostUnpostedTips(ctx); err != nil {
+		log.Printf("Failed to backfill unposted tips before payout batch: %v", err)
+	}
+	if err := s.PostUnpostedRefunds(ctx); err != nil {
+		log.Printf("Failed to backfill unposted refunds before payout batch: %v", err)
+	}
+
+	var result *PayoutBatchResult
+	err := s.repository.Transaction(ctx, func(txRepo *repository.EarningsRepository) error {
+		locked, err := txRepo.TryLockPayoutBatch(ctx)
+		if err != nil {
+			return err
+		}
+		if !locked {
+			return nil
+		}
+
+		_base, err := baseModel.NewBaseModel(s.generator)
+		if err != nil {
+			return err
+		}
+		batch := &model.PayoutBatch{
+			BaseModel:      *_base,
+			ThresholdCents: s.payoutThresholdCents,
+			StartedAt:      time.Now().UTC(),
+		}
+
+		balances, err := txRepo.ListPayableBalances(ctx, s.payoutThresholdCents)
+		if err != nil {
+			return err
+		}
+
+		payouts := make([]model.Payout, 0, len(balances))
+		for _, balance := range balances {
+			payout, err := s.createPayout(ctx, txRepo, batch.ID, balance)
+			if err != nil {
+				return err
+			}
+			payouts = append(payouts, *payout)
+			batch.PayoutCount++
+		}
+
+		// The batch row is written last, together with its payouts, so it only ever exists completed
+		completedAt := time.Now().UTC()
+		batch.Status = model.PayoutBatchStatusCompleted
+		batch.CompletedAt = &completedAt
+		if err := txRepo.CreatePayoutBatch(ctx, batch); err != nil {
+			return err
+		}
+
+		result = &PayoutBatchResult{Batch: batch, Payouts: payouts}
+		return nil
+	})
+	if err != nil {
+		return nil, appError.NewInternalError(err, "payout batch failed")
+	}
+
+	if result != nil {
+		var totalCents int64
+		for _, payout := range result.Payouts {
+			totalCents += payout.AmountCents
+		}
+		audit.Write(ctx, s.auditLog, &audit.Entry{
+			Action:     "payout_batch.run",
+			TargetType: "payout_batch",
+			TargetID:   strconv.FormatUint(result.Batch.ID, 10),
+			After: map[string]interface{}{
+				"payout_count":    result.Batch.PayoutCount,
+				"total_cents":     totalCents,
+				"threshold_cents": result.Batch.ThresholdCents,
+			},
+		})
+	}
+	return result, nil
+}
+
+func (s *EarningsService) createPayout(ctx context.Context, txRepo *repository.EarningsRepository, batchID uint64, balance repository.AvailableBalance) (*model.Payout, error) {
+	_base, err := baseModel.NewBaseModel(s.generator)
+	if err != nil {
+		return nil, err
+	}
+
+	artistID := balance.ArtistID
+	legs := []ledgerLeg{
+		{Account: model.AccountArtistAvailable, ArtistID: &artistID, Currency: balance.Currency, AmountCents: -balance.AmountCents, EntryType: model.EntryTypePayout},
+		{Account: model.AccountArtistPayoutPending, ArtistID: &artistID, Currency: balance.Currency, AmountCents: balance.AmountCents, EntryType: model.EntryTypePayout},
+	}
+	txn, err := s.postTransaction(ctx, txRepo, model.TransactionKindPayout, balance.Currency, "payout", _base.ID,
+		fmt.Sprintf("Payout batch %d", batchID), nil, legs)
+	if err != nil {
+		return nil, err
+	}
+	if txn == nil {
+		return nil, fmt.Errorf("payout %d was already posted", _base.ID)
+	}
+
+	payout := &model.Payout{
+		BaseModel:           *_base,
+		BatchID:             batchID,
+		ArtistID:            balance.ArtistID,
+		Currency:            balance.Currency,
+		AmountCents:         balance.AmountCents,
+		Status:              model.PayoutStatusPending,
+		LedgerTransactionID: txn.ID,
+	}
+	if err := txRepo.CreatePayout(ctx, payout); err != nil {
+		return nil, err
+	}
+	return payout, nil
+}
+
+// MarkPayoutProcessing records that the transfer was handed to the payout provider
+func (s *EarningsService) MarkPayoutProcessing(ctx context.Context, payoutID uint64, externalReference string) (*model.Payout, error) {
+	return s.transitionPayout(ctx, payoutID, func(txRepo *repository.EarningsRepository, payout *model.Payout) error {
+		if payout.Status != model.PayoutStatusPending {
+			return appError.NewBadRequestError(nil, fmt.Sprintf("payout is %s, expected pending", payout.Status))
+		}
+		payout.Status = model.PayoutStatusProcessing
+		payout.ExternalReference = externalReference
+		return nil
+	})
+}
+
+// MarkPayoutPaid settles the payout: the pending amount leaves the platform
+func (s *EarningsService) MarkPayoutPaid(ctx context.Context, payoutID uint64, externalReference string) (*model.Payout, error) {
+	return s.transitionPayout(ctx, payoutID, func(txRepo *repository.EarningsRepository, payout *model.Payout) error {
+		if payout.Status != model.PayoutStatusPending && payout.Status != model.PayoutStatusProcessing {
+			return appError.NewBadRequestError(nil, fmt.Sprintf("payout is already %s", payout.Status))
+		}
+
+		artistID := payout.ArtistID
+		legs := []ledgerLeg{
+			{Account: model.AccountArtistPayoutPending, ArtistID: &artistID, Currency: payout.Currency, AmountCents: -payout.AmountCents, EntryType: model.EntryTypePayout},
+			{Account: model.AccountPayoutsSettled, ArtistID: &artistID, Currency: payout.Currency, AmountCents: payout.AmountCents, EntryType: model.EntryTypePayout},
+		}
+		if _, err := s.postTransaction(ctx, txRepo, model.TransactionKindPayoutSettled, payout.Currency, "payout", payout.ID,
+			"Payout settled", nil, legs); err != nil {
+			return err
+		}
+
+		paidAt := time.Now().UTC()
+		payout.Status = model.PayoutStatusPaid
+		payout.PaidAt = &paidAt
+		if externalReference != "" {
+			payout.ExternalReference = externalReference
+		}
+		return nil
+	})
+}
+
+// MarkPayoutFailed returns the payout amount to the artist's available balance
+func (s *EarningsService) MarkPayoutFailed(ctx context.Context, payoutID uint64, reason string) (*model.Payout, error) {
+	return s.transitionPayout(ctx, payoutID, func(txRepo *repository.EarningsRepository, payout *model.Payout) error {
+		if payout.Status != model.PayoutStatusPending && payout.Status != model.PayoutStatusProcessing {
+			return appError.NewBadRequestError(nil, fmt.Sprintf("payout is already %s", payout.Status))
+		}
+
+		artistID := payout.ArtistID
+		legs := []ledgerLeg{
+			{Account: model.AccountArtistPayoutPending, ArtistID: &artistID, Currency: payout.Currency, AmountCents: -payout.AmountCents, EntryType: model.EntryTypePayout},
+			{Account: model.AccountArtistAvailable, ArtistID: &artistID, Currency: payout.Currency, AmountCents: payout.AmountCents, EntryType: model.EntryTypePayout},
+		}
+		if _, err := s.postTransaction(ctx, txRepo, model.TransactionKindPayoutFailed, payout.Currency, "payout", payout.ID,
+			"Payout failed: "+reason, nil, legs); err != nil {
+			return err
+		}
+
+		payout.Status = model.PayoutStatusFailed
+		payout.FailureReason = reason
+		return nil
+	})
+}
+
+func (s *EarningsService) transitionPayout(ctx context.Context, payoutID uint64, apply func(txRepo *repository.EarningsRepository, payout *model.Payout) error) (*model.Payout, error) {
+	var updated *model.Payout
+	var before model.Payout
+	err := s.repository.Transaction(ctx, func(txRepo *repository.EarningsRepository) error {
+		payout, err := txRepo.GetPayoutForUpdate(ctx, payoutID)
+		if err != nil {
+			return err
+		}
+		if payout == nil {
+			return appError.NewNotFoundError(nil, "payout not found")
+		}
+		before = *payout
+
+		if err := apply(txRepo, payout); err != nil {
+			return err
+		}
+		if err := txRepo.UpdatePayout(ctx, payout); err != nil {
+			return err
+		}
+
+		updated = payout
+		return nil
+	})
+	if err != nil {
+		if appError.IsAppError(err) {
+			return nil, err
+		}
+		return nil, appError.NewInternalError(err, "failed to update payout")
+	}
+
+	audit.Write(ctx, s.auditLog, &audit.Entry{
+		Action:     "payout.status",
+		TargetType: "payout",
+		TargetID:   strconv.FormatUint(payoutID, 10),
+		Before:     payoutAuditState(&before),
+		After:      payoutAuditState(updated),
+		Metadata:   map[string]interface{}{"artist_id": updated.ArtistID, "amount_cents": updated.AmountCents},
+	})
+	return updated, nil
+}
+
+func payoutAuditState(payout *model.Payout) map[string]interface{} {
+	return map[string]interface{}{
+		"status":             payout.Status,
+		"external_reference": payout.ExternalReference,
+		"failure_reason":     payout.FailureReason,
+		"paid_at":            payout.PaidAt,
+	}
+}
+
+func (s *EarningsService) GetPayoutBatch(ctx context.Context, batchID uint64) (*PayoutBatchResult, error) {
+	batch, err := s.repository.GetPayoutBatch(ctx, batchID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load payout batch")
+	}
+	if batch == nil {
+		return nil, appError.NewNotFoundError(nil, "payout batch not found")
+	}
+
+	payouts, err := s.repository.ListPayoutsByBatch(ctx, batchID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load payouts")
+	}
+
+	return &PayoutBatchResult{Batch: batch, Payouts: payouts}, nil
+}
+
+// ListArtistPayouts returns the payouts of the artist owned by userID
+func (s *EarningsService) ListArtistPayouts(ctx context.Context, userID uint64, page pagination.Params) (*PayoutListResult, error) {
+	artistID, err := s.resolveArtistID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	payouts, total, err := s.repository.ListPayoutsByArtist(ctx, artistID, page)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to list payouts")
+	}
+
+	return &PayoutListResult{Payouts: payouts, Pagination: page.Meta(total)}, nil
+}
+
+// PostUnpostedTips posts completed tips that are missing from the ledger
+func (s *EarningsService) PostUnpostedTips(ctx context.Context) error {
+	tips, err := s.repository.ListUnpostedTips(ctx, unpostedTipBackfillLimit)
+	if err != nil {
+		return err
+	}
+
+	for i := range tips {
+		if err := s.RecordTip(ctx, &tips[i]); err != nil {
+			return fmt.Errorf("failed to post tip %d: %w", tips[i].TipID, err)
+		}
+	}
+	return nil
+}
+
+// PostUnpostedRefunds posts tip refunds that are missing from the ledger. It runs after
+// PostUnpostedTips so a refund is never posted ahead of its tip.
+func (s *EarningsService) PostUnpostedRefunds(ctx context.Context) error {
+	refunds, err := s.repository.ListUnpostedRefunds(ctx, unpostedTipBackfillLimit)
+	if err != nil {
+		return err
+	}
+
+	for i := range refunds {
+		if err := s.RecordRefund(ctx, &refunds[i]); err != nil {
+			return fmt.Errorf("failed to post refund %d: %w", refunds[i].RefundID, err)
+		}
+	}
+	return nil
+}
+
+// StartPayoutScheduler runs a payout batch on every tick until ctx is cancelled
+func (s *EarningsService) StartPayoutScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				result, err := s.RunPayoutBatch(ctx)
+				if err != nil {
+					log.Printf("Payout batch failed: %v", err)
+					continue
+				}
+				if result != nil {
+					log.Printf("Payout batch %d created %d payouts", result.Batch.ID, result.Batch.PayoutCount)
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,115 @@
+package application
+
+import (
+	"context"
+	model "music-app-backend/internal/earnings/domain"
+	appError "music-app-backend/pkg/error"
+	"sort"
+	"time"
+)
+
+const reconciliationUnpostedLimit = 100
+
+// Reconcile checks the ledger against itself and against the tables it mirrors:
+// every currency must sum to zero, every transaction must balance, every completed
+// tip and refund must be posted, open payouts must match the pending account, and each
+// artist's net tip payouts must match the ledger's net tip credits in every currency.
+func (s *EarningsService) Reconcile(ctx context.Context) (*model.ReconciliationReport, error) {
+	accounts, err := s.repository.SumAccounts(ctx)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to sum ledger accounts")
+	}
+
+	openPayouts, err := s.repository.SumOpenPayouts(ctx)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to sum open payouts")
+	}
+
+	unbalanced, err := s.repository.ListUnbalancedTransactions(ctx)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to check transactions")
+	}
+
+	unposted, err := s.repository.ListUnpostedTips(ctx, reconciliationUnpostedLimit)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to check unposted tips")
+	}
+
+	unpostedRefunds, err := s.repository.ListUnpostedRefunds(ctx, reconciliationUnpostedLimit)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to check unposted refunds")
+	}
+
+	mismatches, err := s.repository.ListArtistEarningsMismatches(ctx)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to compare artist earnings")
+	}
+
+	currencies := map[string]*model.CurrencyReconciliation{}
+	currencyFor := func(currency string) *model.CurrencyReconciliation {
+		row, ok := currencies[currency]
+		if !ok {
+			row = &model.CurrencyReconciliation{Currency: currency}
+			currencies[currency] = row
+		}
+		return row
+	}
+
+	for _, account := range accounts {
+		row := currencyFor(account.Currency)
+		row.LedgerSumCents += account.AmountCents
+		switch account.Account {
+		case model.AccountTipsClearing:
+			row.TipsClearingCents = account.AmountCents
+		case model.AccountArtistAvailable:
+			row.ArtistAvailableCents = account.AmountCents
+		case model.AccountArtistPayoutPending:
+			row.PayoutPendingCents = account.AmountCents
+		case model.AccountPayoutsSettled:
+			row.PayoutsSettledCents = account.AmountCents
+		case model.AccountPlatformRevenue:
+			row.PlatformRevenueCents = account.AmountCents
+		case model.AccountAdjustments:
+			row.AdjustmentsCents = account.AmountCents
+		}
+	}
+	for currency, amount := range openPayouts {
+		currencyFor(currency).OpenPayoutsCents = amount
+	}
+
+	report := &model.ReconciliationReport{
+		GeneratedAt:            time.Now().UTC(),
+		Healthy:                len(unbalanced) == 0 && len(unposted) == 0 && len(unpostedRefunds) == 0 && len(mismatches) == 0,
+		Currencies:             make([]model.CurrencyReconciliation, 0, len(currencies)),
+		UnbalancedTransactions: unbalanced,
+		UnpostedTips:           make([]uint64, 0, len(unposted)),
+		UnpostedRefunds:        make([]uint64, 0, len(unpostedRefunds)),
+		ArtistMismatches:       mismatches,
+	}
+
+	for _, row := range currencies {
+		row.Balanced = row.LedgerSumCents == 0 && row.PayoutPendingCents == row.OpenPayoutsCents
+		if !row.Balanced {
+			report.Healthy = false
+		}
+		report.Currencies = append(report.Currencies, *row)
+	}
+	sort.Slice(report.Currencies, func(i, j int) bool {
+		return report.Currencies[i].Currency < report.Currencies[j].Currency
+	})
+
+	for _, tip := range unposted {
+		report.UnpostedTips = append(report.UnpostedTips, tip.TipID)
+	}
+	for _, refund := range unpostedRefunds {
+		report.UnpostedRefunds = append(report.UnpostedRefunds, refund.RefundID)
+	}
+	if report.UnbalancedTransactions == nil {
+		report.UnbalancedTransactions = []uint64{}
+	}
+	if report.ArtistMismatches == nil {
+		report.ArtistMismatches = []model.ArtistEarningsMismatch{}
+	}
+
+	return report, nil
+}
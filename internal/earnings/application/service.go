@@ -0,0 +1,233 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"music-app-backend/internal/earnings/adapters/repository"
+	model "music-app-backend/internal/earnings/domain"
+	musicModuleSvc "music-app-backend/internal/music/application"
+	socialModel "music-app-backend/internal/social/domain"
+	"music-app-backend/pkg/audit"
+	appError "music-app-backend/pkg/error"
+	baseModel "music-app-backend/pkg/model"
+	"os"
+	"strconv"
+	"strings"
+
+	goflakeid "github.com/capy-engineer/go-flakeid"
+)
+
+// IEarningsService is the entry point other modules use to post money movements
+type IEarningsService interface {
+	RecordTip(ctx context.Context, tip *model.TipLedgerInput) error
+	RecordRefund(ctx context.Context, refund *model.RefundLedgerInput) error
+}
+
+type EarningsService struct {
+	repository           *repository.EarningsRepository
+	generator            *goflakeid.Generator
+	musicService         musicModuleSvc.IMusicService
+	tipFees              socialModel.TipFeeSchedule
+	payoutThresholdCents int64
+	auditLog             audit.Logger
+}
+
+func NewEarningsService(repository *repository.EarningsRepository, generator *goflakeid.Generator, musicService musicModuleSvc.IMusicService, tipFees socialModel.TipFeeSchedule, auditLog audit.Logger) *EarningsService {
+	threshold := int64(2000) // $20.00 default minimum payout
+	if value := os.Getenv("PAYOUT_THRESHOLD_CENTS"); value != "" {
+		if parsed, err := strconv.ParseInt(value, 10, 64); err == nil && parsed > 0 {
+			threshold = parsed
+		}
+	}
+
+	return &EarningsService{
+		repository:           repository,
+		generator:            generator,
+		musicService:         musicService,
+		tipFees:              tipFees,
+		payoutThresholdCents: threshold,
+		auditLog:             auditLog,
+	}
+}
+
+// RecordTip posts a completed tip: the gross amount leaves tips clearing and is
+// split between the artist's available balance and platform revenue
+func (s *EarningsService) RecordTip(ctx context.Context, tip *model.TipLedgerInput) error {
+	if tip.PlatformFeeCents+tip.ArtistPayoutCents != tip.AmountCents {
+		return fmt.Errorf("tip %d split does not add up: %d + %d != %d",
+			tip.TipID, tip.PlatformFeeCents, tip.ArtistPayoutCents, tip.AmountCents)
+	}
+
+	artistID := tip.ArtistID
+	currency := strings.ToUpper(tip.Currency)
+	entries := []ledgerLeg{
+		{Account: model.AccountTipsClearing, Currency: currency, AmountCents: -tip.AmountCents, EntryType: model.EntryTypeTipReceived},
+		{Account: model.AccountArtistAvailable, ArtistID: &artistID, Currency: currency, AmountCents: tip.ArtistPayoutCents, EntryType: model.EntryTypeTipPayout},
+		{Account: model.AccountPlatformRevenue, Currency: currency, AmountCents: tip.PlatformFeeCents, EntryType: model.EntryTypePlatformFee},
+	}
+
+	_, err := s.postTransaction(ctx, s.repository, model.TransactionKindTip, currency, "tip", tip.TipID,
+		fmt.Sprintf("Tip %d", tip.TipID), nil, entries)
+	return err
+}
+
+// RecordRefund reverses part or all of a tip: the refunded amount goes back to tips
+// clearing, taken proportionally from the artist's available balance and platform revenue
+func (s *EarningsService) RecordRefund(ctx context.Context, refund *model.RefundLedgerInput) error {
+	if refund.PlatformFeeCents+refund.ArtistPayoutCents != refund.AmountCents {
+		return fmt.Errorf("refund %d split does not add up: %d + %d != %d",
+			refund.RefundID, refund.PlatformFeeCents, refund.ArtistPayoutCents, refund.AmountCents)
+	}
+
+	artistID := refund.ArtistID
+	currency := strings.ToUpper(refund.Currency)
+	entries := []ledgerLeg{
+		{Account: model.AccountTipsClearing, Currency: currency, AmountCents: refund.AmountCents, EntryType: model.EntryTypeRefund},
+		{Account: model.AccountArtistAvailable, ArtistID: &artistID, Currency: currency, AmountCents: -refund.ArtistPayoutCents, EntryType: model.EntryTypeRefund},
+		{Account: model.AccountPlatformRevenue, Currency: currency, AmountCents: -refund.PlatformFeeCents, EntryType: model.EntryTypeRefund},
+	}
+
+	_, err := s.postTransaction(ctx, s.repository, model.TransactionKindRefund, currency, "tip_refund", refund.RefundID,
+		fmt.Sprintf("Refund %d of tip %d", refund.RefundID, refund.TipID), nil, entries)
+	return err
+}
+
+// RecordAdjustment moves money between an artist's available balance and the adjustments account
+func (s *EarningsService) RecordAdjustment(ctx context.Context, actorUserID uint64, input *model.AdjustmentInput) (*model.LedgerTransaction, error) {
+	if input.AmountCents == 0 {
+		return nil, appError.NewBadRequestError(nil, "amount_cents must not be zero")
+	}
+	currency := strings.ToUpper(input.Currency)
+	if _, ok := s.tipFees[currency]; !ok {
+		return nil, appError.NewBadRequestError(nil, "unsupported currency: "+currency)
+	}
+
+	artist, err := s.musicService.GetArtistByID(ctx, input.ArtistID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load artist")
+	}
+	if artist == nil {
+		return nil, appError.NewNotFoundError(nil, "artist not found")
+	}
+
+	// Adjustments have no external reference, so each gets its own ID
+	referenceID, err := s.generator.Generate()
+	if err != nil {
+		return nil, err
+	}
+
+	artistID := artist.ID
+	entries := []ledgerLeg{
+		{Account: model.AccountArtistAvailable, ArtistID: &artistID, Currency: currency, AmountCents: input.AmountCents, EntryType: model.EntryTypeAdjustment},
+		{Account: model.AccountAdjustments, Currency: currency, AmountCents: -input.AmountCents, EntryType: model.EntryTypeAdjustment},
+	}
+
+	txn, err := s.postTransaction(ctx, s.repository, model.TransactionKindAdjustment, currency, "adjustment", referenceID,
+		input.Reason, &actorUserID, entries)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to record adjustment")
+	}
+	if txn == nil {
+		// The reference ID is freshly generated, so a conflict means the ID generator repeated itself
+		return nil, appError.NewInternalError(nil, "adjustment was not recorded: duplicate reference")
+	}
+	audit.Write(ctx, s.auditLog, &audit.Entry{
+		Action:     "ledger.adjustment",
+		TargetType: "artist",
+		TargetID:   strconv.FormatUint(artistID, 10),
+		After: map[string]interface{}{
+			"ledger_transaction_id": txn.ID,
+			"amount_cents":          input.AmountCents,
+			"currency":              currency,
+		},
+		Metadata: map[string]interface{}{"reason": input.Reason},
+	})
+	return txn, nil
+}
+
+type ledgerLeg struct {
+	Account     model.LedgerAccount
+	ArtistID    *uint64
+	Currency    string
+	AmountCents int64
+	EntryType   model.EntryType
+}
+
+// postTransaction writes a balanced transaction. Posting the same (kind, reference) twice
+// returns nil without error so callers can retry safely.
+func (s *EarningsService) postTransaction(
+	ctx context.Context,
+	repo *repository.EarningsRepository,
+	kind model.TransactionKind,
+	currency string,
+	referenceType string,
+	referenceID uint64,
+	description string,
+	createdBy *uint64,
+	legs []ledgerLeg,
+) (*model.LedgerTransaction, error) {
+	var sum int64
+	for _, leg := range legs {
+		if leg.Currency != currency {
+			return nil, fmt.Errorf("ledger leg currency %s does not match transaction currency %s", leg.Currency, currency)
+		}
+		sum += leg.AmountCents
+	}
+	if sum != 0 {
+		return nil, fmt.Errorf("ledger transaction %s/%d is unbalanced by %d cents", referenceType, referenceID, sum)
+	}
+
+	_base, err := baseModel.NewBaseModel(s.generator)
+	if err != nil {
+		return nil, err
+	}
+
+	txn := &model.LedgerTransaction{
+		BaseModel:       *_base,
+		Kind:            kind,
+		Currency:        currency,
+		ReferenceType:   referenceType,
+		ReferenceID:     referenceID,
+		Description:     description,
+		CreatedByUserID: createdBy,
+	}
+
+	for _, leg := range legs {
+		if leg.AmountCents == 0 {
+			continue
+		}
+		entryBase, err := baseModel.NewBaseModel(s.generator)
+		if err != nil {
+			return nil, err
+		}
+		txn.Entries = append(txn.Entries, model.LedgerEntry{
+			BaseModel:     *entryBase,
+			TransactionID: txn.ID,
+			Account:       leg.Account,
+			ArtistID:      leg.ArtistID,
+			Currency:      leg.Currency,
+			AmountCents:   leg.AmountCents,
+			EntryType:     leg.EntryType,
+		})
+	}
+
+	created, err := repo.CreateLedgerTransaction(ctx, txn)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, nil
+	}
+	return txn, nil
+}
+
+func (s *EarningsService) resolveArtistID(ctx context.Context, userID uint64) (uint64, error) {
+	artist, err := s.musicService.GetArtistByUserID(ctx, userID)
+	if err != nil {
+		return 0, appError.NewInternalError(err, "failed to load artist")
+	}
+	if artist == nil {
+		return 0, appError.NewNotFoundError(nil, "artist profile not found")
+	}
+	return artist.ID, nil
+}
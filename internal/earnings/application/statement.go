@@ -0,0 +1,106 @@
+package application
+
+import (
+	"context"
+	model "music-app-backend/internal/earnings/domain"
+	appError "music-app-backend/pkg/error"
+	"sort"
+	"time"
+)
+
+const maxStatementRange = 366 * 24 * time.Hour
+
+// GetStatement builds the earnings statement of the artist owned by userID for [from, to)
+func (s *EarningsService) GetStatement(ctx context.Context, userID uint64, from, to time.Time) (*model.EarningsStatement, error) {
+	if !to.After(from) {
+		return nil, appError.NewBadRequestError(nil, "'to' must be after 'from'")
+	}
+	if to.Sub(from) > maxStatementRange {
+		return nil, appError.NewBadRequestError(nil, "statement range must not exceed one year")
+	}
+
+	artistID, err := s.resolveArtistID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	opening, err := s.repository.SumArtistAvailableBefore(ctx, artistID, from)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load opening balance")
+	}
+
+	rows, err := s.repository.ListArtistAvailableEntries(ctx, artistID, from, to)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load ledger entries")
+	}
+
+	statements := map[string]*model.CurrencyStatement{}
+	statementFor := func(currency string) *model.CurrencyStatement {
+		statement, ok := statements[currency]
+		if !ok {
+			statement = &model.CurrencyStatement{
+				Currency:            currency,
+				OpeningBalanceCents: opening[currency],
+				ClosingBalanceCents: opening[currency],
+				Lines:               []model.StatementLine{},
+			}
+			statements[currency] = statement
+		}
+		return statement
+	}
+
+	for currency := range opening {
+		statementFor(currency)
+	}
+
+	for _, row := range rows {
+		statement := statementFor(row.Currency)
+		switch row.EntryType {
+		case model.EntryTypeTipPayout:
+			statement.TipPayoutsCents += row.AmountCents
+		case model.EntryTypeRefund:
+			statement.RefundsCents += row.AmountCents
+		case model.EntryTypeAdjustment:
+			statement.AdjustmentsCents += row.AmountCents
+		case model.EntryTypePayout:
+			statement.PayoutsCents += row.AmountCents
+		}
+		statement.ClosingBalanceCents += row.AmountCents
+		statement.Lines = append(statement.Lines, row.StatementLine)
+	}
+
+	balances, err := s.repository.GetArtistBalances(ctx, artistID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load balances")
+	}
+
+	result := &model.EarningsStatement{
+		ArtistID:   artistID,
+		From:       from,
+		To:         to,
+		Currencies: make([]model.CurrencyStatement, 0, len(statements)),
+		Balances:   balances,
+	}
+	for _, statement := range statements {
+		result.Currencies = append(result.Currencies, *statement)
+	}
+	sort.Slice(result.Currencies, func(i, j int) bool {
+		return result.Currencies[i].Currency < result.Currencies[j].Currency
+	})
+
+	return result, nil
+}
+
+// GetBalances returns the current balances of the artist owned by userID
+func (s *EarningsService) GetBalances(ctx context.Context, userID uint64) ([]model.ArtistBalance, error) {
+	artistID, err := s.resolveArtistID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	balances, err := s.repository.GetArtistBalances(ctx, artistID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load balances")
+	}
+	return balances, nil
+}
@@ -0,0 +1,106 @@
+package model
+
+import (
+	"time"
+)
+
+// TipLedgerInput carries the money split of a completed tip into the ledger
+type TipLedgerInput struct {
+	TipID             uint64
+	ArtistID          uint64
+	Currency          string
+	AmountCents       int64
+	PlatformFeeCents  int64
+	ArtistPayoutCents int64
+}
+
+// RefundLedgerInput carries the reversed share of a tip refund or chargeback
+type RefundLedgerInput struct {
+	RefundID          uint64
+	TipID             uint64
+	ArtistID          uint64
+	Currency          string
+	AmountCents       int64
+	PlatformFeeCents  int64
+	ArtistPayoutCents int64
+}
+
+type AdjustmentInput struct {
+	ArtistID    uint64 `json:"artist_id" binding:"required"`
+	Currency    string `json:"currency" binding:"required,len=3"`
+	AmountCents int64  `json:"amount_cents" binding:"required"` // Positive credits the artist, negative debits
+	Reason      string `json:"reason" binding:"required,min=3,max=500"`
+}
+
+type ArtistBalance struct {
+	Currency           string `json:"currency"`
+	AvailableCents     int64  `json:"available_cents"`
+	PendingPayoutCents int64  `json:"pending_payout_cents"`
+	PaidOutCents       int64  `json:"paid_out_cents"`
+}
+
+type StatementLine struct {
+	TransactionID uint64    `json:"transaction_id"`
+	EntryType     EntryType `json:"entry_type"`
+	AmountCents   int64     `json:"amount_cents"`
+	ReferenceType string    `json:"reference_type"`
+	ReferenceID   uint64    `json:"reference_id"`
+	Description   string    `json:"description"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type CurrencyStatement struct {
+	Currency            string          `json:"currency"`
+	OpeningBalanceCents int64           `json:"opening_balance_cents"`
+	TipPayoutsCents     int64           `json:"tip_payouts_cents"`
+	RefundsCents        int64           `json:"refunds_cents"`
+	AdjustmentsCents    int64           `json:"adjustments_cents"`
+	PayoutsCents        int64           `json:"payouts_cents"`
+	ClosingBalanceCents int64           `json:"closing_balance_cents"`
+	Lines               []StatementLine `json:"lines"`
+}
+
+type EarningsStatement struct {
+	ArtistID   uint64              `json:"artist_id"`
+	From       time.Time           `json:"from"`
+	To         time.Time           `json:"to"`
+	Currencies []CurrencyStatement `json:"currencies"`
+	Balances   []ArtistBalance     `json:"balances"`
+}
+
+// AccountBalance is a ledger account total used by reconciliation
+type AccountBalance struct {
+	Account     LedgerAccount `json:"account"`
+	Currency    string        `json:"currency"`
+	AmountCents int64         `json:"amount_cents"`
+}
+
+type CurrencyReconciliation struct {
+	Currency             string `json:"currency"`
+	LedgerSumCents       int64  `json:"ledger_sum_cents"` // Must be zero
+	TipsClearingCents    int64  `json:"tips_clearing_cents"`
+	ArtistAvailableCents int64  `json:"artist_available_cents"`
+	PayoutPendingCents   int64  `json:"payout_pending_cents"`
+	PayoutsSettledCents  int64  `json:"payouts_settled_cents"`
+	PlatformRevenueCents int64  `json:"platform_revenue_cents"`
+	AdjustmentsCents     int64  `json:"adjustments_cents"`
+	OpenPayoutsCents     int64  `json:"open_payouts_cents"` // pending + processing rows in payouts
+	Balanced             bool   `json:"balanced"`
+}
+
+type ArtistEarningsMismatch struct {
+	ArtistID           uint64 `json:"artist_id"`
+	Currency           string `json:"currency"`
+	TipsNetCents       int64  `json:"tips_net_cents"` // Posted tips minus succeeded refunds
+	LedgerNetTipsCents int64  `json:"ledger_net_tips_cents"`
+}
+
+type ReconciliationReport struct {
+	GeneratedAt            time.Time                `json:"generated_at"`
+	Healthy                bool                     `json:"healthy"`
+	Currencies             []CurrencyReconciliation `json:"currencies"`
+	UnbalancedTransactions []uint64                 `json:"unbalanced_transactions"`
+	UnpostedTips           []uint64                 `json:"unposted_tips"`
+	UnpostedRefunds        []uint64                 `json:"unposted_refunds"`
+	ArtistMismatches       []ArtistEarningsMismatch `json:"artist_mismatches"`
+}
@@ -0,0 +1,70 @@
+package model
+
+import (
+	"music-app-backend/pkg/model"
+)
+
+// LedgerAccount names a bucket of money. Every ledger transaction moves money
+// between accounts and its entries always sum to zero per currency.
+type LedgerAccount string
+
+const (
+	// Money collected from listeners by the payment processor
+	AccountTipsClearing LedgerAccount = "tips_clearing"
+	// Money owed to an artist that has not been batched for payout yet
+	AccountArtistAvailable LedgerAccount = "artist_available"
+	// Money assigned to a payout that has not been confirmed as sent
+	AccountArtistPayoutPending LedgerAccount = "artist_payout_pending"
+	// Money that has left the platform to the artist
+	AccountPayoutsSettled LedgerAccount = "payouts_settled"
+	// Platform share of tips
+	AccountPlatformRevenue LedgerAccount = "platform_revenue"
+	// Counter-account for manual corrections
+	AccountAdjustments LedgerAccount = "adjustments"
+)
+
+type EntryType string
+
+const (
+	EntryTypeTipPayout   EntryType = "tip_payout"
+	EntryTypePlatformFee EntryType = "platform_fee"
+	EntryTypeTipReceived EntryType = "tip_received"
+	EntryTypeRefund      EntryType = "refund"
+	EntryTypeAdjustment  EntryType = "adjustment"
+	EntryTypePayout      EntryType = "payout"
+)
+
+type TransactionKind string
+
+const (
+	TransactionKindTip           TransactionKind = "tip"
+	TransactionKindRefund        TransactionKind = "refund"
+	TransactionKindAdjustment    TransactionKind = "adjustment"
+	TransactionKindPayout        TransactionKind = "payout"
+	TransactionKindPayoutSettled TransactionKind = "payout_settled"
+	TransactionKindPayoutFailed  TransactionKind = "payout_failed"
+)
+
+// LedgerTransaction groups balanced entries. (kind, reference_type, reference_id)
+// is unique so posting the same business event twice is a no-op.
+type LedgerTransaction struct {
+	model.BaseModel
+	Kind            TransactionKind `json:"kind" gorm:"not null;size:50;uniqueIndex:idx_ledger_transaction_reference"`
+	Currency        string          `json:"currency" gorm:"not null;size:3"`
+	ReferenceType   string          `json:"reference_type" gorm:"not null;size:50;uniqueIndex:idx_ledger_transaction_reference"`
+	ReferenceID     uint64          `json:"reference_id" gorm:"not null;uniqueIndex:idx_ledger_transaction_reference"`
+	Description     string          `json:"description"`
+	CreatedByUserID *uint64         `json:"created_by_user_id"`
+	Entries         []LedgerEntry   `json:"entries" gorm:"foreignKey:TransactionID"`
+}
+
+// LedgerEntry is one signed leg of a transaction, in integer cents
+type LedgerEntry struct {
+	model.BaseModel
+	TransactionID uint64        `json:"transaction_id" gorm:"not null;index"`
+	Account       LedgerAccount `json:"account" gorm:"not null;size:50"`
+	ArtistID      *uint64       `json:"artist_id" gorm:"index"`
+	Currency      string        `json:"currency" gorm:"not null;size:3"`
+	AmountCents   int64         `json:"amount_cents" gorm:"not null"`
+	EntryType     EntryType     `json:"entry_type" gorm:"not null;size:50"`
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"music-app-backend/pkg/model"
+	"time"
+)
+
+type PayoutBatchStatus string
+
+// A batch is only ever stored once it has finished, so it has no running state
+const (
+	PayoutBatchStatusCompleted PayoutBatchStatus = "completed"
+)
+
+type PayoutStatus string
+
+const (
+	PayoutStatusPending    PayoutStatus = "pending"
+	PayoutStatusProcessing PayoutStatus = "processing"
+	PayoutStatusPaid       PayoutStatus = "paid"
+	PayoutStatusFailed     PayoutStatus = "failed"
+)
+
+// PayoutBatch is one run of the payout job. A batch is written in a single
+// database transaction, so it is never left half-created.
+type PayoutBatch struct {
+	model.BaseModel
+	Status         PayoutBatchStatus `json:"status" gorm:"not null;size:50"`
+	ThresholdCents int64             `json:"threshold_cents" gorm:"not null"`
+	PayoutCount    int               `json:"payout_count" gorm:"default:0"`
+	StartedAt      time.Time         `json:"started_at"`
+	CompletedAt    *time.Time        `json:"completed_at"`
+}
+
+type Payout struct {
+	model.BaseModel
+	BatchID             uint64       `json:"batch_id" gorm:"not null;index"`
+	ArtistID            uint64       `json:"artist_id" gorm:"not null;index"`
+	Currency            string       `json:"currency" gorm:"not null;size:3"`
+	AmountCents         int64        `json:"amount_cents" gorm:"not null"`
+	Status              PayoutStatus `json:"status" gorm:"not null;size:50"`
+	LedgerTransactionID uint64       `json:"ledger_transaction_id" gorm:"not null"`
+	ExternalReference   string       `json:"external_reference" gorm:"size:100"`
+	FailureReason       string       `json:"failure_reason,omitempty"`
+	PaidAt              *time.Time   `json:"paid_at"`
+}
@@ -0,0 +1,82 @@
+package earnings
+
+import (
+	"context"
+	"log"
+	"music-app-backend/internal/earnings/adapters/http"
+	"music-app-backend/internal/earnings/adapters/repository"
+	"music-app-backend/internal/earnings/application"
+	model "music-app-backend/internal/earnings/domain"
+	musicModuleSvc "music-app-backend/internal/music/application"
+	socialModel "music-app-backend/internal/social/domain"
+	ctx2 "music-app-backend/pkg/context"
+	"music-app-backend/pkg/middleware"
+	"os"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EarningsModule struct {
+	Repository *repository.EarningsRepository
+	Service    *application.EarningsService
+	Handler    *http.EarningsHandler
+
+	authMiddleware *middleware.AuthMiddleware
+}
+
+func NewEarningsModule(serviceContext *ctx2.ServiceContext, authMiddleware *middleware.AuthMiddleware, musicService musicModuleSvc.IMusicService) *EarningsModule {
+	// Adjustments are limited to the currencies tips can be sent in
+	tipFees, err := socialModel.NewTipFeeScheduleFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load tip fee rules: %v", err)
+	}
+
+	earningsRepo := repository.NewEarningsRepository(serviceContext.GetDB())
+	earningsService := application.NewEarningsService(earningsRepo, serviceContext.GetIDGenerator(), musicService, tipFees, serviceContext.GetAuditLog())
+	earningsHandler := http.NewEarningsHandler(earningsService)
+
+	return &EarningsModule{
+		Repository:     earningsRepo,
+		Service:        earningsService,
+		Handler:        earningsHandler,
+		authMiddleware: authMiddleware,
+	}
+}
+
+func (e *EarningsModule) RegisterRoutes(router *gin.RouterGroup) {
+	earnings := router.Group("/earnings")
+	earnings.Use(e.authMiddleware.RequireAuth())
+	{
+		artist := earnings.Group("")
+		artist.Use(e.authMiddleware.RequireArtist())
+		{
+			artist.GET("/balance", e.Handler.GetBalance)
+			artist.GET("/statement", e.Handler.GetStatement)
+			artist.GET("/payouts", e.Handler.ListPayouts)
+		}
+
+		operator := earnings.Group("/admin")
+		operator.Use(e.authMiddleware.RequireAdmin(), e.authMiddleware.RequirePermission(middleware.PermissionManagePayouts))
+		{
+			operator.POST("/payout-batches", e.Handler.RunPayoutBatch)
+			operator.GET("/payout-batches/:batch_id", e.Handler.GetPayoutBatch)
+			operator.POST("/payouts/:payout_id/processing", e.Handler.UpdatePayoutStatus(model.PayoutStatusProcessing))
+			operator.POST("/payouts/:payout_id/paid", e.Handler.UpdatePayoutStatus(model.PayoutStatusPaid))
+			operator.POST("/payouts/:payout_id/failed", e.Handler.UpdatePayoutStatus(model.PayoutStatusFailed))
+			operator.POST("/adjustments", e.Handler.CreateAdjustment)
+			operator.GET("/reconciliation", e.Handler.GetReconciliation)
+		}
+	}
+}
+
+// StartWorkers launches the payout scheduler (PAYOUT_BATCH_INTERVAL, default 24h)
+func (e *EarningsModule) StartWorkers(ctx context.Context) {
+	interval := 24 * time.Hour
+	if value := os.Getenv("PAYOUT_BATCH_INTERVAL"); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+			interval = parsed
+		}
+	}
+	e.Service.StartPayoutScheduler(ctx, interval)
+}
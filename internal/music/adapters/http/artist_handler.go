@@ -0,0 +1,84 @@
+package http
+
+import (
+	model "music-app-backend/internal/music/domain"
+	jsonResponse "music-app-backend/pkg/json"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetArtistPage returns an artist's public page. Signed-in viewers also get whether they
+// follow the artist.
+func (h *MusicHandler) GetArtistPage(c *gin.Context) {
+	artistID, err := strconv.ParseUint(c.Param("artist_id"), 10, 64)
+	if err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid artist ID")
+		return
+	}
+
+	var viewerID *uint64
+	if userID, exists := c.Get("user_id"); exists {
+		id := userID.(uint64)
+		viewerID = &id
+	}
+
+	page, err := h.musicService.GetArtistPage(c.Request.Context(), artistID, viewerID)
+	if h.HandleError(c, err) {
+		return
+	}
+	jsonResponse.ResponseOK(c, page)
+}
+
+// GetMyArtistProfile returns the current artist's profile, including private fields
+func (h *MusicHandler) GetMyArtistProfile(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	artist, err := h.musicService.GetMyArtistProfile(c.Request.Context(), userID.(uint64))
+	if h.HandleError(c, err) {
+		return
+	}
+	jsonResponse.ResponseOK(c, artist)
+}
+
+// UpdateMyArtistProfile changes the fields present in the body; empty strings clear them
+func (h *MusicHandler) UpdateMyArtistProfile(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	request := &model.UpdateArtistProfileDTO{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	artist, err := h.musicService.UpdateArtistProfile(c.Request.Context(), userID.(uint64), request)
+	if h.HandleError(c, err) {
+		return
+	}
+	jsonResponse.ResponseOK(c, artist)
+}
+
+// Search finds artists and songs matching ?q=
+func (h *MusicHandler) Search(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	var viewerID *uint64
+	if userID, exists := c.Get("user_id"); exists {
+		id := userID.(uint64)
+		viewerID = &id
+	}
+
+	result, err := h.musicService.Search(c.Request.Context(), c.Query("q"), limit, viewerID)
+	if h.HandleError(c, err) {
+		return
+	}
+	jsonResponse.ResponseOK(c, result)
+}
@@ -491,6 +491,29 @@ func (h *MusicHandler) GetStreamingURL(c *gin.Context) {
 		return
 	}
 
+	parsedSongID, err := h.parseSongID(songID)
+	if err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid song ID")
+		return
+	}
+	song, err := h.musicService.GetSongByID(c.Request.Context(), parsedSongID)
+	if h.HandleError(c, err) {
+		return
+	}
+	if song == nil || !song.IsActive {
+		jsonResponse.ResponseNotFound(c)
+		return
+	}
+	// Only a signed-in listener has content preferences to check
+	var userID *uint64
+	if value, exists := c.Get("user_id"); exists {
+		id := value.(uint64)
+		userID = &id
+	}
+	if h.HandleError(c, h.musicService.CheckSongAccess(c.Request.Context(), song, userID)) {
+		return
+	}
+
 	objectPath := fmt.Sprintf("processed/%s/%s.%s", songID, format, h.getFormatExtension(format))
 
 	streamingURL, err := h.storageService.GetStreamingURL(
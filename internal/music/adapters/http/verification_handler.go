@@ -0,0 +1,44 @@
+package http
+
+import (
+	model "music-app-backend/internal/music/domain"
+	jsonResponse "music-app-backend/pkg/json"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SubmitVerificationRequest asks admins to verify the current artist
+func (h *MusicHandler) SubmitVerificationRequest(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	request := &model.SubmitVerificationDTO{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	verification, err := h.musicService.SubmitVerificationRequest(c.Request.Context(), userID.(uint64), request)
+	if h.HandleError(c, err) {
+		return
+	}
+	jsonResponse.ResponseCreated(c, verification)
+}
+
+// GetVerificationHistory returns the current artist's verification requests
+func (h *MusicHandler) GetVerificationHistory(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	history, err := h.musicService.GetVerificationHistory(c.Request.Context(), userID.(uint64))
+	if h.HandleError(c, err) {
+		return
+	}
+	jsonResponse.ResponseOK(c, history)
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	model "music-app-backend/internal/music/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// releasedSongs limits a query to the songs an artist page lists: processed, active and
+// in a released tier
+func releasedSongs(db *gorm.DB, artistID uint64, includeExplicit bool) *gorm.DB {
+	query := db.Model(&model.Song{}).
+		Where("artist_id = ? AND is_active = true AND processing_status = ?", artistID, model.ProcessingStatusCompleted).
+		Where("tier IN ?", []model.ContentTier{model.ContentTierPublicDiscovery, model.ContentTierFanExclusives})
+	if !includeExplicit {
+		query = query.Where("is_explicit = false")
+	}
+	return query
+}
+
+func (db *MusicRepository) ListTopSongs(ctx context.Context, artistID uint64, includeExplicit bool, limit int) ([]model.SongSummary, error) {
+	var songs []model.SongSummary
+	err := releasedSongs(db.db.WithContext(ctx), artistID, includeExplicit).
+		Order("play_count DESC, id DESC").
+		Limit(limit).
+		Scan(&songs).Error
+	return songs, err
+}
+
+// ListRecentReleases orders by release date, falling back to the upload date
+func (db *MusicRepository) ListRecentReleases(ctx context.Context, artistID uint64, includeExplicit bool, limit int) ([]model.SongSummary, error) {
+	var songs []model.SongSummary
+	err := releasedSongs(db.db.WithContext(ctx), artistID, includeExplicit).
+		Order("COALESCE(release_date, created_at::date) DESC, created_at DESC").
+		Limit(limit).
+		Scan(&songs).Error
+	return songs, err
+}
+
+// IsFollowing reads the social module's follow table
+func (db *MusicRepository) IsFollowing(ctx context.Context, artistID, userID uint64) (bool, error) {
+	var count int64
+	err := db.db.WithContext(ctx).Table("artist_followers").
+		Where("artist_id = ? AND follower_user_id = ?", artistID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (db *MusicRepository) UpdateArtist(ctx context.Context, artistID uint64, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now().UTC()
+	return db.db.WithContext(ctx).Model(&model.Artist{}).Where("id = ?", artistID).Updates(updates).Error
+}
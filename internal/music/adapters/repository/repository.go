@@ -3,12 +3,16 @@ package repository
 import (
 	"context"
 	model "music-app-backend/internal/music/domain"
+	"music-app-backend/pkg/pagination"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 type IMusicRepository interface {
-	InsertArtist(artist *model.Artist) error
+	GetArtistByID(ctx context.Context, artistID uint64) (*model.Artist, error)
+	GetListedArtistByID(ctx context.Context, artistID uint64) (*model.Artist, error)
+	GetArtistByUserID(ctx context.Context, userID uint64) (*model.Artist, error)
 	CreateUploadSession(ctx context.Context, upload *model.UploadSession) error
 	GetUploadSession(ctx context.Context, uploadID string) (*model.UploadSession, error)
 	UpdateUploadSession(ctx context.Context, uploadID string, updateData string) error
@@ -17,6 +21,21 @@ type IMusicRepository interface {
 	GetSongByID(ctx context.Context, songID uint64) (*model.Song, error)
 	CreateProcessedAudioFormats(ctx context.Context, formats []model.ProcessedAudioFormat) error
 	CreateAudioAnalysis(ctx context.Context, analysis *model.AudioAnalysis) error
+	ListActiveGenreIDs(ctx context.Context, ids []uint64) ([]uint64, error)
+	ListActiveMoodIDs(ctx context.Context, ids []uint64) ([]uint64, error)
+	ListTopSongs(ctx context.Context, artistID uint64, includeExplicit bool, limit int) ([]model.SongSummary, error)
+	ListRecentReleases(ctx context.Context, artistID uint64, includeExplicit bool, limit int) ([]model.SongSummary, error)
+	IsFollowing(ctx context.Context, artistID, userID uint64) (bool, error)
+	UpdateArtist(ctx context.Context, artistID uint64, updates map[string]interface{}) error
+	CreateVerificationRequest(ctx context.Context, request *model.ArtistVerificationRequest) error
+	GetVerificationRequest(ctx context.Context, requestID uint64) (*model.ArtistVerificationRequest, error)
+	HasPendingVerificationRequest(ctx context.Context, artistID uint64) (bool, error)
+	ListVerificationRequests(ctx context.Context, artistID uint64) ([]model.ArtistVerificationRequest, error)
+	ListVerificationQueue(ctx context.Context, status model.VerificationStatus, page pagination.Params) ([]model.VerificationQueueItem, int64, error)
+	ReviewVerificationRequest(ctx context.Context, request *model.ArtistVerificationRequest) error
+	SearchArtists(ctx context.Context, query string, limit int) ([]model.ArtistSearchResult, error)
+	SetSongTakedown(ctx context.Context, songID uint64, reason *string) error
+	SearchSongs(ctx context.Context, query string, includeExplicit bool, limit int) ([]model.SongSearchResult, error)
 }
 
 type MusicRepository struct {
@@ -29,8 +48,44 @@ func NewMusicRepository(db *gorm.DB) *MusicRepository {
 	}
 }
 
-func (db *MusicRepository) InsertArtist(artist *model.Artist) error {
-	return db.db.Create(artist).Error
+func (db *MusicRepository) GetArtistByID(ctx context.Context, artistID uint64) (*model.Artist, error) {
+	var artist model.Artist
+	err := db.db.WithContext(ctx).Where("id = ?", artistID).First(&artist).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &artist, nil
+}
+
+// GetListedArtistByID returns the artist only while their account is active, so artists
+// deactivated here or disabled or deleted in Kratos drop out of public pages
+func (db *MusicRepository) GetListedArtistByID(ctx context.Context, artistID uint64) (*model.Artist, error) {
+	var artist model.Artist
+	err := db.db.WithContext(ctx).
+		Where("id = ? AND EXISTS (SELECT 1 FROM users WHERE users.id = artists.user_id AND users.is_active)", artistID).
+		First(&artist).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &artist, nil
+}
+
+func (db *MusicRepository) GetArtistByUserID(ctx context.Context, userID uint64) (*model.Artist, error) {
+	var artist model.Artist
+	err := db.db.WithContext(ctx).Where("user_id = ?", userID).First(&artist).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &artist, nil
 }
 
 func (db *MusicRepository) CreateUploadSession(ctx context.Context, upload *model.UploadSession) error {
@@ -83,3 +138,37 @@ func (db *MusicRepository) CreateProcessedAudioFormats(ctx context.Context, form
 func (db *MusicRepository) CreateAudioAnalysis(ctx context.Context, analysis *model.AudioAnalysis) error {
 	return db.db.WithContext(ctx).Create(analysis).Error
 }
+
+// ListActiveGenreIDs returns which of ids are active genres
+func (db *MusicRepository) ListActiveGenreIDs(ctx context.Context, ids []uint64) ([]uint64, error) {
+	var active []uint64
+	err := db.db.WithContext(ctx).Model(&model.Genre{}).
+		Where("id IN ? AND is_active = true", ids).
+		Pluck("id", &active).Error
+	return active, err
+}
+
+// ListActiveMoodIDs returns which of ids are active moods
+func (db *MusicRepository) ListActiveMoodIDs(ctx context.Context, ids []uint64) ([]uint64, error) {
+	var active []uint64
+	err := db.db.WithContext(ctx).Model(&model.Mood{}).
+		Where("id IN ? AND is_active = true", ids).
+		Pluck("id", &active).Error
+	return active, err
+}
+
+// SetSongTakedown hides the song everywhere it is served when reason is set, and
+// restores it when reason is nil
+func (db *MusicRepository) SetSongTakedown(ctx context.Context, songID uint64, reason *string) error {
+	now := time.Now().UTC()
+	updates := map[string]interface{}{
+		"is_active":       reason == nil,
+		"taken_down_at":   nil,
+		"takedown_reason": reason,
+		"updated_at":      now,
+	}
+	if reason != nil {
+		updates["taken_down_at"] = now
+	}
+	return db.db.WithContext(ctx).Model(&model.Song{}).Where("id = ?", songID).Updates(updates).Error
+}
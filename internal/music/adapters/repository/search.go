@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	model "music-app-backend/internal/music/domain"
+	"strings"
+)
+
+// Search ranks by how well the name matches, then boosts verified artists and popularity.
+// The verified boost is below the gap between match classes, so an exact match by an
+// unverified artist still ranks above a partial match by a verified one.
+const (
+	verifiedRankBoost = 25
+	// Popularity adds at most this much, on a log scale
+	maxPopularityBoost = 20
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// matchRank scores column against the query: exact 100, prefix 50, substring 10
+func matchRank(column string) string {
+	return fmt.Sprintf("CASE WHEN LOWER(%[1]s) = LOWER(@query) THEN 100 WHEN %[1]s ILIKE @prefix THEN 50 ELSE 10 END", column)
+}
+
+func rankScore(column, verified, popularity string) string {
+	return fmt.Sprintf("%s + CASE WHEN %s THEN %d ELSE 0 END + LEAST(LN(1 + GREATEST(%s, 0)) * 2, %d)",
+		matchRank(column), verified, verifiedRankBoost, popularity, maxPopularityBoost)
+}
+
+func searchArgs(query string) map[string]interface{} {
+	escaped := likeEscaper.Replace(query)
+	return map[string]interface{}{
+		"query":    query,
+		"prefix":   escaped + "%",
+		"contains": "%" + escaped + "%",
+	}
+}
+
+func (db *MusicRepository) SearchArtists(ctx context.Context, query string, limit int) ([]model.ArtistSearchResult, error) {
+	var artists []model.ArtistSearchResult
+	err := db.db.WithContext(ctx).Raw(fmt.Sprintf(`
+		SELECT id, artist_name, profile_image_url, is_verified, follower_count
+		FROM artists
+		WHERE artist_name ILIKE @contains
+			AND EXISTS (SELECT 1 FROM users WHERE users.id = artists.user_id AND users.is_active)
+		ORDER BY %s DESC, follower_count DESC, id DESC
+		LIMIT @limit`, rankScore("artist_name", "is_verified", "follower_count")),
+		withLimit(searchArgs(query), limit)).
+		Scan(&artists).Error
+	return artists, err
+}
+
+// SearchSongs only finds songs an artist page would list, so the songs of an artist whose
+// account is deactivated, or disabled or deleted in Kratos, drop out with the artist
+func (db *MusicRepository) SearchSongs(ctx context.Context, query string, includeExplicit bool, limit int) ([]model.SongSearchResult, error) {
+	args := withLimit(searchArgs(query), limit)
+	args["tiers"] = []model.ContentTier{model.ContentTierPublicDiscovery, model.ContentTierFanExclusives}
+	args["completed"] = model.ProcessingStatusCompleted
+	args["include_explicit"] = includeExplicit
+
+	var songs []model.SongSearchResult
+	err := db.db.WithContext(ctx).Raw(fmt.Sprintf(`
+		SELECT s.id, s.title, s.artwork_url, s.duration_seconds, s.genre_id, s.mood_id, s.tier,
+			s.is_explicit, s.play_count, s.like_count, s.release_date, s.created_at,
+			a.id AS artist_id, a.artist_name, a.is_verified AS artist_is_verified
+		FROM songs AS s
+		JOIN artists AS a ON a.id = s.artist_id
+		WHERE s.title ILIKE @contains
+			AND s.is_active = true AND s.processing_status = @completed AND s.tier IN @tiers
+			AND EXISTS (SELECT 1 FROM users WHERE users.id = a.user_id AND users.is_active)
+			AND (@include_explicit OR s.is_explicit = false)
+		ORDER BY %s DESC, s.play_count DESC, s.id DESC
+		LIMIT @limit`, rankScore("s.title", "a.is_verified", "s.play_count")), args).
+		Scan(&songs).Error
+	return songs, err
+}
+
+func withLimit(args map[string]interface{}, limit int) map[string]interface{} {
+	args["limit"] = limit
+	return args
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	model "music-app-backend/internal/music/domain"
+	"music-app-backend/pkg/pagination"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrVerificationAlreadyReviewed is returned when another reviewer decided the request first
+var ErrVerificationAlreadyReviewed = errors.New("verification request already reviewed")
+
+// CreateVerificationRequest stores the request and marks the artist as waiting for review
+func (db *MusicRepository) CreateVerificationRequest(ctx context.Context, request *model.ArtistVerificationRequest) error {
+	return db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(request).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.Artist{}).Where("id = ?", request.ArtistID).Updates(map[string]interface{}{
+			"verification_requested_at": request.CreatedAt,
+			"updated_at":                request.CreatedAt,
+		}).Error
+	})
+}
+
+func (db *MusicRepository) GetVerificationRequest(ctx context.Context, requestID uint64) (*model.ArtistVerificationRequest, error) {
+	var request model.ArtistVerificationRequest
+	err := db.db.WithContext(ctx).Where("id = ?", requestID).First(&request).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (db *MusicRepository) HasPendingVerificationRequest(ctx context.Context, artistID uint64) (bool, error) {
+	var count int64
+	err := db.db.WithContext(ctx).Model(&model.ArtistVerificationRequest{}).
+		Where("artist_id = ? AND status = ?", artistID, model.VerificationStatusPending).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// ListVerificationRequests returns an artist's requests, newest first
+func (db *MusicRepository) ListVerificationRequests(ctx context.Context, artistID uint64) ([]model.ArtistVerificationRequest, error) {
+	var requests []model.ArtistVerificationRequest
+	err := db.db.WithContext(ctx).
+		Where("artist_id = ?", artistID).
+		Order("created_at DESC, id DESC").
+		Find(&requests).Error
+	return requests, err
+}
+
+// ListVerificationQueue returns requests in a status, oldest first so reviewers work
+// through pending requests in the order they came in
+func (db *MusicRepository) ListVerificationQueue(ctx context.Context, status model.VerificationStatus, page pagination.Params) ([]model.VerificationQueueItem, int64, error) {
+	var total int64
+	err := db.db.WithContext(ctx).Model(&model.ArtistVerificationRequest{}).
+		Where("status = ?", status).
+		Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var items []model.VerificationQueueItem
+	err = db.db.WithContext(ctx).Table("artist_verification_requests AS r").
+		Select("r.*, a.artist_name, a.follower_count, a.total_plays").
+		Joins("JOIN artists AS a ON a.id = r.artist_id").
+		Where("r.status = ?", status).
+		Order("r.created_at ASC, r.id ASC").
+		Offset(page.Offset()).
+		Limit(page.Limit).
+		Scan(&items).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// ReviewVerificationRequest records the decision and applies it to the artist. Only a
+// pending request can be reviewed, so two reviewers cannot both decide it.
+func (db *MusicRepository) ReviewVerificationRequest(ctx context.Context, request *model.ArtistVerificationRequest) error {
+	return db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.ArtistVerificationRequest{}).
+			Where("id = ? AND status = ?", request.ID, model.VerificationStatusPending).
+			Updates(map[string]interface{}{
+				"status":           request.Status,
+				"reviewer_id":      request.ReviewerID,
+				"rejection_reason": request.RejectionReason,
+				"reviewed_at":      request.ReviewedAt,
+				"updated_at":       time.Now().UTC(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrVerificationAlreadyReviewed
+		}
+
+		updates := map[string]interface{}{
+			"verification_requested_at": nil,
+			"updated_at":                time.Now().UTC(),
+		}
+		if request.Status == model.VerificationStatusApproved {
+			updates["is_verified"] = true
+		}
+		return tx.Model(&model.Artist{}).Where("id = ?", request.ArtistID).Updates(updates).Error
+	})
+}
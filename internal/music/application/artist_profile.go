@@ -0,0 +1,211 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	model "music-app-backend/internal/music/domain"
+	appError "music-app-backend/pkg/error"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	artistPageSongLimit = 10
+	maxArtistNameLength = 150
+	maxProfileURLLength = 2048
+)
+
+// socialLinkHosts lists where each social link may point; subdomains such as
+// open.spotify.com or music.youtube.com are accepted too
+var socialLinkHosts = map[string][]string{
+	"spotify_url":   {"spotify.com"},
+	"instagram_url": {"instagram.com"},
+	"twitter_url":   {"twitter.com", "x.com"},
+	"youtube_url":   {"youtube.com", "youtu.be"},
+}
+
+// GetMyArtistProfile returns the full artist record of the current user
+func (s *MusicService) GetMyArtistProfile(ctx context.Context, userID uint64) (*model.Artist, error) {
+	artist, err := s.repository.GetArtistByUserID(ctx, userID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load artist")
+	}
+	if artist == nil {
+		return nil, appError.NewNotFoundError(nil, "artist profile not found")
+	}
+	return artist, nil
+}
+
+// UpdateArtistProfile applies the fields present in request to the current user's artist
+func (s *MusicService) UpdateArtistProfile(ctx context.Context, userID uint64, request *model.UpdateArtistProfileDTO) (*model.Artist, error) {
+	artist, err := s.GetMyArtistProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := map[string]interface{}{}
+	if request.ArtistName != nil {
+		name, err := normalizeArtistName(*request.ArtistName)
+		if err != nil {
+			return nil, err
+		}
+		updates["artist_name"] = name
+	}
+	if request.Bio != nil {
+		bio := strings.TrimSpace(*request.Bio)
+		if bio == "" {
+			updates["bio"] = nil
+		} else {
+			updates["bio"] = bio
+		}
+	}
+
+	links := map[string]*string{
+		"profile_image_url": request.ProfileImageURL,
+		"banner_image_url":  request.BannerImageURL,
+		"website_url":       request.WebsiteURL,
+		"spotify_url":       request.SpotifyURL,
+		"instagram_url":     request.InstagramURL,
+		"twitter_url":       request.TwitterURL,
+		"youtube_url":       request.YoutubeURL,
+	}
+	for column, value := range links {
+		if value == nil {
+			continue
+		}
+		link, err := validateProfileURL(column, *value, socialLinkHosts[column])
+		if err != nil {
+			return nil, err
+		}
+		updates[column] = link
+	}
+
+	if len(updates) == 0 {
+		return artist, nil
+	}
+
+	// Kratos first, as for the user profile, or the next identity sync would undo the rename
+	if name, ok := updates["artist_name"].(string); ok && name != artist.ArtistName && s.identityTraits != nil {
+		if err := s.identityTraits.SetArtistNameTrait(ctx, userID, name); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.repository.UpdateArtist(ctx, artist.ID, updates); err != nil {
+		return nil, appError.NewInternalError(err, "failed to update artist profile")
+	}
+	return s.GetMyArtistProfile(ctx, userID)
+}
+
+// SyncArtistName sets the name of the user's artist from their Kratos identity and
+// reports whether it changed. Users without an artist are left alone.
+func (s *MusicService) SyncArtistName(ctx context.Context, userID uint64, name string) (bool, error) {
+	name, err := normalizeArtistName(name)
+	if err != nil {
+		return false, err
+	}
+	artist, err := s.repository.GetArtistByUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if artist == nil || artist.ArtistName == name {
+		return false, nil
+	}
+	if err := s.repository.UpdateArtist(ctx, artist.ID, map[string]interface{}{"artist_name": name}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// normalizeArtistName trims name and checks its length
+func normalizeArtistName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxArtistNameLength {
+		return "", appError.NewBadRequestError(nil, fmt.Sprintf("artist name must be 1 to %d characters", maxArtistNameLength))
+	}
+	return name, nil
+}
+
+// GetArtistPage builds the public page of an artist. Anonymous viewers and viewers who
+// have not allowed explicit content do not see explicit songs; the artist sees all of
+// their released songs.
+func (s *MusicService) GetArtistPage(ctx context.Context, artistID uint64, viewerID *uint64) (*model.ArtistPage, error) {
+	artist, err := s.repository.GetListedArtistByID(ctx, artistID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load artist")
+	}
+	if artist == nil {
+		return nil, appError.NewNotFoundError(nil, "artist not found")
+	}
+
+	page := &model.ArtistPage{Artist: model.NewArtistPublicProfile(artist)}
+	includeExplicit := s.contentPolicy == nil
+
+	if viewerID != nil {
+		following, err := s.repository.IsFollowing(ctx, artist.ID, *viewerID)
+		if err != nil {
+			return nil, appError.NewInternalError(err, "failed to load follow status")
+		}
+		page.Viewer = &model.ArtistPageViewer{
+			IsFollowing: following,
+			IsOwner:     artist.UserID == *viewerID,
+		}
+
+		if page.Viewer.IsOwner {
+			includeExplicit = true
+		} else if s.contentPolicy != nil {
+			includeExplicit, err = s.contentPolicy.ExplicitContentAllowed(ctx, *viewerID)
+			if err != nil {
+				return nil, appError.NewInternalError(err, "failed to load content preferences")
+			}
+		}
+	}
+
+	page.TopSongs, err = s.repository.ListTopSongs(ctx, artist.ID, includeExplicit, artistPageSongLimit)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load top songs")
+	}
+	page.RecentReleases, err = s.repository.ListRecentReleases(ctx, artist.ID, includeExplicit, artistPageSongLimit)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load recent releases")
+	}
+	if page.TopSongs == nil {
+		page.TopSongs = []model.SongSummary{}
+	}
+	if page.RecentReleases == nil {
+		page.RecentReleases = []model.SongSummary{}
+	}
+
+	return page, nil
+}
+
+// validateProfileURL accepts absolute http(s) URLs, limited to hosts when given. An empty
+// value clears the field.
+func validateProfileURL(field, value string, hosts []string) (*string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil || len(value) > maxProfileURLLength || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return nil, appError.NewBadRequestError(err, fmt.Sprintf("%s must be an http or https URL", field))
+	}
+
+	if len(hosts) > 0 {
+		hostname := strings.ToLower(parsed.Hostname())
+		allowed := false
+		for _, host := range hosts {
+			if hostname == host || strings.HasSuffix(hostname, "."+host) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return nil, appError.NewBadRequestError(nil, fmt.Sprintf("%s must link to %s", field, strings.Join(hosts, " or ")))
+		}
+	}
+
+	link := parsed.String()
+	return &link, nil
+}
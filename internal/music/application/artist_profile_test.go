@@ -0,0 +1,65 @@
+package application
+
+import (
+	appError "music-app-backend/pkg/error"
+	"strings"
+	"testing"
+)
+
+func TestValidateProfileURL(t *testing.T) {
+	spotify := socialLinkHosts["spotify_url"]
+	twitter := socialLinkHosts["twitter_url"]
+
+	tests := []struct {
+		name    string
+		value   string
+		hosts   []string
+		want    string // Empty means the field is cleared
+		wantErr bool
+	}{
+		{name: "empty clears the field", value: "", hosts: spotify},
+		{name: "blank clears the field", value: "   ", hosts: spotify},
+		{name: "any host for a website", value: "https://example.com/band", want: "https://example.com/band"},
+		{name: "http allowed", value: "http://example.com", want: "http://example.com"},
+		{name: "surrounding spaces trimmed", value: "  https://example.com  ", want: "https://example.com"},
+		{name: "allowed host", value: "https://spotify.com/artist/1", hosts: spotify, want: "https://spotify.com/artist/1"},
+		{name: "allowed subdomain", value: "https://open.spotify.com/artist/1", hosts: spotify, want: "https://open.spotify.com/artist/1"},
+		{name: "host matched case-insensitively", value: "https://Open.Spotify.COM/artist/1", hosts: spotify, want: "https://Open.Spotify.COM/artist/1"},
+		{name: "second allowed host", value: "https://x.com/band", hosts: twitter, want: "https://x.com/band"},
+		{name: "other host", value: "https://example.com/artist/1", hosts: spotify, wantErr: true},
+		{name: "lookalike host", value: "https://notspotify.com/artist/1", hosts: spotify, wantErr: true},
+		{name: "allowed host as a prefix", value: "https://spotify.com.evil.test/artist/1", hosts: spotify, wantErr: true},
+		{name: "allowed host in userinfo", value: "https://spotify.com@evil.test/artist/1", hosts: spotify, wantErr: true},
+		{name: "javascript scheme", value: "javascript:alert(1)", wantErr: true},
+		{name: "ftp scheme", value: "ftp://example.com/file", wantErr: true},
+		{name: "relative path", value: "/artist/1", wantErr: true},
+		{name: "no scheme", value: "spotify.com/artist/1", hosts: spotify, wantErr: true},
+		{name: "no host", value: "https:///artist/1", wantErr: true},
+		{name: "unparseable", value: "https://exa mple.com/%zz", wantErr: true},
+		{name: "too long", value: "https://example.com/" + strings.Repeat("a", maxProfileURLLength), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validateProfileURL("website_url", tt.value, tt.hosts)
+			if tt.wantErr {
+				if !appError.IsAppError(err) {
+					t.Fatalf("err = %v, want a bad request error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.want == "" {
+				if got != nil {
+					t.Fatalf("got %q, want the field cleared", *got)
+				}
+				return
+			}
+			if got == nil || *got != tt.want {
+				t.Fatalf("got %v, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package application
+
+import (
+	"context"
+	model "music-app-backend/internal/music/domain"
+	appError "music-app-backend/pkg/error"
+)
+
+// CheckSongAccess rejects explicit songs for listeners who have not allowed explicit
+// content. Anonymous listeners have no preferences and keep the default, which is off.
+func (s *MusicService) CheckSongAccess(ctx context.Context, song *model.Song, userID *uint64) error {
+	if !song.IsExplicit || s.contentPolicy == nil {
+		return nil
+	}
+	if userID == nil {
+		return appError.NewForbiddenError(nil, "explicit content is not allowed")
+	}
+
+	allowed, err := s.contentPolicy.ExplicitContentAllowed(ctx, *userID)
+	if err != nil {
+		return appError.NewInternalError(err, "failed to load content preferences")
+	}
+	if !allowed {
+		return appError.NewForbiddenError(nil, "explicit content is not allowed")
+	}
+	return nil
+}
+
+func (s *MusicService) ListActiveGenreIDs(ctx context.Context, ids []uint64) ([]uint64, error) {
+	return s.repository.ListActiveGenreIDs(ctx, ids)
+}
+
+func (s *MusicService) ListActiveMoodIDs(ctx context.Context, ids []uint64) ([]uint64, error) {
+	return s.repository.ListActiveMoodIDs(ctx, ids)
+}
@@ -0,0 +1,76 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	model "music-app-backend/internal/music/domain"
+	"music-app-backend/pkg/audit"
+	appError "music-app-backend/pkg/error"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const maxTakedownReasonLength = 1000
+
+// TakeDownSong removes a song from streaming, playback, tips and artist pages. The files
+// are kept so the takedown can be reversed.
+func (s *MusicService) TakeDownSong(ctx context.Context, songID uint64, reason string) (*model.Song, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, appError.NewBadRequestError(nil, "a reason is required to take down a song")
+	}
+	if utf8.RuneCountInString(reason) > maxTakedownReasonLength {
+		return nil, appError.NewBadRequestError(nil, fmt.Sprintf("reason must be at most %d characters", maxTakedownReasonLength))
+	}
+	return s.setSongTakedown(ctx, songID, &reason)
+}
+
+// RestoreSong reverses a takedown
+func (s *MusicService) RestoreSong(ctx context.Context, songID uint64) (*model.Song, error) {
+	return s.setSongTakedown(ctx, songID, nil)
+}
+
+func (s *MusicService) setSongTakedown(ctx context.Context, songID uint64, reason *string) (*model.Song, error) {
+	song, err := s.repository.GetSongByID(ctx, songID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load song")
+	}
+	if song == nil {
+		return nil, appError.NewNotFoundError(nil, "song not found")
+	}
+	if reason == nil && song.TakenDownAt == nil {
+		return nil, appError.NewBadRequestError(nil, "song is not taken down")
+	}
+
+	if err := s.repository.SetSongTakedown(ctx, songID, reason); err != nil {
+		return nil, appError.NewInternalError(err, "failed to update song")
+	}
+	updated, err := s.repository.GetSongByID(ctx, songID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load song")
+	}
+
+	action := "song.takedown"
+	if reason == nil {
+		action = "song.restore"
+	}
+	audit.Write(ctx, s.auditLog, &audit.Entry{
+		Action:     action,
+		TargetType: "song",
+		TargetID:   strconv.FormatUint(songID, 10),
+		Before:     songTakedownState(song),
+		After:      songTakedownState(updated),
+	})
+	return updated, nil
+}
+
+func songTakedownState(song *model.Song) map[string]interface{} {
+	if song == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"taken_down_at":   song.TakenDownAt,
+		"takedown_reason": song.TakedownReason,
+	}
+}
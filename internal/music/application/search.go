@@ -0,0 +1,58 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	model "music-app-backend/internal/music/domain"
+	appError "music-app-backend/pkg/error"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	minSearchQueryLength = 2
+	maxSearchQueryLength = 100
+	defaultSearchLimit   = 10
+	maxSearchLimit       = 50
+)
+
+// Search finds artists by name and released songs by title. Results are ranked by match,
+// with verified artists and their songs boosted; explicit songs follow the same rules as
+// artist pages.
+func (s *MusicService) Search(ctx context.Context, query string, limit int, viewerID *uint64) (*model.SearchResult, error) {
+	query = strings.TrimSpace(query)
+	length := utf8.RuneCountInString(query)
+	if length < minSearchQueryLength || length > maxSearchQueryLength {
+		return nil, appError.NewBadRequestError(nil, fmt.Sprintf("query must be %d to %d characters", minSearchQueryLength, maxSearchQueryLength))
+	}
+	if limit < 1 {
+		limit = defaultSearchLimit
+	}
+	limit = min(limit, maxSearchLimit)
+
+	includeExplicit := s.contentPolicy == nil
+	if viewerID != nil && s.contentPolicy != nil {
+		allowed, err := s.contentPolicy.ExplicitContentAllowed(ctx, *viewerID)
+		if err != nil {
+			return nil, appError.NewInternalError(err, "failed to load content preferences")
+		}
+		includeExplicit = allowed
+	}
+
+	artists, err := s.repository.SearchArtists(ctx, query, limit)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to search artists")
+	}
+	songs, err := s.repository.SearchSongs(ctx, query, includeExplicit, limit)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to search songs")
+	}
+
+	if artists == nil {
+		artists = []model.ArtistSearchResult{}
+	}
+	if songs == nil {
+		songs = []model.SongSearchResult{}
+	}
+	return &model.SearchResult{Artists: artists, Songs: songs}, nil
+}
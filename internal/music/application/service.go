@@ -1,35 +1,118 @@
 package application
 
 import (
+	"context"
 	"music-app-backend/internal/music/adapters/repository"
 	model "music-app-backend/internal/music/domain"
+	realtimeModel "music-app-backend/internal/realtime/domain"
+	"music-app-backend/pkg/audit"
 	baseModel "music-app-backend/pkg/model"
+	"music-app-backend/pkg/pagination"
 
 	goflakeid "github.com/capy-engineer/go-flakeid"
 )
 
 type IMusicService interface {
-	InsertArtist(artist *model.CreateArtistDTO) error
+	NewArtist(artist *model.CreateArtistDTO) (*model.Artist, error)
+	GetArtistByID(ctx context.Context, artistID uint64) (*model.Artist, error)
+	GetArtistByUserID(ctx context.Context, userID uint64) (*model.Artist, error)
+	GetSongByID(ctx context.Context, songID uint64) (*model.Song, error)
+	CheckSongAccess(ctx context.Context, song *model.Song, userID *uint64) error
+	ListActiveGenreIDs(ctx context.Context, ids []uint64) ([]uint64, error)
+	ListActiveMoodIDs(ctx context.Context, ids []uint64) ([]uint64, error)
+	ListVerificationQueue(ctx context.Context, status model.VerificationStatus, page pagination.Params) (*model.VerificationQueueResult, error)
+	ReviewVerificationRequest(ctx context.Context, reviewerID, requestID uint64, decision *model.ReviewVerificationDTO) (*model.ArtistVerificationRequest, error)
+	TakeDownSong(ctx context.Context, songID uint64, reason string) (*model.Song, error)
+	RestoreSong(ctx context.Context, songID uint64) (*model.Song, error)
+	SyncArtistName(ctx context.Context, userID uint64, name string) (bool, error)
+}
+
+// EventPublisher pushes song events to the artist's live dashboard. It is declared here
+// rather than imported because the realtime module depends on this package.
+type EventPublisher interface {
+	PublishArtistEvent(ctx context.Context, artistID uint64, eventType realtimeModel.EventType, data interface{}) error
+}
+
+// ProcessingListener is told when a song finished processing, successfully or not.
+// Declared here for the same reason as EventPublisher.
+type ProcessingListener interface {
+	SongProcessed(ctx context.Context, song *model.Song, success bool)
+}
+
+// ContentPolicy answers a listener's content settings. Declared here because the user
+// module depends on this package.
+type ContentPolicy interface {
+	ExplicitContentAllowed(ctx context.Context, userID uint64) (bool, error)
+}
+
+// IdentityTraits writes the artist name to the user's Kratos identity, which identity sync
+// copies back to the artist. Declared here for the same reason as ContentPolicy.
+type IdentityTraits interface {
+	SetArtistNameTrait(ctx context.Context, userID uint64, name string) error
+}
+
+// VerificationListener is told when an admin decided an artist's verification request.
+// Declared here for the same reason as EventPublisher.
+type VerificationListener interface {
+	ArtistVerificationReviewed(ctx context.Context, artist *model.Artist, request *model.ArtistVerificationRequest)
 }
 
 type MusicService struct {
-	repository repository.IMusicRepository
-	generator  *goflakeid.Generator
+	repository            repository.IMusicRepository
+	generator             *goflakeid.Generator
+	eventPublisher        EventPublisher
+	processingListeners   []ProcessingListener
+	contentPolicy         ContentPolicy
+	identityTraits        IdentityTraits
+	verificationListeners []VerificationListener
+	auditLog              audit.Logger
+}
+
+func NewMusicService(repository repository.IMusicRepository, generator *goflakeid.Generator, auditLog audit.Logger) *MusicService {
+	return &MusicService{repository: repository, generator: generator, auditLog: auditLog}
+}
+
+// SetEventPublisher enables dashboard events once the realtime module is built
+func (s *MusicService) SetEventPublisher(publisher EventPublisher) {
+	s.eventPublisher = publisher
 }
 
-func NewMusicService(repository repository.IMusicRepository, generator *goflakeid.Generator) *MusicService {
-	return &MusicService{repository: repository, generator: generator}
+// AddProcessingListener registers a module to be told about finished processing
+func (s *MusicService) AddProcessingListener(listener ProcessingListener) {
+	s.processingListeners = append(s.processingListeners, listener)
 }
 
-func (s *MusicService) InsertArtist(artist *model.CreateArtistDTO) error {
+// AddVerificationListener registers a module to be told about verification decisions
+func (s *MusicService) AddVerificationListener(listener VerificationListener) {
+	s.verificationListeners = append(s.verificationListeners, listener)
+}
+
+// SetContentPolicy enables explicit content checks once the user module is built
+func (s *MusicService) SetContentPolicy(policy ContentPolicy) {
+	s.contentPolicy = policy
+}
+
+// SetIdentityTraits keeps artist renames in Kratos once the user module is built
+func (s *MusicService) SetIdentityTraits(traits IdentityTraits) {
+	s.identityTraits = traits
+}
+
+// NewArtist validates the artist and builds its record without saving it, so the caller
+// can insert it in the same transaction as the user it belongs to
+func (s *MusicService) NewArtist(artist *model.CreateArtistDTO) (*model.Artist, error) {
+	name, err := normalizeArtistName(artist.ArtistName)
+	if err != nil {
+		return nil, err
+	}
+
 	_base, err := baseModel.NewBaseModel(s.generator)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	artistModel := &model.Artist{
+	return &model.Artist{
 		BaseModel:       *_base,
 		UserID:          artist.UserID,
-		ArtistName:      artist.ArtistName,
+		ArtistName:      name,
 		Bio:             artist.Bio,
 		ProfileImageURL: artist.ProfileImageURL,
 		BannerImageURL:  artist.BannerImageURL,
@@ -38,7 +121,13 @@ func (s *MusicService) InsertArtist(artist *model.CreateArtistDTO) error {
 		InstagramURL:    artist.InstagramURL,
 		TwitterURL:      artist.TwitterURL,
 		YoutubeURL:      artist.YoutubeURL,
-	}
+	}, nil
+}
+
+func (s *MusicService) GetArtistByID(ctx context.Context, artistID uint64) (*model.Artist, error) {
+	return s.repository.GetArtistByID(ctx, artistID)
+}
 
-	return s.repository.InsertArtist(artistModel)
+func (s *MusicService) GetArtistByUserID(ctx context.Context, userID uint64) (*model.Artist, error) {
+	return s.repository.GetArtistByUserID(ctx, userID)
 }
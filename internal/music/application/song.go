@@ -2,7 +2,9 @@ package application
 
 import (
 	"context"
+	"log"
 	model "music-app-backend/internal/music/domain"
+	realtimeModel "music-app-backend/internal/realtime/domain"
 	baseModel "music-app-backend/pkg/model"
 	"music-app-backend/pkg/queue"
 )
@@ -58,7 +60,41 @@ func (s *MusicService) UpdateSongWithProcessingResults(ctx context.Context, song
 		}
 	}
 
-	return s.repository.UpdateSongProcessingResult(ctx, songID, updates)
+	if err := s.repository.UpdateSongProcessingResult(ctx, songID, updates); err != nil {
+		return err
+	}
+
+	s.announceSongProcessed(ctx, songID, result)
+	return nil
+}
+
+// announceSongProcessed publishes the dashboard event and tells the processing listeners
+func (s *MusicService) announceSongProcessed(ctx context.Context, songID uint64, result *queue.AudioProcessingResult) {
+	if s.eventPublisher == nil && len(s.processingListeners) == 0 {
+		return
+	}
+
+	song, err := s.GetSongByID(ctx, songID)
+	if err != nil || song == nil {
+		log.Printf("Failed to load song %d for processed event: %v", songID, err)
+		return
+	}
+
+	for _, listener := range s.processingListeners {
+		listener.SongProcessed(ctx, song, result.Success)
+	}
+
+	if s.eventPublisher == nil {
+		return
+	}
+	if err := s.eventPublisher.PublishArtistEvent(ctx, song.ArtistID, realtimeModel.EventSongProcessed, realtimeModel.SongProcessedData{
+		SongID:  song.ID,
+		Title:   song.Title,
+		Success: result.Success,
+		Error:   result.Error,
+	}); err != nil {
+		log.Printf("Failed to publish processed event for song %d: %v", songID, err)
+	}
 }
 
 func (s *MusicService) storeProcessedAudioFormats(ctx context.Context, songID uint64, formats []queue.ProcessedAudioFormat) error {
@@ -0,0 +1,183 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"music-app-backend/internal/music/adapters/repository"
+	model "music-app-backend/internal/music/domain"
+	"music-app-backend/pkg/audit"
+	appError "music-app-backend/pkg/error"
+	baseModel "music-app-backend/pkg/model"
+	"music-app-backend/pkg/pagination"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/lib/pq"
+)
+
+const (
+	maxEvidenceLinks           = 5
+	maxVerificationMessage     = 1000
+	maxVerificationReasonChars = 1000
+)
+
+// SubmitVerificationRequest asks admins to verify the current user's artist. An artist
+// can have one pending request; after a rejection they may submit again.
+func (s *MusicService) SubmitVerificationRequest(ctx context.Context, userID uint64, request *model.SubmitVerificationDTO) (*model.ArtistVerificationRequest, error) {
+	artist, err := s.GetMyArtistProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if artist.IsVerified {
+		return nil, appError.NewBadRequestError(nil, "artist is already verified")
+	}
+
+	pending, err := s.repository.HasPendingVerificationRequest(ctx, artist.ID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load verification requests")
+	}
+	if pending {
+		return nil, appError.NewBadRequestError(nil, "a verification request is already waiting for review")
+	}
+
+	if len(request.EvidenceLinks) == 0 || len(request.EvidenceLinks) > maxEvidenceLinks {
+		return nil, appError.NewBadRequestError(nil, fmt.Sprintf("provide 1 to %d evidence links", maxEvidenceLinks))
+	}
+	links := make(pq.StringArray, 0, len(request.EvidenceLinks))
+	for _, value := range request.EvidenceLinks {
+		link, err := validateProfileURL("evidence_links", value, nil)
+		if err != nil {
+			return nil, err
+		}
+		if link == nil {
+			return nil, appError.NewBadRequestError(nil, "evidence links must not be empty")
+		}
+		links = append(links, *link)
+	}
+
+	message := optionalTrimmed(request.Message)
+	if message != nil && utf8.RuneCountInString(*message) > maxVerificationMessage {
+		return nil, appError.NewBadRequestError(nil, fmt.Sprintf("message must be at most %d characters", maxVerificationMessage))
+	}
+
+	_base, err := baseModel.NewBaseModel(s.generator)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to create verification request")
+	}
+	verification := &model.ArtistVerificationRequest{
+		BaseModel:     *_base,
+		ArtistID:      artist.ID,
+		Status:        model.VerificationStatusPending,
+		EvidenceLinks: links,
+		Message:       message,
+	}
+	if err := s.repository.CreateVerificationRequest(ctx, verification); err != nil {
+		return nil, appError.NewInternalError(err, "failed to create verification request")
+	}
+	return verification, nil
+}
+
+// GetVerificationHistory returns the current artist's status and every request they made
+func (s *MusicService) GetVerificationHistory(ctx context.Context, userID uint64) (*model.VerificationHistory, error) {
+	artist, err := s.GetMyArtistProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	requests, err := s.repository.ListVerificationRequests(ctx, artist.ID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load verification requests")
+	}
+	if requests == nil {
+		requests = []model.ArtistVerificationRequest{}
+	}
+	return &model.VerificationHistory{IsVerified: artist.IsVerified, Requests: requests}, nil
+}
+
+// ListVerificationQueue lists requests for reviewers, pending ones by default
+func (s *MusicService) ListVerificationQueue(ctx context.Context, status model.VerificationStatus, page pagination.Params) (*model.VerificationQueueResult, error) {
+	switch status {
+	case "":
+		status = model.VerificationStatusPending
+	case model.VerificationStatusPending, model.VerificationStatusApproved, model.VerificationStatusRejected:
+	default:
+		return nil, appError.NewBadRequestError(nil, "status must be pending, approved or rejected")
+	}
+
+	items, total, err := s.repository.ListVerificationQueue(ctx, status, page)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to list verification requests")
+	}
+	if items == nil {
+		items = []model.VerificationQueueItem{}
+	}
+	return &model.VerificationQueueResult{Requests: items, Pagination: page.Meta(total)}, nil
+}
+
+// ReviewVerificationRequest approves or rejects a pending request and tells the artist
+func (s *MusicService) ReviewVerificationRequest(ctx context.Context, reviewerID, requestID uint64, decision *model.ReviewVerificationDTO) (*model.ArtistVerificationRequest, error) {
+	request, err := s.repository.GetVerificationRequest(ctx, requestID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load verification request")
+	}
+	if request == nil {
+		return nil, appError.NewNotFoundError(nil, "verification request not found")
+	}
+	if request.Status != model.VerificationStatusPending {
+		return nil, appError.NewBadRequestError(nil, "verification request was already reviewed")
+	}
+
+	reviewedAt := time.Now().UTC()
+	request.ReviewerID = &reviewerID
+	request.ReviewedAt = &reviewedAt
+	if decision.Approve {
+		request.Status = model.VerificationStatusApproved
+	} else {
+		reason := strings.TrimSpace(decision.Reason)
+		if reason == "" {
+			return nil, appError.NewBadRequestError(nil, "a reason is required to reject a request")
+		}
+		if utf8.RuneCountInString(reason) > maxVerificationReasonChars {
+			return nil, appError.NewBadRequestError(nil, fmt.Sprintf("reason must be at most %d characters", maxVerificationReasonChars))
+		}
+		request.Status = model.VerificationStatusRejected
+		request.RejectionReason = &reason
+	}
+
+	if err := s.repository.ReviewVerificationRequest(ctx, request); err != nil {
+		if errors.Is(err, repository.ErrVerificationAlreadyReviewed) {
+			return nil, appError.NewBadRequestError(err, "verification request was already reviewed")
+		}
+		return nil, appError.NewInternalError(err, "failed to review verification request")
+	}
+	audit.Write(ctx, s.auditLog, &audit.Entry{
+		Action:     "artist_verification.review",
+		TargetType: "artist_verification_request",
+		TargetID:   strconv.FormatUint(request.ID, 10),
+		Before:     map[string]interface{}{"status": model.VerificationStatusPending},
+		After:      map[string]interface{}{"status": request.Status, "rejection_reason": request.RejectionReason},
+		Metadata:   map[string]interface{}{"artist_id": request.ArtistID},
+	})
+
+	artist, err := s.repository.GetArtistByID(ctx, request.ArtistID)
+	if err == nil && artist != nil {
+		for _, listener := range s.verificationListeners {
+			listener.ArtistVerificationReviewed(ctx, artist, request)
+		}
+	}
+	return request, nil
+}
+
+func optionalTrimmed(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
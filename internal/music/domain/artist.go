@@ -2,6 +2,7 @@ package model
 
 import (
 	"music-app-backend/pkg/model"
+	"time"
 )
 
 type Artist struct {
@@ -17,7 +18,7 @@ type Artist struct {
 	TwitterURL              *string  `json:"twitter_url"`
 	YoutubeURL              *string  `json:"youtube_url"`
 	IsVerified              bool    `json:"is_verified" gorm:"default:false"`
-	VerificationRequestedAt *int64  `json:"verification_requested_at"`
+	VerificationRequestedAt *time.Time `json:"verification_requested_at"` // Set while a request is pending
 	TotalPlays              int64   `json:"total_plays" gorm:"default:0"`
 	TotalEarnings           float64 `json:"total_earnings" gorm:"type:decimal(10,2);default:0.00"`
 	FollowerCount           int     `json:"follower_count" gorm:"default:0"`
@@ -0,0 +1,82 @@
+package model
+
+import "time"
+
+// UpdateArtistProfileDTO is a partial update by the artist; an empty string clears an
+// optional field
+type UpdateArtistProfileDTO struct {
+	ArtistName      *string `json:"artist_name"`
+	Bio             *string `json:"bio"`
+	ProfileImageURL *string `json:"profile_image_url"`
+	BannerImageURL  *string `json:"banner_image_url"`
+	WebsiteURL      *string `json:"website_url"`
+	SpotifyURL      *string `json:"spotify_url"`
+	InstagramURL    *string `json:"instagram_url"`
+	TwitterURL      *string `json:"twitter_url"`
+	YoutubeURL      *string `json:"youtube_url"`
+}
+
+// ArtistPublicProfile is the part of an artist anyone may see; earnings and the owning
+// user stay private
+type ArtistPublicProfile struct {
+	ID              uint64  `json:"id"`
+	ArtistName      string  `json:"artist_name"`
+	Bio             *string `json:"bio"`
+	ProfileImageURL *string `json:"profile_image_url"`
+	BannerImageURL  *string `json:"banner_image_url"`
+	WebsiteURL      *string `json:"website_url"`
+	SpotifyURL      *string `json:"spotify_url"`
+	InstagramURL    *string `json:"instagram_url"`
+	TwitterURL      *string `json:"twitter_url"`
+	YoutubeURL      *string `json:"youtube_url"`
+	IsVerified      bool    `json:"is_verified"`
+	FollowerCount   int     `json:"follower_count"`
+	TotalPlays      int64   `json:"total_plays"`
+}
+
+// SongSummary is a song as listed on an artist page
+type SongSummary struct {
+	ID              uint64      `json:"id"`
+	Title           string      `json:"title"`
+	ArtworkURL      string      `json:"artwork_url"`
+	DurationSeconds *int        `json:"duration_seconds"`
+	GenreID         *uint64     `json:"genre_id"`
+	MoodID          *uint64     `json:"mood_id"`
+	Tier            ContentTier `json:"tier"`
+	IsExplicit      bool        `json:"is_explicit"`
+	PlayCount       int64       `json:"play_count"`
+	LikeCount       int         `json:"like_count"`
+	ReleaseDate     *string     `json:"release_date"`
+	CreatedAt       time.Time   `json:"created_at"`
+}
+
+// ArtistPageViewer describes the signed-in viewer's relation to the artist
+type ArtistPageViewer struct {
+	IsFollowing bool `json:"is_following"`
+	IsOwner     bool `json:"is_owner"`
+}
+
+type ArtistPage struct {
+	Artist         ArtistPublicProfile `json:"artist"`
+	TopSongs       []SongSummary       `json:"top_songs"`
+	RecentReleases []SongSummary       `json:"recent_releases"`
+	Viewer         *ArtistPageViewer   `json:"viewer"` // nil for anonymous viewers
+}
+
+func NewArtistPublicProfile(artist *Artist) ArtistPublicProfile {
+	return ArtistPublicProfile{
+		ID:              artist.ID,
+		ArtistName:      artist.ArtistName,
+		Bio:             artist.Bio,
+		ProfileImageURL: artist.ProfileImageURL,
+		BannerImageURL:  artist.BannerImageURL,
+		WebsiteURL:      artist.WebsiteURL,
+		SpotifyURL:      artist.SpotifyURL,
+		InstagramURL:    artist.InstagramURL,
+		TwitterURL:      artist.TwitterURL,
+		YoutubeURL:      artist.YoutubeURL,
+		IsVerified:      artist.IsVerified,
+		FollowerCount:   artist.FollowerCount,
+		TotalPlays:      artist.TotalPlays,
+	}
+}
@@ -0,0 +1,23 @@
+package model
+
+// ArtistSearchResult is an artist as listed in search results
+type ArtistSearchResult struct {
+	ID              uint64  `json:"id"`
+	ArtistName      string  `json:"artist_name"`
+	ProfileImageURL *string `json:"profile_image_url"`
+	IsVerified      bool    `json:"is_verified"`
+	FollowerCount   int     `json:"follower_count"`
+}
+
+// SongSearchResult is a released song with the artist it belongs to
+type SongSearchResult struct {
+	SongSummary
+	ArtistID         uint64 `json:"artist_id"`
+	ArtistName       string `json:"artist_name"`
+	ArtistIsVerified bool   `json:"artist_is_verified"`
+}
+
+type SearchResult struct {
+	Artists []ArtistSearchResult `json:"artists"`
+	Songs   []SongSearchResult   `json:"songs"`
+}
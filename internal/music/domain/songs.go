@@ -2,6 +2,7 @@ package model
 
 import (
 	"music-app-backend/pkg/model"
+	"time"
 )
 
 type ContentTier string
@@ -48,4 +49,6 @@ type Song struct {
 	TotalTips            float64          `json:"total_tips" gorm:"type:decimal(10,2);default:0.00"`
 	ReleaseDate          *string          `json:"release_date" gorm:"type:date"`
 	IsActive             bool             `json:"is_active" gorm:"default:true"`
+	TakenDownAt          *time.Time       `json:"taken_down_at"`
+	TakedownReason       *string          `json:"takedown_reason"`
 }
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"music-app-backend/pkg/model"
+	"music-app-backend/pkg/pagination"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type VerificationStatus string
+
+const (
+	VerificationStatusPending  VerificationStatus = "pending"
+	VerificationStatusApproved VerificationStatus = "approved"
+	VerificationStatusRejected VerificationStatus = "rejected"
+)
+
+// ArtistVerificationRequest is one submission by an artist. Requests are never deleted, so
+// the table is the artist's verification history.
+type ArtistVerificationRequest struct {
+	model.BaseModel
+	ArtistID        uint64             `json:"artist_id" gorm:"not null;index"`
+	Status          VerificationStatus `json:"status" gorm:"not null;size:20;default:'pending'"`
+	EvidenceLinks   pq.StringArray     `json:"evidence_links" gorm:"type:text[];not null"`
+	Message         *string            `json:"message"`
+	ReviewerID      *uint64            `json:"reviewer_id"`
+	RejectionReason *string            `json:"rejection_reason"`
+	ReviewedAt      *time.Time         `json:"reviewed_at"`
+}
+
+// SubmitVerificationDTO is an artist's request to be verified. Evidence links point at
+// press, label or platform pages that show the artist is who they claim to be.
+type SubmitVerificationDTO struct {
+	EvidenceLinks []string `json:"evidence_links" binding:"required"`
+	Message       *string  `json:"message"`
+}
+
+// ReviewVerificationDTO is an admin's decision; a rejection needs a reason the artist can act on
+type ReviewVerificationDTO struct {
+	Approve bool   `json:"approve"`
+	Reason  string `json:"reason"`
+}
+
+// VerificationQueueItem is a request as listed for reviewers
+type VerificationQueueItem struct {
+	ArtistVerificationRequest
+	ArtistName    string `json:"artist_name"`
+	FollowerCount int    `json:"follower_count"`
+	TotalPlays    int64  `json:"total_plays"`
+}
+
+type VerificationHistory struct {
+	IsVerified bool                        `json:"is_verified"`
+	Requests   []ArtistVerificationRequest `json:"requests"`
+}
+
+type VerificationQueueResult struct {
+	Requests   []VerificationQueueItem `json:"requests"`
+	Pagination pagination.Meta         `json:"pagination"`
+}
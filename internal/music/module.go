@@ -5,6 +5,7 @@ import (
 	"music-app-backend/internal/music/adapters/repository"
 	"music-app-backend/internal/music/application"
 	ctx2 "music-app-backend/pkg/context"
+	"music-app-backend/pkg/middleware"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -14,17 +15,21 @@ type MusicModule struct {
 	Repository repository.IMusicRepository
 	Service    *application.MusicService
 	Handler    *http.MusicHandler
+
+	authMiddleware *middleware.AuthMiddleware
 }
 
-func NewMusicModule(db *gorm.DB, serviceContext *ctx2.ServiceContext) *MusicModule {
+func NewMusicModule(db *gorm.DB, serviceContext *ctx2.ServiceContext, authMiddleware *middleware.AuthMiddleware) *MusicModule {
 	musicRepo := repository.NewMusicRepository(db)
-	musicService := application.NewMusicService(musicRepo, serviceContext.GetIDGenerator())
+	musicService := application.NewMusicService(musicRepo, serviceContext.GetIDGenerator(), serviceContext.GetAuditLog())
 	uploadHandler := http.NewMusicHandler(musicService, serviceContext.GetStorageService(), serviceContext.GetRedisClient(), serviceContext.GetIDGenerator())
 
 	return &MusicModule{
 		Repository: musicRepo,
 		Service:    musicService,
 		Handler:    uploadHandler,
+
+		authMiddleware: authMiddleware,
 	}
 }
 
@@ -37,8 +42,23 @@ func (s *MusicModule) RegisterRoutes(router *gin.RouterGroup) {
 	}
 	router.GET("/processing/status/{song_id}", s.Handler.GetProcessingStatus)
 	router.POST("/processing/callback/{song_id}", s.Handler.ProcessingCallback)
+	// Streaming needs the listener's tier and content preferences
 	streamRouter := router.Group("/stream")
+	streamRouter.Use(s.authMiddleware.RequireAuth())
+	{
+		streamRouter.GET("/:song_id", s.Handler.GetStreamingURL)
+	}
+
+	// Artist pages are public; a session only adds the viewer's follow status
+	router.GET("/artists/:artist_id", s.authMiddleware.OptionalAuth(), s.Handler.GetArtistPage)
+	router.GET("/search", s.authMiddleware.OptionalAuth(), s.Handler.Search)
+
+	artistRouter := router.Group("/me/artist")
+	artistRouter.Use(s.authMiddleware.RequireAuth(), s.authMiddleware.RequireArtist())
 	{
-		streamRouter.GET("/{song_id}", s.Handler.GetStreamingURL)
+		artistRouter.GET("", s.Handler.GetMyArtistProfile)
+		artistRouter.PATCH("", s.Handler.UpdateMyArtistProfile)
+		artistRouter.GET("/verification", s.Handler.GetVerificationHistory)
+		artistRouter.POST("/verification", s.Handler.SubmitVerificationRequest)
 	}
 }
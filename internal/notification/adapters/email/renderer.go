@@ -0,0 +1,66 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"music-app-backend/pkg/mail"
+	"strings"
+	texttemplate "text/template"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// TemplateData is passed to every template. Data holds the fields specific to one
+// template, e.g. song_title for processing emails.
+type TemplateData struct {
+	RecipientName  string
+	AppURL         string
+	UnsubscribeURL string
+	Title          string
+	Body           string
+	Data           map[string]string
+}
+
+// Renderer turns a template name into a message. Each template file defines
+// "<name>.subject", "<name>.text" and "<name>.html"; the HTML part is escaped by
+// html/template, the subject and text parts are not.
+type Renderer struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+func NewRenderer() (*Renderer, error) {
+	text, err := texttemplate.New("email").Option("missingkey=zero").ParseFS(templateFS, "templates/*.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse text email templates: %w", err)
+	}
+	html, err := htmltemplate.New("email").Option("missingkey=zero").ParseFS(templateFS, "templates/*.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse html email templates: %w", err)
+	}
+	return &Renderer{text: text, html: html}, nil
+}
+
+// Render fills in subject and both bodies; the caller addresses the message
+func (r *Renderer) Render(name string, data *TemplateData) (*mail.Message, error) {
+	var subject, text, html bytes.Buffer
+	if err := r.text.ExecuteTemplate(&subject, name+".subject", data); err != nil {
+		return nil, fmt.Errorf("failed to render subject of %s: %w", name, err)
+	}
+	if err := r.text.ExecuteTemplate(&text, name+".text", data); err != nil {
+		return nil, fmt.Errorf("failed to render text body of %s: %w", name, err)
+	}
+	if err := r.html.ExecuteTemplate(&html, name+".html", data); err != nil {
+		return nil, fmt.Errorf("failed to render html body of %s: %w", name, err)
+	}
+
+	return &mail.Message{
+		// A subject must stay on one header line
+		Subject:  strings.Join(strings.Fields(subject.String()), " "),
+		TextBody: strings.TrimSpace(text.String()) + "\n",
+		HTMLBody: html.String(),
+	}, nil
+}
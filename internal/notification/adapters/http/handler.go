@@ -0,0 +1,110 @@
+package http
+
+import (
+	"music-app-backend/internal/notification/application"
+	appError "music-app-backend/pkg/error"
+	jsonResponse "music-app-backend/pkg/json"
+	"music-app-backend/pkg/pagination"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	notificationService *application.NotificationService
+}
+
+func NewNotificationHandler(notificationService *application.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+	}
+}
+
+func (h *NotificationHandler) HandleError(c *gin.Context, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if appErr, ok := appError.GetAppError(err); ok {
+		jsonResponse.ResponseJSON(c, appErr.StatusCode, appErr.Message, appErr.Data)
+		return true
+	}
+
+	jsonResponse.ResponseInternalError(c, err)
+	return true
+}
+
+// ListNotifications returns the current user's inbox; pass unread=true for unread only
+func (h *NotificationHandler) ListNotifications(c *gin.Context) {
+	page, err := pagination.CursorFromQuery(c)
+	if err != nil {
+		jsonResponse.ResponseBadRequest(c, err.Error())
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	unreadOnly, _ := strconv.ParseBool(c.DefaultQuery("unread", "false"))
+	result, err := h.notificationService.ListNotifications(c.Request.Context(), userID.(uint64), unreadOnly, page)
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseOK(c, result)
+}
+
+// GetUnreadCount returns the badge count for the current user
+func (h *NotificationHandler) GetUnreadCount(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	count, err := h.notificationService.CountUnread(c.Request.Context(), userID.(uint64))
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseOK(c, gin.H{"unread_count": count})
+}
+
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	notificationID, err := strconv.ParseUint(c.Param("notification_id"), 10, 64)
+	if err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid notification ID")
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	notification, err := h.notificationService.MarkRead(c.Request.Context(), userID.(uint64), notificationID)
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseOK(c, notification)
+}
+
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	count, err := h.notificationService.MarkAllRead(c.Request.Context(), userID.(uint64))
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseOK(c, gin.H{"marked_read": count})
+}
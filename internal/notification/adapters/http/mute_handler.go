@@ -0,0 +1,86 @@
+package http
+
+import (
+	model "music-app-backend/internal/notification/domain"
+	jsonResponse "music-app-backend/pkg/json"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MuteArtistRequest struct {
+	// Hours to mute for; omit to mute until unmuted
+	DurationHours int `json:"duration_hours" binding:"omitempty,gt=0,lte=8760"`
+}
+
+// MuteArtist silences an artist's release and message notifications
+func (h *NotificationHandler) MuteArtist(c *gin.Context) {
+	artistID, err := strconv.ParseUint(c.Param("artist_id"), 10, 64)
+	if err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid artist ID")
+		return
+	}
+
+	request := &MuteArtistRequest{}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(request); err != nil {
+			jsonResponse.ResponseBadRequest(c, "Invalid request: "+err.Error())
+			return
+		}
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	mute, err := h.notificationService.MuteArtist(c.Request.Context(), &model.MuteArtistDTO{
+		UserID:   userID.(uint64),
+		ArtistID: artistID,
+		Duration: time.Duration(request.DurationHours) * time.Hour,
+	})
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseOK(c, mute)
+}
+
+func (h *NotificationHandler) UnmuteArtist(c *gin.Context) {
+	artistID, err := strconv.ParseUint(c.Param("artist_id"), 10, 64)
+	if err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid artist ID")
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	err = h.notificationService.UnmuteArtist(c.Request.Context(), userID.(uint64), artistID)
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseOK(c, nil)
+}
+
+// ListMutes returns the artists the current user has muted
+func (h *NotificationHandler) ListMutes(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	mutes, err := h.notificationService.ListMutes(c.Request.Context(), userID.(uint64))
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseOK(c, mutes)
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	model "music-app-backend/internal/notification/domain"
+)
+
+// GetEmailRecipient returns the user's address, or nil when the account is inactive or
+// switched notification emails off. Users without a preferences row get them.
+func (r *NotificationRepository) GetEmailRecipient(ctx context.Context, userID uint64) (*model.EmailRecipient, error) {
+	var recipients []model.EmailRecipient
+	err := r.db.WithContext(ctx).Table("users AS u").
+		Select("u.id AS user_id, u.email, COALESCE(u.display_name, '') AS display_name").
+		Joins("LEFT JOIN user_preferences AS p ON p.user_id = u.id").
+		Where("u.id = ? AND u.is_active = true AND u.email <> ''", userID).
+		Where("COALESCE(p.email_notifications, true) = true").
+		Limit(1).
+		Scan(&recipients).Error
+	if err != nil || len(recipients) == 0 {
+		return nil, err
+	}
+	return &recipients[0], nil
+}
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	model "music-app-backend/internal/notification/domain"
+	"music-app-backend/pkg/pagination"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) *NotificationRepository {
+	return &NotificationRepository{
+		db: db,
+	}
+}
+
+// CreateNotifications inserts the batch and returns the rows that were new; rows whose
+// (user_id, dedup_key) already exists are skipped
+func (r *NotificationRepository) CreateNotifications(ctx context.Context, notifications []model.Notification) ([]model.Notification, error) {
+	if len(notifications) == 0 {
+		return nil, nil
+	}
+
+	placeholders := make([]string, 0, len(notifications))
+	args := make([]interface{}, 0, len(notifications)*11)
+	for _, n := range notifications {
+		placeholders = append(placeholders, "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+		args = append(args, n.ID, n.UserID, n.Type, n.Title, n.Body, n.ArtistID, n.SongID, n.ReferenceID, n.DedupKey, n.CreatedAt, n.UpdatedAt)
+	}
+
+	var inserted []uint64
+	err := r.db.WithContext(ctx).Raw(`
+		INSERT INTO notifications (id, user_id, type, title, body, artist_id, song_id, reference_id, dedup_key, created_at, updated_at)
+		VALUES `+strings.Join(placeholders, ", ")+`
+		ON CONFLICT (user_id, dedup_key) DO NOTHING
+		RETURNING id`, args...).Scan(&inserted).Error
+	if err != nil {
+		return nil, err
+	}
+
+	created := make(map[uint64]bool, len(inserted))
+	for _, id := range inserted {
+		created[id] = true
+	}
+	result := make([]model.Notification, 0, len(inserted))
+	for _, n := range notifications {
+		if created[n.ID] {
+			result = append(result, n)
+		}
+	}
+	return result, nil
+}
+
+// ListOptedOutUsers returns which of userIDs switched off the given preference. Users
+// without a preferences row keep the defaults, which are all on.
+func (r *NotificationRepository) ListOptedOutUsers(ctx context.Context, preferenceColumn string, userIDs []uint64) ([]uint64, error) {
+	var optedOut []uint64
+	err := r.db.WithContext(ctx).Table("user_preferences").
+		Where("user_id IN ?", userIDs).
+		Where(clause.Eq{Column: clause.Column{Name: preferenceColumn}, Value: false}).
+		Pluck("user_id", &optedOut).Error
+	return optedOut, err
+}
+
+// ListMutedUsers returns which of userIDs currently mute the artist
+func (r *NotificationRepository) ListMutedUsers(ctx context.Context, artistID uint64, userIDs []uint64, now time.Time) ([]uint64, error) {
+	var muted []uint64
+	err := r.db.WithContext(ctx).Model(&model.NotificationMute{}).
+		Where("artist_id = ? AND user_id IN ?", artistID, userIDs).
+		Where("muted_until IS NULL OR muted_until > ?", now).
+		Pluck("user_id", &muted).Error
+	return muted, err
+}
+
+// ListNotifications returns up to page.Limit+1 rows so the caller can tell whether more follow
+func (r *NotificationRepository) ListNotifications(ctx context.Context, userID uint64, unreadOnly bool, page pagination.CursorParams) ([]model.Notification, error) {
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	if page.After != nil {
+		query = query.Where("(created_at, id) < (?, ?)", page.After.Time, page.After.ID)
+	}
+
+	var notifications []model.Notification
+	err := query.Order("created_at DESC, id DESC").Limit(page.Limit + 1).Find(&notifications).Error
+	return notifications, err
+}
+
+func (r *NotificationRepository) CountUnread(ctx context.Context, userID uint64) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&model.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *NotificationRepository) GetNotification(ctx context.Context, userID, notificationID uint64) (*model.Notification, error) {
+	var notification model.Notification
+	err := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", notificationID, userID).First(&notification).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &notification, nil
+}
+
+func (r *NotificationRepository) MarkRead(ctx context.Context, userID, notificationID uint64, readAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&model.Notification{}).
+		Where("id = ? AND user_id = ? AND read_at IS NULL", notificationID, userID).
+		Updates(map[string]interface{}{
+			"read_at":    readAt,
+			"updated_at": readAt,
+		}).Error
+}
+
+// MarkAllRead marks every unread notification created up to readAt and returns how many changed
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userID uint64, readAt time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&model.Notification{}).
+		Where("user_id = ? AND read_at IS NULL AND created_at <= ?", userID, readAt).
+		Updates(map[string]interface{}{
+			"read_at":    readAt,
+			"updated_at": readAt,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// UpsertMute creates the mute or replaces its expiry
+func (r *NotificationRepository) UpsertMute(ctx context.Context, mute *model.NotificationMute) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "artist_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"muted_until", "updated_at"}),
+		}).
+		Create(mute).Error
+}
+
+func (r *NotificationRepository) DeleteMute(ctx context.Context, userID, artistID uint64) error {
+	return r.db.WithContext(ctx).
+		Where("user_id = ? AND artist_id = ?", userID, artistID).
+		Delete(&model.NotificationMute{}).Error
+}
+
+// ListActiveMutes returns the user's mutes that have not expired
+func (r *NotificationRepository) ListActiveMutes(ctx context.Context, userID uint64, now time.Time) ([]model.NotificationMute, error) {
+	var mutes []model.NotificationMute
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Where("muted_until IS NULL OR muted_until > ?", now).
+		Order("created_at DESC").
+		Find(&mutes).Error
+	return mutes, err
+}
+
+func (r *NotificationRepository) GetMute(ctx context.Context, userID, artistID uint64) (*model.NotificationMute, error) {
+	var mute model.NotificationMute
+	err := r.db.WithContext(ctx).Where("user_id = ? AND artist_id = ?", userID, artistID).First(&mute).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &mute, nil
+}
@@ -0,0 +1,161 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"music-app-backend/internal/notification/adapters/email"
+	model "music-app-backend/internal/notification/domain"
+	"music-app-backend/pkg/mail"
+	"music-app-backend/pkg/redis"
+	"net/url"
+	"time"
+)
+
+const (
+	EmailQueue       = "queue:notification_emails"
+	emailMaxAttempts = 5
+)
+
+// EmailChannel holds what the service needs to send the email copy of notifications.
+// UnsubscribeURL is the public API endpoint that applies a token.
+type EmailChannel struct {
+	RedisClient    *redis.Client
+	Sender         mail.Sender
+	Renderer       *email.Renderer
+	Tokens         *mail.UnsubscribeTokens
+	AppURL         string
+	UnsubscribeURL string
+}
+
+// enqueueEmails queues the email copy for the users who just got the notification.
+// Whether each of them wants notification emails is checked when the job runs.
+func (s *NotificationService) enqueueEmails(ctx context.Context, notification *model.NotifyDTO, created []model.Notification) {
+	template := notification.Type.EmailTemplate()
+	if s.email == nil || template == "" {
+		return
+	}
+
+	for i := range created {
+		job := model.EmailJob{
+			UserID:   created[i].UserID,
+			Template: template,
+			Title:    notification.Title,
+			Body:     notification.Body,
+			Data:     notification.EmailData,
+		}
+		if err := s.email.RedisClient.Enqueue(ctx, EmailQueue, job); err != nil {
+			// The in-app notification is the primary channel; a lost email is not retried
+			log.Printf("Failed to enqueue email for notification %d: %v", created[i].ID, err)
+		}
+	}
+}
+
+// SendEmail renders and sends one job. It returns nil without sending when the user
+// cannot receive notification emails.
+func (s *NotificationService) SendEmail(ctx context.Context, job *model.EmailJob) error {
+	recipient, err := s.repository.GetEmailRecipient(ctx, job.UserID)
+	if err != nil {
+		return err
+	}
+	if recipient == nil {
+		return nil
+	}
+
+	token := s.email.Tokens.Generate(recipient.UserID, mail.ScopeNotifications)
+	name := recipient.DisplayName
+	if name == "" {
+		name = "there"
+	}
+
+	message, err := s.email.Renderer.Render(job.Template, &email.TemplateData{
+		RecipientName:  name,
+		AppURL:         s.email.AppURL,
+		UnsubscribeURL: s.email.AppURL + "/email/unsubscribe?token=" + url.QueryEscape(token),
+		Title:          job.Title,
+		Body:           job.Body,
+		Data:           job.Data,
+	})
+	if err != nil {
+		return err
+	}
+
+	message.To = recipient.Email
+	message.ToName = recipient.DisplayName
+	// One-click unsubscribe from the mail client (RFC 8058) goes straight to the API
+	message.Headers = map[string]string{
+		"List-Unsubscribe":      fmt.Sprintf("<%s?token=%s>", s.email.UnsubscribeURL, url.QueryEscape(token)),
+		"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+	}
+	return s.email.Sender.Send(ctx, message)
+}
+
+// StartEmailWorkers runs the email consumers and moves retries back onto the queue once
+// their delay has passed
+func (s *NotificationService) StartEmailWorkers(ctx context.Context, workers int) {
+	if s.email == nil {
+		return
+	}
+	for i := 0; i < workers; i++ {
+		go s.consumeEmails(ctx)
+	}
+
+	ticker := time.NewTicker(10 * time.Second)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.email.RedisClient.ProcessDelayedJobs(ctx, EmailQueue); err != nil {
+					log.Printf("Failed to requeue delayed emails: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+func (s *NotificationService) consumeEmails(ctx context.Context) {
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		payload, err := s.email.RedisClient.Dequeue(ctx, EmailQueue, 5*time.Second)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("Failed to read email queue: %v", err)
+				time.Sleep(time.Second)
+			}
+			continue
+		}
+		if payload == nil {
+			continue
+		}
+
+		var job model.EmailJob
+		if err := json.Unmarshal(payload, &job); err != nil {
+			log.Printf("Dropping malformed email job: %v", err)
+			continue
+		}
+		if err := s.SendEmail(ctx, &job); err != nil {
+			s.retryEmail(ctx, &job, err)
+		}
+	}
+}
+
+// retryEmail backs off one minute per failed attempt, e.g. while the SMTP server is down
+func (s *NotificationService) retryEmail(ctx context.Context, job *model.EmailJob, sendErr error) {
+	job.Attempt++
+	if job.Attempt >= emailMaxAttempts {
+		log.Printf("Giving up on %s email to user %d after %d attempts: %v", job.Template, job.UserID, job.Attempt, sendErr)
+		return
+	}
+
+	log.Printf("Failed to send %s email to user %d, retrying: %v", job.Template, job.UserID, sendErr)
+	if err := s.email.RedisClient.EnqueueWithDelay(ctx, EmailQueue, job, time.Duration(job.Attempt)*time.Minute); err != nil {
+		log.Printf("Failed to schedule retry of %s email to user %d: %v", job.Template, job.UserID, err)
+	}
+}
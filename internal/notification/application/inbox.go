@@ -0,0 +1,78 @@
+package application
+
+import (
+	"context"
+	model "music-app-backend/internal/notification/domain"
+	appError "music-app-backend/pkg/error"
+	"music-app-backend/pkg/pagination"
+	"time"
+)
+
+// ListNotifications returns the user's inbox, newest first
+func (s *NotificationService) ListNotifications(ctx context.Context, userID uint64, unreadOnly bool, page pagination.CursorParams) (*model.NotificationListResult, error) {
+	notifications, err := s.repository.ListNotifications(ctx, userID, unreadOnly, page)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to list notifications")
+	}
+
+	fetched := len(notifications)
+	if fetched > page.Limit {
+		notifications = notifications[:page.Limit]
+	}
+	var last pagination.Cursor
+	if len(notifications) > 0 {
+		last = pagination.Cursor{Time: notifications[len(notifications)-1].CreatedAt, ID: notifications[len(notifications)-1].ID}
+	}
+	if notifications == nil {
+		notifications = []model.Notification{}
+	}
+
+	unread, err := s.repository.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to count unread notifications")
+	}
+
+	return &model.NotificationListResult{
+		Notifications: notifications,
+		UnreadCount:   unread,
+		Pagination:    page.Meta(fetched, last),
+	}, nil
+}
+
+func (s *NotificationService) CountUnread(ctx context.Context, userID uint64) (int64, error) {
+	count, err := s.repository.CountUnread(ctx, userID)
+	if err != nil {
+		return 0, appError.NewInternalError(err, "failed to count unread notifications")
+	}
+	return count, nil
+}
+
+// MarkRead marks one notification as read; marking it again keeps the first read time
+func (s *NotificationService) MarkRead(ctx context.Context, userID, notificationID uint64) (*model.Notification, error) {
+	notification, err := s.repository.GetNotification(ctx, userID, notificationID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load notification")
+	}
+	if notification == nil {
+		return nil, appError.NewNotFoundError(nil, "notification not found")
+	}
+	if notification.ReadAt != nil {
+		return notification, nil
+	}
+
+	readAt := time.Now().UTC()
+	if err := s.repository.MarkRead(ctx, userID, notificationID, readAt); err != nil {
+		return nil, appError.NewInternalError(err, "failed to mark notification as read")
+	}
+	notification.ReadAt = &readAt
+	return notification, nil
+}
+
+// MarkAllRead marks everything currently in the inbox as read and returns how many changed
+func (s *NotificationService) MarkAllRead(ctx context.Context, userID uint64) (int64, error) {
+	count, err := s.repository.MarkAllRead(ctx, userID, time.Now().UTC())
+	if err != nil {
+		return 0, appError.NewInternalError(err, "failed to mark notifications as read")
+	}
+	return count, nil
+}
@@ -0,0 +1,65 @@
+package application
+
+import (
+	"context"
+	model "music-app-backend/internal/notification/domain"
+	appError "music-app-backend/pkg/error"
+	baseModel "music-app-backend/pkg/model"
+	"time"
+)
+
+// MuteArtist silences the artist's releases and messages for the user. Muting again
+// replaces the previous expiry.
+func (s *NotificationService) MuteArtist(ctx context.Context, request *model.MuteArtistDTO) (*model.NotificationMute, error) {
+	artist, err := s.musicService.GetArtistByID(ctx, request.ArtistID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load artist")
+	}
+	if artist == nil {
+		return nil, appError.NewNotFoundError(nil, "artist not found")
+	}
+
+	_base, err := baseModel.NewBaseModel(s.generator)
+	if err != nil {
+		return nil, err
+	}
+
+	mute := &model.NotificationMute{
+		BaseModel: *_base,
+		UserID:    request.UserID,
+		ArtistID:  artist.ID,
+	}
+	if request.Duration > 0 {
+		mutedUntil := _base.CreatedAt.Add(request.Duration)
+		mute.MutedUntil = &mutedUntil
+	}
+
+	if err := s.repository.UpsertMute(ctx, mute); err != nil {
+		return nil, appError.NewInternalError(err, "failed to mute artist")
+	}
+
+	// On conflict the existing row was updated; return it with its own ID
+	stored, err := s.repository.GetMute(ctx, request.UserID, artist.ID)
+	if err != nil || stored == nil {
+		return mute, nil
+	}
+	return stored, nil
+}
+
+func (s *NotificationService) UnmuteArtist(ctx context.Context, userID, artistID uint64) error {
+	if err := s.repository.DeleteMute(ctx, userID, artistID); err != nil {
+		return appError.NewInternalError(err, "failed to unmute artist")
+	}
+	return nil
+}
+
+func (s *NotificationService) ListMutes(ctx context.Context, userID uint64) ([]model.NotificationMute, error) {
+	mutes, err := s.repository.ListActiveMutes(ctx, userID, time.Now().UTC())
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to list mutes")
+	}
+	if mutes == nil {
+		mutes = []model.NotificationMute{}
+	}
+	return mutes, nil
+}
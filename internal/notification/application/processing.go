@@ -0,0 +1,45 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log"
+	musicModel "music-app-backend/internal/music/domain"
+	model "music-app-backend/internal/notification/domain"
+	"strconv"
+)
+
+// SongProcessed tells the uploading artist that processing finished, once per song and
+// outcome. It implements the music module's ProcessingListener.
+func (s *NotificationService) SongProcessed(ctx context.Context, song *musicModel.Song, success bool) {
+	artist, err := s.musicService.GetArtistByID(ctx, song.ArtistID)
+	if err != nil || artist == nil {
+		log.Printf("Failed to load artist %d for processing notification: %v", song.ArtistID, err)
+		return
+	}
+
+	notification := &model.NotifyDTO{
+		Type:        model.NotificationTypeProcessingCompleted,
+		Title:       fmt.Sprintf("%q is ready", song.Title),
+		Body:        "Your upload finished processing and can now be streamed.",
+		ArtistID:    &artist.ID,
+		SongID:      &song.ID,
+		ReferenceID: &song.ID,
+		EmailData: map[string]string{
+			"song_id":    strconv.FormatUint(song.ID, 10),
+			"song_title": song.Title,
+		},
+	}
+	if !success {
+		notification.Type = model.NotificationTypeProcessingFailed
+		notification.Title = fmt.Sprintf("Processing failed for %q", song.Title)
+		notification.Body = song.ProcessingError
+		notification.EmailData["error"] = song.ProcessingError
+	}
+	// A redelivered processing callback must not notify the artist twice
+	notification.DedupKey = string(notification.Type) + ":" + strconv.FormatUint(song.ID, 10)
+
+	if _, err := s.Notify(ctx, notification, artist.UserID); err != nil {
+		log.Printf("Failed to notify artist %d about song %d: %v", artist.ID, song.ID, err)
+	}
+}
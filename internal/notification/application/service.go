@@ -0,0 +1,156 @@
+package application
+
+import (
+	"context"
+	"log"
+	analyticsModuleSvc "music-app-backend/internal/analytics/application"
+	musicModuleSvc "music-app-backend/internal/music/application"
+	"music-app-backend/internal/notification/adapters/repository"
+	model "music-app-backend/internal/notification/domain"
+	realtimeModuleSvc "music-app-backend/internal/realtime/application"
+	realtimeModel "music-app-backend/internal/realtime/domain"
+	baseModel "music-app-backend/pkg/model"
+	"time"
+
+	goflakeid "github.com/capy-engineer/go-flakeid"
+)
+
+// notifyBatchSize bounds the IN lists and the multi-row insert of one round
+const notifyBatchSize = 500
+
+// INotificationService is used by other modules to put notifications in users' inboxes
+type INotificationService interface {
+	Notify(ctx context.Context, notification *model.NotifyDTO, userIDs ...uint64) (int, error)
+}
+
+type NotificationService struct {
+	repository       *repository.NotificationRepository
+	generator        *goflakeid.Generator
+	musicService     musicModuleSvc.IMusicService
+	analyticsService analyticsModuleSvc.IAnalyticsService
+	realtimeService  realtimeModuleSvc.IRealtimeService
+	email            *EmailChannel // nil disables emails
+}
+
+func NewNotificationService(
+	repository *repository.NotificationRepository,
+	generator *goflakeid.Generator,
+	musicService musicModuleSvc.IMusicService,
+	analyticsService analyticsModuleSvc.IAnalyticsService,
+	realtimeService realtimeModuleSvc.IRealtimeService,
+	email *EmailChannel,
+) *NotificationService {
+	return &NotificationService{
+		repository:       repository,
+		generator:        generator,
+		musicService:     musicService,
+		analyticsService: analyticsService,
+		realtimeService:  realtimeService,
+		email:            email,
+	}
+}
+
+// Notify stores the notification for every recipient whose preferences and mutes allow
+// it, pushes it to their live stream, queues the email copy for types that have one, and
+// returns how many were created. With a DedupKey a recipient who already got the
+// notification is skipped, so producers may retry.
+func (s *NotificationService) Notify(ctx context.Context, notification *model.NotifyDTO, userIDs ...uint64) (int, error) {
+	created := 0
+	for start := 0; start < len(userIDs); start += notifyBatchSize {
+		end := start + notifyBatchSize
+		if end > len(userIDs) {
+			end = len(userIDs)
+		}
+
+		count, err := s.notifyBatch(ctx, notification, userIDs[start:end])
+		created += count
+		if err != nil {
+			return created, err
+		}
+	}
+	return created, nil
+}
+
+func (s *NotificationService) notifyBatch(ctx context.Context, notification *model.NotifyDTO, userIDs []uint64) (int, error) {
+	recipients, err := s.routeRecipients(ctx, notification, userIDs)
+	if err != nil {
+		return 0, err
+	}
+	if len(recipients) == 0 {
+		return 0, nil
+	}
+
+	var dedupKey *string
+	if notification.DedupKey != "" {
+		dedupKey = &notification.DedupKey
+	}
+
+	rows := make([]model.Notification, 0, len(recipients))
+	for _, userID := range recipients {
+		_base, err := baseModel.NewBaseModel(s.generator)
+		if err != nil {
+			return 0, err
+		}
+		rows = append(rows, model.Notification{
+			BaseModel:   *_base,
+			UserID:      userID,
+			Type:        notification.Type,
+			Title:       notification.Title,
+			Body:        notification.Body,
+			ArtistID:    notification.ArtistID,
+			SongID:      notification.SongID,
+			ReferenceID: notification.ReferenceID,
+			DedupKey:    dedupKey,
+		})
+	}
+
+	created, err := s.repository.CreateNotifications(ctx, rows)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range created {
+		if err := s.realtimeService.PublishUserEvent(ctx, created[i].UserID, realtimeModel.EventNotificationCreated, &created[i]); err != nil {
+			// The notification is in the inbox; the client sees it on its next fetch
+			log.Printf("Failed to push notification %d: %v", created[i].ID, err)
+		}
+	}
+	s.enqueueEmails(ctx, notification, created)
+	return len(created), nil
+}
+
+// routeRecipients drops users who switched the notification type off or muted the artist
+func (s *NotificationService) routeRecipients(ctx context.Context, notification *model.NotifyDTO, userIDs []uint64) ([]uint64, error) {
+	excluded := map[uint64]bool{}
+
+	if column := notification.Type.PreferenceColumn(); column != "" {
+		optedOut, err := s.repository.ListOptedOutUsers(ctx, column, userIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, userID := range optedOut {
+			excluded[userID] = true
+		}
+	}
+
+	if notification.Type.Mutable() && notification.ArtistID != nil {
+		muted, err := s.repository.ListMutedUsers(ctx, *notification.ArtistID, userIDs, time.Now().UTC())
+		if err != nil {
+			return nil, err
+		}
+		for _, userID := range muted {
+			excluded[userID] = true
+		}
+	}
+
+	recipients := make([]uint64, 0, len(userIDs))
+	seen := make(map[uint64]bool, len(userIDs))
+	for _, userID := range userIDs {
+		if excluded[userID] || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		recipients = append(recipients, userID)
+	}
+	return recipients, nil
+}
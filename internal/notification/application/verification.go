@@ -0,0 +1,39 @@
+package application
+
+import (
+	"context"
+	"log"
+	musicModel "music-app-backend/internal/music/domain"
+	model "music-app-backend/internal/notification/domain"
+	"strconv"
+)
+
+// ArtistVerificationReviewed tells the artist how their verification request was decided.
+// It implements the music module's VerificationListener.
+func (s *NotificationService) ArtistVerificationReviewed(ctx context.Context, artist *musicModel.Artist, request *musicModel.ArtistVerificationRequest) {
+	notification := &model.NotifyDTO{
+		Type:        model.NotificationTypeVerificationApproved,
+		Title:       "Your artist profile is verified",
+		Body:        artist.ArtistName + " now shows the verified badge.",
+		ArtistID:    &artist.ID,
+		ReferenceID: &request.ID,
+		DedupKey:    "verification:" + strconv.FormatUint(request.ID, 10),
+		EmailData: map[string]string{
+			"artist_id":   strconv.FormatUint(artist.ID, 10),
+			"artist_name": artist.ArtistName,
+		},
+	}
+	if request.Status == musicModel.VerificationStatusRejected {
+		notification.Type = model.NotificationTypeVerificationRejected
+		notification.Title = "Your verification request was not approved"
+		notification.Body = ""
+		if request.RejectionReason != nil {
+			notification.Body = *request.RejectionReason
+			notification.EmailData["reason"] = *request.RejectionReason
+		}
+	}
+
+	if _, err := s.Notify(ctx, notification, artist.UserID); err != nil {
+		log.Printf("Failed to notify artist %d about verification request %d: %v", artist.ID, request.ID, err)
+	}
+}
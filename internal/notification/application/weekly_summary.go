@@ -0,0 +1,107 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log"
+	analyticsModel "music-app-backend/internal/analytics/domain"
+	model "music-app-backend/internal/notification/domain"
+	"strconv"
+	"time"
+)
+
+const weeklySummaryBatchSize = 200
+
+// StartWeeklySummaries checks every interval whether last week's artist summaries went
+// out. Checking often rather than waiting for Monday means a restart cannot skip a week.
+func (s *NotificationService) StartWeeklySummaries(ctx context.Context, interval time.Duration) {
+	if s.email == nil {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.SendWeeklySummaries(ctx, time.Now().UTC()); err != nil {
+					log.Printf("Failed to send weekly artist summaries: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// SendWeeklySummaries queues a summary of the last full ISO week (Monday to Sunday, UTC)
+// before now for every artist with activity in it. A Redis lock per week makes sure only
+// one replica sends them, once; artists are not retried if the run fails midway.
+func (s *NotificationService) SendWeeklySummaries(ctx context.Context, now time.Time) error {
+	weekStart := startOfISOWeek(now).AddDate(0, 0, -7)
+	weekEnd := weekStart.AddDate(0, 0, 6)
+
+	year, week := weekStart.ISOWeek()
+	acquired, err := s.email.RedisClient.SetNX(ctx, fmt.Sprintf("email:weekly_summary:%d-W%02d", year, week), now.Unix(), 8*24*time.Hour)
+	if err != nil || !acquired {
+		return err
+	}
+
+	var afterArtistID uint64
+	queued := 0
+	for {
+		totals, err := s.analyticsService.SumArtistStats(ctx, weekStart, weekEnd, afterArtistID, weeklySummaryBatchSize)
+		if err != nil {
+			return err
+		}
+		for i := range totals {
+			if err := s.queueWeeklySummary(ctx, &totals[i], weekStart, weekEnd); err != nil {
+				log.Printf("Failed to queue weekly summary for artist %d: %v", totals[i].ArtistID, err)
+				continue
+			}
+			queued++
+		}
+		if len(totals) < weeklySummaryBatchSize {
+			break
+		}
+		afterArtistID = totals[len(totals)-1].ArtistID
+	}
+
+	log.Printf("Queued %d weekly artist summaries for %d-W%02d", queued, year, week)
+	return nil
+}
+
+func (s *NotificationService) queueWeeklySummary(ctx context.Context, totals *analyticsModel.ArtistStatsTotals, weekStart, weekEnd time.Time) error {
+	artist, err := s.musicService.GetArtistByID(ctx, totals.ArtistID)
+	if err != nil || artist == nil {
+		return err
+	}
+
+	// Refunds of tips from earlier weeks can push the week's net below zero
+	tipsCents := max(totals.TipsReceivedCents, 0)
+
+	return s.email.RedisClient.Enqueue(ctx, EmailQueue, model.EmailJob{
+		UserID:   artist.UserID,
+		Template: model.EmailTemplateWeeklySummary,
+		Title:    "Your week on Audora",
+		Data: map[string]string{
+			"artist_name":    artist.ArtistName,
+			"week_start":     weekStart.Format("Jan 2"),
+			"week_end":       weekEnd.Format("Jan 2, 2006"),
+			"plays":          strconv.Itoa(totals.TotalPlays),
+			"listeners":      strconv.Itoa(totals.UniqueListeners),
+			"minutes_played": strconv.Itoa(totals.TotalDurationPlayed / 60),
+			"new_followers":  strconv.Itoa(totals.NewFollowers),
+			"tip_count":      strconv.Itoa(totals.TipCount),
+			"tips_amount":    fmt.Sprintf("%d.%02d", tipsCents/100, tipsCents%100),
+		},
+	})
+}
+
+// startOfISOWeek returns Monday 00:00 UTC of the week containing t
+func startOfISOWeek(t time.Time) time.Time {
+	t = t.UTC()
+	daysSinceMonday := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-daysSinceMonday, 0, 0, 0, 0, time.UTC)
+}
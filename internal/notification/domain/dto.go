@@ -0,0 +1,30 @@
+package model
+
+import (
+	"music-app-backend/pkg/pagination"
+	"time"
+)
+
+// NotifyDTO describes a notification sent to one or more users
+type NotifyDTO struct {
+	Type        NotificationType
+	Title       string
+	Body        string
+	ArtistID    *uint64
+	SongID      *uint64
+	ReferenceID *uint64
+	DedupKey    string
+	EmailData   map[string]string // Fields for the email template, see NotificationType.EmailTemplate
+}
+
+type NotificationListResult struct {
+	Notifications []Notification        `json:"notifications"`
+	UnreadCount   int64                 `json:"unread_count"`
+	Pagination    pagination.CursorMeta `json:"pagination"`
+}
+
+type MuteArtistDTO struct {
+	UserID   uint64
+	ArtistID uint64
+	Duration time.Duration // Zero mutes until unmuted
+}
@@ -0,0 +1,21 @@
+package model
+
+// EmailTemplateWeeklySummary is the weekly artist digest; it has no inbox entry
+const EmailTemplateWeeklySummary = "weekly_summary"
+
+// EmailJob is one queued email to one user. Attempt counts failed sends so far.
+type EmailJob struct {
+	UserID   uint64            `json:"user_id"`
+	Template string            `json:"template"`
+	Title    string            `json:"title"`
+	Body     string            `json:"body"`
+	Data     map[string]string `json:"data"`
+	Attempt  int               `json:"attempt"`
+}
+
+// EmailRecipient is a user who can currently receive notification emails
+type EmailRecipient struct {
+	UserID      uint64
+	Email       string
+	DisplayName string
+}
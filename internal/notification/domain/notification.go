@@ -0,0 +1,64 @@
+package model
+
+import (
+	"music-app-backend/pkg/model"
+	"time"
+)
+
+type NotificationType string
+
+const (
+	NotificationTypeNewRelease           NotificationType = "new_release"
+	NotificationTypeArtistMessage        NotificationType = "artist_message"
+	NotificationTypeTipReceived          NotificationType = "tip_received"
+	NotificationTypeTipRefunded          NotificationType = "tip_refunded"
+	NotificationTypeProcessingCompleted  NotificationType = "processing_completed"
+	NotificationTypeProcessingFailed     NotificationType = "processing_failed"
+	NotificationTypeVerificationApproved NotificationType = "verification_approved"
+	NotificationTypeVerificationRejected NotificationType = "verification_rejected"
+)
+
+// PreferenceColumn names the user_preferences switch that gates this type. Types without
+// one (the artist's own processing results) are always delivered.
+func (t NotificationType) PreferenceColumn() string {
+	switch t {
+	case NotificationTypeNewRelease:
+		return "notification_new_releases"
+	case NotificationTypeArtistMessage:
+		return "notification_artist_messages"
+	case NotificationTypeTipReceived, NotificationTypeTipRefunded:
+		return "notification_tips_received"
+	}
+	return ""
+}
+
+// EmailTemplate names the email sent alongside the inbox entry; types without one stay in-app
+func (t NotificationType) EmailTemplate() string {
+	switch t {
+	case NotificationTypeNewRelease, NotificationTypeTipReceived,
+		NotificationTypeProcessingCompleted, NotificationTypeProcessingFailed,
+		NotificationTypeVerificationApproved, NotificationTypeVerificationRejected:
+		return string(t)
+	}
+	return ""
+}
+
+// Mutable reports whether muting an artist suppresses this type
+func (t NotificationType) Mutable() bool {
+	return t == NotificationTypeNewRelease || t == NotificationTypeArtistMessage
+}
+
+// Notification is one entry of a user's in-app inbox. ReferenceID points at the tip,
+// message or song the notification is about, depending on Type.
+type Notification struct {
+	model.BaseModel
+	UserID      uint64           `json:"user_id" gorm:"not null;index"`
+	Type        NotificationType `json:"type" gorm:"not null;size:50"`
+	Title       string           `json:"title" gorm:"not null;size:200"`
+	Body        string           `json:"body"`
+	ArtistID    *uint64          `json:"artist_id"`
+	SongID      *uint64          `json:"song_id"`
+	ReferenceID *uint64          `json:"reference_id"`
+	DedupKey    *string          `json:"-" gorm:"size:100"` // Unique per user so a retried producer does not notify twice
+	ReadAt      *time.Time       `json:"read_at"`
+}
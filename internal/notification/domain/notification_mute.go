@@ -0,0 +1,19 @@
+package model
+
+import (
+	"music-app-backend/pkg/model"
+	"time"
+)
+
+// NotificationMute silences an artist's releases and messages for one user, either
+// until MutedUntil or, when it is nil, until the mute is removed
+type NotificationMute struct {
+	model.BaseModel
+	UserID     uint64     `json:"user_id" gorm:"not null;uniqueIndex:idx_notification_mute"`
+	ArtistID   uint64     `json:"artist_id" gorm:"not null;uniqueIndex:idx_notification_mute"`
+	MutedUntil *time.Time `json:"muted_until"`
+}
+
+func (m *NotificationMute) IsActive(now time.Time) bool {
+	return m.MutedUntil == nil || m.MutedUntil.After(now)
+}
@@ -0,0 +1,102 @@
+package notification
+
+import (
+	"context"
+	"log"
+	analyticsModuleSvc "music-app-backend/internal/analytics/application"
+	musicModuleSvc "music-app-backend/internal/music/application"
+	"music-app-backend/internal/notification/adapters/email"
+	"music-app-backend/internal/notification/adapters/http"
+	"music-app-backend/internal/notification/adapters/repository"
+	"music-app-backend/internal/notification/application"
+	realtimeModuleSvc "music-app-backend/internal/realtime/application"
+	ctx2 "music-app-backend/pkg/context"
+	"music-app-backend/pkg/mail"
+	"music-app-backend/pkg/middleware"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationModule struct {
+	Repository *repository.NotificationRepository
+	Service    *application.NotificationService
+	Handler    *http.NotificationHandler
+
+	authMiddleware *middleware.AuthMiddleware
+}
+
+func NewNotificationModule(serviceContext *ctx2.ServiceContext, authMiddleware *middleware.AuthMiddleware, musicService musicModuleSvc.IMusicService, analyticsService analyticsModuleSvc.IAnalyticsService, realtimeService realtimeModuleSvc.IRealtimeService) *NotificationModule {
+	sender, err := mail.NewSenderFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to initialize mail sender: %v", err)
+	}
+
+	renderer, err := email.NewRenderer()
+	if err != nil {
+		log.Fatalf("Failed to load email templates: %v", err)
+	}
+
+	emailChannel := &application.EmailChannel{
+		RedisClient:    serviceContext.GetRedisClient(),
+		Sender:         sender,
+		Renderer:       renderer,
+		Tokens:         mail.NewUnsubscribeTokensFromEnv(),
+		AppURL:         strings.TrimRight(getEnv("AUDORA_APP_URL", "http://localhost:3000"), "/"),
+		UnsubscribeURL: strings.TrimRight(getEnv("AUDORA_API_URL", "http://localhost:8080"), "/") + "/api/v1/email/unsubscribe",
+	}
+
+	notificationRepo := repository.NewNotificationRepository(serviceContext.GetDB())
+	notificationService := application.NewNotificationService(notificationRepo, serviceContext.GetIDGenerator(), musicService, analyticsService, realtimeService, emailChannel)
+	notificationHandler := http.NewNotificationHandler(notificationService)
+
+	return &NotificationModule{
+		Repository:     notificationRepo,
+		Service:        notificationService,
+		Handler:        notificationHandler,
+		authMiddleware: authMiddleware,
+	}
+}
+
+func (n *NotificationModule) RegisterRoutes(router *gin.RouterGroup) {
+	notifications := router.Group("/notifications")
+	notifications.Use(n.authMiddleware.RequireAuth())
+	{
+		notifications.GET("", n.Handler.ListNotifications)
+		notifications.GET("/unread-count", n.Handler.GetUnreadCount)
+		notifications.POST("/read-all", n.Handler.MarkAllRead)
+		notifications.POST("/:notification_id/read", n.Handler.MarkRead)
+
+		notifications.GET("/mutes", n.Handler.ListMutes)
+		notifications.PUT("/mutes/artists/:artist_id", n.Handler.MuteArtist)
+		notifications.DELETE("/mutes/artists/:artist_id", n.Handler.UnmuteArtist)
+	}
+}
+
+// StartWorkers launches the email senders (NOTIFICATION_EMAIL_WORKERS, default 2) and,
+// unless WEEKLY_SUMMARY_ENABLED is false, the hourly check for weekly artist summaries
+func (n *NotificationModule) StartWorkers(ctx context.Context) {
+	n.Service.StartEmailWorkers(ctx, int(intFromEnv("NOTIFICATION_EMAIL_WORKERS", 2)))
+	if os.Getenv("WEEKLY_SUMMARY_ENABLED") != "false" {
+		n.Service.StartWeeklySummaries(ctx, time.Hour)
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func intFromEnv(key string, defaultValue int64) int64 {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseInt(value, 10, 64); err == nil && parsed > 0 {
+			return parsed
+		}
+	}
+	return defaultValue
+}
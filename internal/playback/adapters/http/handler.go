@@ -0,0 +1,117 @@
+package http
+
+import (
+	"music-app-backend/internal/playback/application"
+	model "music-app-backend/internal/playback/domain"
+	appError "music-app-backend/pkg/error"
+	jsonResponse "music-app-backend/pkg/json"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PlaybackHandler struct {
+	playbackService *application.PlaybackService
+}
+
+func NewPlaybackHandler(playbackService *application.PlaybackService) *PlaybackHandler {
+	return &PlaybackHandler{
+		playbackService: playbackService,
+	}
+}
+
+func (h *PlaybackHandler) HandleError(c *gin.Context, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if appErr, ok := appError.GetAppError(err); ok {
+		jsonResponse.ResponseJSON(c, appErr.StatusCode, appErr.Message, appErr.Data)
+		return true
+	}
+
+	jsonResponse.ResponseInternalError(c, err)
+	return true
+}
+
+type PlayEventRequest struct {
+	ClientEventID   string              `json:"client_event_id" binding:"required"`
+	PlayID          string              `json:"play_id" binding:"required"`
+	SongID          uint64              `json:"song_id" binding:"required"`
+	Type            model.PlayEventType `json:"type" binding:"required,oneof=start progress end skip"`
+	PositionSeconds int                 `json:"position_seconds" binding:"gte=0"`
+	SkipReason      model.SkipReason    `json:"skip_reason"`
+	OccurredAt      *time.Time          `json:"occurred_at"` // Client timestamp, required for offline plays
+	SessionID       string              `json:"session_id"`
+	CountryCode     string              `json:"country_code" binding:"omitempty,len=2"`
+	City            string              `json:"city" binding:"max=100"`
+}
+
+type PlayEventBatchRequest struct {
+	Events []PlayEventRequest `json:"events" binding:"required,dive"`
+}
+
+// RecordPlayEvent records a single start/progress/end/skip event
+func (h *PlaybackHandler) RecordPlayEvent(c *gin.Context) {
+	request := &PlayEventRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	result, err := h.playbackService.RecordPlayEvent(c.Request.Context(), h.toDTO(c, request))
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseOK(c, result)
+}
+
+// RecordPlayEventBatch records events queued by the client, e.g. while offline
+func (h *PlaybackHandler) RecordPlayEventBatch(c *gin.Context) {
+	request := &PlayEventBatchRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	events := make([]model.PlayEventDTO, len(request.Events))
+	for i := range request.Events {
+		events[i] = *h.toDTO(c, &request.Events[i])
+	}
+
+	result, err := h.playbackService.RecordPlayEventBatch(c.Request.Context(), events)
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseOK(c, result)
+}
+
+func (h *PlaybackHandler) toDTO(c *gin.Context, request *PlayEventRequest) *model.PlayEventDTO {
+	event := &model.PlayEventDTO{
+		ClientEventID:   request.ClientEventID,
+		PlayID:          request.PlayID,
+		SongID:          request.SongID,
+		Type:            request.Type,
+		PositionSeconds: request.PositionSeconds,
+		SkipReason:      request.SkipReason,
+		OccurredAt:      request.OccurredAt,
+		SessionID:       request.SessionID,
+		IPAddress:       c.ClientIP(),
+		UserAgent:       c.Request.UserAgent(),
+		CountryCode:     strings.ToUpper(request.CountryCode),
+		City:            request.City,
+	}
+
+	// Fall back to the country resolved by the CDN when the client does not send one
+	if event.CountryCode == "" {
+		if country := c.GetHeader("CF-IPCountry"); len(country) == 2 {
+			event.CountryCode = strings.ToUpper(country)
+		}
+	}
+
+	event.UserID = currentUserID(c)
+	return event
+}
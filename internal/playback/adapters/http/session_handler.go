@@ -0,0 +1,145 @@
+package http
+
+import (
+	model "music-app-backend/internal/playback/domain"
+	jsonResponse "music-app-backend/pkg/json"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StartSessionRequest struct {
+	SongID      uint64 `json:"song_id" binding:"required"`
+	SessionID   string `json:"session_id"`
+	CountryCode string `json:"country_code" binding:"omitempty,len=2"`
+	City        string `json:"city" binding:"max=100"`
+}
+
+type SessionHeartbeatRequest struct {
+	SessionID string `json:"session_id"`
+	SongID    uint64 `json:"song_id"` // Optional; send when the listener moved to another song
+}
+
+// StartSession opens a live listening session
+func (h *PlaybackHandler) StartSession(c *gin.Context) {
+	request := &StartSessionRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	dto := &model.ListeningSessionDTO{
+		UserID:      currentUserID(c),
+		SessionID:   request.SessionID,
+		SongID:      request.SongID,
+		CountryCode: strings.ToUpper(request.CountryCode),
+		City:        request.City,
+	}
+	if dto.CountryCode == "" {
+		if country := c.GetHeader("CF-IPCountry"); len(country) == 2 {
+			dto.CountryCode = strings.ToUpper(country)
+		}
+	}
+
+	session, err := h.playbackService.StartListeningSession(c.Request.Context(), dto)
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseCreated(c, session)
+}
+
+// HeartbeatSession keeps a listening session live
+func (h *PlaybackHandler) HeartbeatSession(c *gin.Context) {
+	sessionID, err := strconv.ParseUint(c.Param("listening_session_id"), 10, 64)
+	if err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid session ID")
+		return
+	}
+
+	request := &SessionHeartbeatRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	session, err := h.playbackService.HeartbeatListeningSession(c.Request.Context(), &model.ListeningSessionDTO{
+		ListeningSessionID: sessionID,
+		UserID:             currentUserID(c),
+		SessionID:          request.SessionID,
+		SongID:             request.SongID,
+	})
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseOK(c, session)
+}
+
+// StopSession ends a listening session
+func (h *PlaybackHandler) StopSession(c *gin.Context) {
+	sessionID, err := strconv.ParseUint(c.Param("listening_session_id"), 10, 64)
+	if err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid session ID")
+		return
+	}
+
+	request := &SessionHeartbeatRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	err = h.playbackService.StopListeningSession(c.Request.Context(), &model.ListeningSessionDTO{
+		ListeningSessionID: sessionID,
+		UserID:             currentUserID(c),
+		SessionID:          request.SessionID,
+	})
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseOK(c, gin.H{"stopped": true})
+}
+
+// GetLiveListeners returns the current artist's live listeners by song, country and city
+func (h *PlaybackHandler) GetLiveListeners(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	live, err := h.playbackService.GetLiveListeners(c.Request.Context(), userID.(uint64))
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseOK(c, live)
+}
+
+// GetSongLiveListeners returns how many people are listening to a song right now
+func (h *PlaybackHandler) GetSongLiveListeners(c *gin.Context) {
+	songID, err := strconv.ParseUint(c.Param("song_id"), 10, 64)
+	if err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid song ID")
+		return
+	}
+
+	count, err := h.playbackService.GetSongLiveListeners(c.Request.Context(), songID, currentUserID(c))
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseOK(c, count)
+}
+
+func currentUserID(c *gin.Context) *uint64 {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		return nil
+	}
+	id := userID.(uint64)
+	return &id
+}
@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	model "music-app-backend/internal/playback/domain"
+	"music-app-backend/pkg/redis"
+	"strconv"
+	"time"
+)
+
+// Presence keys. Every live session has a hash with its details and is a member of its
+// artist's and song's sorted sets, scored by the last heartbeat in unix milliseconds.
+// Counts only include members newer than the timeout, so a replica that dies without
+// stopping its sessions never inflates the numbers; the sweeper just reclaims memory.
+const (
+	presenceArtistsKey = "presence:artists" // artist IDs with recent sessions
+	presenceSongsKey   = "presence:songs"   // song IDs with recent sessions
+	presenceSweepLock  = "presence:sweeper:lock"
+)
+
+func presenceArtistKey(artistID uint64) string {
+	return fmt.Sprintf("presence:artist:%d", artistID)
+}
+
+func presenceSongKey(songID uint64) string {
+	return fmt.Sprintf("presence:song:%d", songID)
+}
+
+func presenceSessionKey(sessionID uint64) string {
+	return fmt.Sprintf("presence:session:%d", sessionID)
+}
+
+type PresenceStore struct {
+	client  *redis.Client
+	timeout time.Duration
+}
+
+func NewPresenceStore(client *redis.Client, timeout time.Duration) *PresenceStore {
+	return &PresenceStore{
+		client:  client,
+		timeout: timeout,
+	}
+}
+
+// Touch records a heartbeat for the session
+func (p *PresenceStore) Touch(ctx context.Context, session *model.ListeningSession, at time.Time) error {
+	member := strconv.FormatUint(session.ID, 10)
+	score := float64(at.UnixMilli())
+
+	if err := p.client.HSet(ctx, presenceSessionKey(session.ID),
+		"artist_id", session.ArtistID,
+		"song_id", session.SongID,
+		"country_code", session.CountryCode,
+		"city", session.City,
+	); err != nil {
+		return err
+	}
+	if err := p.client.Expire(ctx, presenceSessionKey(session.ID), 2*p.timeout); err != nil {
+		return err
+	}
+
+	if err := p.client.ZAdd(ctx, presenceArtistKey(session.ArtistID), score, member); err != nil {
+		return err
+	}
+	if err := p.client.ZAdd(ctx, presenceSongKey(session.SongID), score, member); err != nil {
+		return err
+	}
+	if err := p.client.ZAdd(ctx, presenceArtistsKey, score, strconv.FormatUint(session.ArtistID, 10)); err != nil {
+		return err
+	}
+	return p.client.ZAdd(ctx, presenceSongsKey, score, strconv.FormatUint(session.SongID, 10))
+}
+
+// Remove drops the session from the sets it was counted in
+func (p *PresenceStore) Remove(ctx context.Context, sessionID, artistID, songID uint64) error {
+	member := strconv.FormatUint(sessionID, 10)
+	if err := p.client.ZRem(ctx, presenceArtistKey(artistID), member); err != nil {
+		return err
+	}
+	if err := p.client.ZRem(ctx, presenceSongKey(songID), member); err != nil {
+		return err
+	}
+	return p.client.Del(ctx, presenceSessionKey(sessionID))
+}
+
+func (p *PresenceStore) liveSince(now time.Time) string {
+	return "(" + strconv.FormatInt(now.Add(-p.timeout).UnixMilli(), 10)
+}
+
+// CountSongListeners returns the number of live sessions on the song
+func (p *PresenceStore) CountSongListeners(ctx context.Context, songID uint64, now time.Time) (int64, error) {
+	return p.client.ZCount(ctx, presenceSongKey(songID), p.liveSince(now), "+inf")
+}
+
+// CountArtistListeners returns the number of live sessions across the artist's songs
+func (p *PresenceStore) CountArtistListeners(ctx context.Context, artistID uint64, now time.Time) (int64, error) {
+	return p.client.ZCount(ctx, presenceArtistKey(artistID), p.liveSince(now), "+inf")
+}
+
+// ListArtistSessions returns the details of every live session for the artist
+func (p *PresenceStore) ListArtistSessions(ctx context.Context, artistID uint64, now time.Time) ([]model.LivePresence, error) {
+	members, err := p.client.ZRangeByScore(ctx, presenceArtistKey(artistID), p.liveSince(now), "+inf")
+	if err != nil {
+		return nil, err
+	}
+	if len(members) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, len(members))
+	for i, member := range members {
+		keys[i] = "presence:session:" + member
+	}
+	hashes, err := p.client.HGetAllMany(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]model.LivePresence, 0, len(hashes))
+	for i, hash := range hashes {
+		if len(hash) == 0 {
+			continue // Expired between the two reads
+		}
+		sessionID, _ := strconv.ParseUint(members[i], 10, 64)
+		songID, _ := strconv.ParseUint(hash["song_id"], 10, 64)
+		sessions = append(sessions, model.LivePresence{
+			SessionID:   sessionID,
+			SongID:      songID,
+			CountryCode: hash["country_code"],
+			City:        hash["city"],
+		})
+	}
+	return sessions, nil
+}
+
+// AcquireSweepLock lets a single replica sweep per interval. The lock is never released
+// explicitly; it expires just before the next tick.
+func (p *PresenceStore) AcquireSweepLock(ctx context.Context, ttl time.Duration) (bool, error) {
+	return p.client.SetNX(ctx, presenceSweepLock, strconv.FormatInt(time.Now().UnixNano(), 10), ttl)
+}
+
+// Sweep removes expired members from every artist and song set and forgets empty sets
+func (p *PresenceStore) Sweep(ctx context.Context, now time.Time) (int64, error) {
+	cutoff := strconv.FormatInt(now.Add(-p.timeout).UnixMilli(), 10)
+
+	var removed int64
+	for _, index := range []struct {
+		key    string
+		keyFor func(id uint64) string
+	}{
+		{presenceArtistsKey, presenceArtistKey},
+		{presenceSongsKey, presenceSongKey},
+	} {
+		ids, err := p.client.ZRangeByScore(ctx, index.key, "-inf", "+inf")
+		if err != nil {
+			return removed, err
+		}
+		for _, rawID := range ids {
+			id, err := strconv.ParseUint(rawID, 10, 64)
+			if err != nil {
+				continue
+			}
+			count, err := p.client.ZRemRangeByScore(ctx, index.keyFor(id), "-inf", cutoff)
+			if err != nil {
+				return removed, err
+			}
+			if index.key == presenceArtistsKey {
+				removed += count
+			}
+		}
+		// An ID whose last heartbeat is older than the timeout has no live members left
+		if _, err := p.client.ZRemRangeByScore(ctx, index.key, "-inf", cutoff); err != nil {
+			return removed, err
+		}
+	}
+	return removed, nil
+}
@@ -1,7 +1,12 @@
 package repository
 
 import (
+	"context"
+	model "music-app-backend/internal/playback/domain"
+	"time"
+
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PlaybackRepository struct {
@@ -15,4 +20,129 @@ func NewPlaybackRepository(db *gorm.DB) *PlaybackRepository {
 	}
 }
 
-func (r *PlaybackRepository) IMockRepository() {}
+// Transaction runs fn against a repository bound to a single database transaction
+func (r *PlaybackRepository) Transaction(ctx context.Context, fn func(txRepo *PlaybackRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&PlaybackRepository{db: tx})
+	})
+}
+
+// CreatePlayEvent stores the event and returns false if its client event ID was already seen
+func (r *PlaybackRepository) CreatePlayEvent(ctx context.Context, event *model.PlayEvent) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "client_event_id"}}, DoNothing: true}).
+		Create(event)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *PlaybackRepository) GetPlayEventByClientID(ctx context.Context, clientEventID string) (*model.PlayEvent, error) {
+	var event model.PlayEvent
+	err := r.db.WithContext(ctx).Where("client_event_id = ?", clientEventID).First(&event).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &event, nil
+}
+
+// CreateSongPlay inserts the play unless another request already created the same
+// client play ID. The trigger_update_play_counts trigger counts the play on insert.
+func (r *PlaybackRepository) CreateSongPlay(ctx context.Context, play *model.SongPlay) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "client_play_id"}}, DoNothing: true}).
+		Create(play)
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetSongPlayForUpdate loads a play by client play ID and locks the row for the rest of the transaction
+func (r *PlaybackRepository) GetSongPlayForUpdate(ctx context.Context, clientPlayID string) (*model.SongPlay, error) {
+	var play model.SongPlay
+	err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("client_play_id = ?", clientPlayID).
+		First(&play).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &play, nil
+}
+
+// GetSongPlayCount reads songs.play_count. Called in the transaction that inserted a play,
+// it returns the count including that play, and the trigger's row lock keeps it unique.
+func (r *PlaybackRepository) GetSongPlayCount(ctx context.Context, songID uint64) (int64, error) {
+	var playCount int64
+	err := r.db.WithContext(ctx).Table("songs").Select("play_count").Where("id = ?", songID).Scan(&playCount).Error
+	return playCount, err
+}
+
+func (r *PlaybackRepository) GetSongPlayByID(ctx context.Context, id uint64) (*model.SongPlay, error) {
+	var play model.SongPlay
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&play).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &play, nil
+}
+
+func (r *PlaybackRepository) UpdateSongPlayProgress(ctx context.Context, play *model.SongPlay) error {
+	return r.db.WithContext(ctx).Model(&model.SongPlay{}).
+		Where("id = ?", play.ID).
+		Updates(map[string]interface{}{
+			"duration_played_seconds": play.DurationPlayedSeconds,
+			"completed":               play.Completed,
+			"skip_reason":             play.SkipReason,
+			"updated_at":              play.UpdatedAt,
+		}).Error
+}
+
+func (r *PlaybackRepository) CreateListeningSession(ctx context.Context, session *model.ListeningSession) error {
+	return r.db.WithContext(ctx).Create(session).Error
+}
+
+func (r *PlaybackRepository) GetListeningSession(ctx context.Context, id uint64) (*model.ListeningSession, error) {
+	var session model.ListeningSession
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&session).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *PlaybackRepository) UpdateListeningSession(ctx context.Context, session *model.ListeningSession) error {
+	return r.db.WithContext(ctx).Save(session).Error
+}
+
+// EndClientSessions deactivates the listener's open sessions on the same client and returns them
+func (r *PlaybackRepository) EndClientSessions(ctx context.Context, sessionID string) ([]model.ListeningSession, error) {
+	var sessions []model.ListeningSession
+	err := r.db.WithContext(ctx).Raw(`
+		UPDATE listening_sessions
+		SET is_active = false, updated_at = CURRENT_TIMESTAMP
+		WHERE session_id = ? AND is_active = true
+		RETURNING *`, sessionID,
+	).Scan(&sessions).Error
+	return sessions, err
+}
+
+// ExpireStaleSessions deactivates sessions without a heartbeat since before and returns them
+func (r *PlaybackRepository) ExpireStaleSessions(ctx context.Context, before time.Time) ([]model.ListeningSession, error) {
+	var sessions []model.ListeningSession
+	err := r.db.WithContext(ctx).Raw(`
+		UPDATE listening_sessions
+		SET is_active = false, updated_at = CURRENT_TIMESTAMP
+		WHERE is_active = true AND last_heartbeat < ?
+		RETURNING *`, before,
+	).Scan(&sessions).Error
+	return sessions, err
+}
@@ -0,0 +1,372 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log"
+	analyticsModel "music-app-backend/internal/analytics/domain"
+	musicModel "music-app-backend/internal/music/domain"
+	"music-app-backend/internal/playback/adapters/repository"
+	model "music-app-backend/internal/playback/domain"
+	realtimeModel "music-app-backend/internal/realtime/domain"
+	appError "music-app-backend/pkg/error"
+	baseModel "music-app-backend/pkg/model"
+	"music-app-backend/pkg/ratelimit"
+	"net"
+	"strconv"
+	"time"
+)
+
+const (
+	MaxPlayEventBatchSize = 100
+
+	maxClientIDLength = 100
+	// Offline plays older than this are dropped; client clocks ahead by more than the skew are rejected
+	maxOfflineEventAge = 30 * 24 * time.Hour
+	maxClientClockSkew = 5 * time.Minute
+)
+
+// RecordPlayEvent applies one client event to its SongPlay. The first event of a play_id
+// creates the row (and counts the play); later events only move the played duration
+// forward. Re-sent events are reported as duplicates without changing anything.
+func (s *PlaybackService) RecordPlayEvent(ctx context.Context, event *model.PlayEventDTO) (*model.PlayEventResult, error) {
+	if err := s.allowPlayEvents(ctx, event); err != nil {
+		return nil, err
+	}
+	return s.recordPlayEvent(ctx, event)
+}
+
+func (s *PlaybackService) recordPlayEvent(ctx context.Context, event *model.PlayEventDTO) (*model.PlayEventResult, error) {
+	occurredAt, err := validatePlayEvent(event)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repository.GetPlayEventByClientID(ctx, event.ClientEventID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to check play event")
+	}
+	if existing != nil {
+		return s.duplicateResult(ctx, existing)
+	}
+
+	song, err := s.musicService.GetSongByID(ctx, event.SongID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load song")
+	}
+	if song == nil || !song.IsActive {
+		return nil, appError.NewNotFoundError(nil, "song not found")
+	}
+
+	position := event.PositionSeconds
+	if song.DurationSeconds != nil && *song.DurationSeconds > 0 && position > *song.DurationSeconds {
+		position = *song.DurationSeconds
+	}
+
+	var play *model.SongPlay
+	var isNewPlay, isDuplicate bool
+	var playedDelta int
+	var playCount int64
+	err = s.repository.Transaction(ctx, func(txRepo *repository.PlaybackRepository) error {
+		play, err = txRepo.GetSongPlayForUpdate(ctx, event.PlayID)
+		if err != nil {
+			return err
+		}
+
+		if play == nil {
+			// Events can arrive out of order offline, so any event type may open the play
+			play, err = s.newSongPlay(event, occurredAt.Add(-time.Duration(position)*time.Second))
+			if err != nil {
+				return err
+			}
+			created, err := txRepo.CreateSongPlay(ctx, play)
+			if err != nil {
+				return err
+			}
+			isNewPlay = created
+			if created {
+				if playCount, err = txRepo.GetSongPlayCount(ctx, song.ID); err != nil {
+					return err
+				}
+			}
+			if !created {
+				if play, err = txRepo.GetSongPlayForUpdate(ctx, event.PlayID); err != nil {
+					return err
+				}
+			}
+		}
+
+		if !ownsPlay(play, event) {
+			return appError.NewBadRequestError(nil, "play_id belongs to another playback")
+		}
+
+		_base, err := baseModel.NewBaseModel(s.generator)
+		if err != nil {
+			return err
+		}
+		recorded, err := txRepo.CreatePlayEvent(ctx, &model.PlayEvent{
+			BaseModel:       *_base,
+			ClientEventID:   event.ClientEventID,
+			SongPlayID:      play.ID,
+			EventType:       event.Type,
+			PositionSeconds: position,
+			OccurredAt:      occurredAt,
+		})
+		if err != nil {
+			return err
+		}
+		if !recorded {
+			// Lost a race with a concurrent retry of the same event
+			isDuplicate = true
+			return nil
+		}
+
+		if event.Type == model.PlayEventStart && !isNewPlay {
+			return nil
+		}
+
+		played := play.DurationPlayedSeconds
+		if position > played {
+			played = position
+		}
+		playedDelta = played - play.DurationPlayedSeconds
+		play.DurationPlayedSeconds = played
+		play.Completed = play.Completed || model.IsCompleted(played, song.DurationSeconds, event.Type == model.PlayEventEnd)
+		if event.Type == model.PlayEventSkip {
+			reason := event.SkipReason
+			play.SkipReason = &reason
+		}
+		play.UpdatedAt = time.Now().UTC()
+		return txRepo.UpdateSongPlayProgress(ctx, play)
+	})
+	if err != nil {
+		if appError.IsAppError(err) {
+			return nil, err
+		}
+		return nil, appError.NewInternalError(err, "failed to record play event")
+	}
+
+	if isDuplicate {
+		return &model.PlayEventResult{
+			ClientEventID: event.ClientEventID,
+			Status:        model.PlayEventStatusDuplicate,
+			SongPlayID:    play.ID,
+			Completed:     play.Completed,
+		}, nil
+	}
+
+	if isNewPlay || playedDelta > 0 {
+		delta := &analyticsModel.ArtistStatsDelta{TotalDurationPlayed: playedDelta}
+		if isNewPlay {
+			delta.TotalPlays = 1
+		}
+		if err := s.analyticsService.RecordArtistStats(ctx, song.ArtistID, play.PlayedAt, delta); err != nil {
+			log.Printf("Failed to record play %d in daily artist stats: %v", play.ID, err)
+		}
+	}
+	if isNewPlay && isPlayMilestone(playCount) {
+		s.publishSongMilestone(ctx, song, playCount)
+	}
+
+	return &model.PlayEventResult{
+		ClientEventID: event.ClientEventID,
+		Status:        model.PlayEventStatusAccepted,
+		SongPlayID:    play.ID,
+		Completed:     play.Completed,
+	}, nil
+}
+
+// RecordPlayEventBatch applies events in order, typically plays queued while offline.
+// Invalid events are rejected individually; a server error aborts the batch so the
+// client retries it, which is safe because accepted events are deduplicated.
+func (s *PlaybackService) RecordPlayEventBatch(ctx context.Context, events []model.PlayEventDTO) (*model.PlayEventBatchResult, error) {
+	if len(events) == 0 {
+		return nil, appError.NewBadRequestError(nil, "events must not be empty")
+	}
+	if len(events) > MaxPlayEventBatchSize {
+		return nil, appError.NewBadRequestError(nil, fmt.Sprintf("at most %d events can be sent at once", MaxPlayEventBatchSize))
+	}
+
+	// A batch counts as one request; its size is bounded above
+	if err := s.allowPlayEvents(ctx, &events[0]); err != nil {
+		return nil, err
+	}
+
+	batch := &model.PlayEventBatchResult{Results: make([]model.PlayEventResult, 0, len(events))}
+	for i := range events {
+		result, err := s.recordPlayEvent(ctx, &events[i])
+		if err != nil {
+			appErr, ok := appError.GetAppError(err)
+			if !ok || appErr.StatusCode >= 500 {
+				return nil, err
+			}
+			result = &model.PlayEventResult{
+				ClientEventID: events[i].ClientEventID,
+				Status:        model.PlayEventStatusRejected,
+				Error:         appErr.Message,
+			}
+		}
+
+		switch result.Status {
+		case model.PlayEventStatusAccepted:
+			batch.Accepted++
+		case model.PlayEventStatusDuplicate:
+			batch.Duplicate++
+		case model.PlayEventStatusRejected:
+			batch.Rejected++
+		}
+		batch.Results = append(batch.Results, *result)
+	}
+
+	return batch, nil
+}
+
+// allowPlayEvents rate limits play event requests, which anonymous clients can send too:
+// per user when signed in, otherwise per device (session_id) and per IP address
+func (s *PlaybackService) allowPlayEvents(ctx context.Context, event *model.PlayEventDTO) error {
+	type check struct {
+		limiter *ratelimit.Limiter
+		key     string
+	}
+	var checks []check
+	if event.UserID != nil {
+		checks = append(checks, check{s.listenerLimiter, "user:" + strconv.FormatUint(*event.UserID, 10)})
+	} else {
+		if event.SessionID != "" {
+			checks = append(checks, check{s.listenerLimiter, "device:" + event.SessionID})
+		}
+		checks = append(checks, check{s.ipLimiter, "ip:" + event.IPAddress})
+	}
+
+	for _, c := range checks {
+		limit, err := c.limiter.Allow(ctx, c.key)
+		if err != nil {
+			return appError.NewInternalError(err, "failed to check play event rate limit")
+		}
+		if !limit.Allowed {
+			retryAfter := int(limit.RetryAfter.Seconds()) + 1
+			return appError.NewTooManyRequestsError(nil,
+				fmt.Sprintf("play event limit of %d per %s reached", limit.Limit.Max, limit.Limit.Window)).
+				WithData(map[string]int{"retry_after_seconds": retryAfter})
+		}
+	}
+	return nil
+}
+
+func (s *PlaybackService) newSongPlay(event *model.PlayEventDTO, playedAt time.Time) (*model.SongPlay, error) {
+	_base, err := baseModel.NewBaseModel(s.generator)
+	if err != nil {
+		return nil, err
+	}
+
+	var ipAddress *string
+	if ip := net.ParseIP(event.IPAddress); ip != nil {
+		value := ip.String()
+		ipAddress = &value
+	}
+
+	return &model.SongPlay{
+		BaseModel:    *_base,
+		SongID:       event.SongID,
+		UserID:       event.UserID,
+		SessionID:    event.SessionID,
+		ClientPlayID: event.PlayID,
+		IPAddress:    ipAddress,
+		UserAgent:    event.UserAgent,
+		CountryCode:  event.CountryCode,
+		City:         event.City,
+		PlayedAt:     playedAt,
+	}, nil
+}
+
+func (s *PlaybackService) duplicateResult(ctx context.Context, event *model.PlayEvent) (*model.PlayEventResult, error) {
+	play, err := s.repository.GetSongPlayByID(ctx, event.SongPlayID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load play")
+	}
+
+	result := &model.PlayEventResult{
+		ClientEventID: event.ClientEventID,
+		Status:        model.PlayEventStatusDuplicate,
+		SongPlayID:    event.SongPlayID,
+	}
+	if play != nil {
+		result.Completed = play.Completed
+	}
+	return result, nil
+}
+
+// ownsPlay makes sure a client can only extend its own plays
+func ownsPlay(play *model.SongPlay, event *model.PlayEventDTO) bool {
+	if play.SongID != event.SongID {
+		return false
+	}
+	if play.UserID != nil || event.UserID != nil {
+		return play.UserID != nil && event.UserID != nil && *play.UserID == *event.UserID
+	}
+	return play.SessionID == event.SessionID
+}
+
+func validatePlayEvent(event *model.PlayEventDTO) (time.Time, error) {
+	if event.ClientEventID == "" || len(event.ClientEventID) > maxClientIDLength {
+		return time.Time{}, appError.NewBadRequestError(nil, fmt.Sprintf("client_event_id is required and must be at most %d characters", maxClientIDLength))
+	}
+	if event.PlayID == "" || len(event.PlayID) > maxClientIDLength {
+		return time.Time{}, appError.NewBadRequestError(nil, fmt.Sprintf("play_id is required and must be at most %d characters", maxClientIDLength))
+	}
+	if event.SongID == 0 {
+		return time.Time{}, appError.NewBadRequestError(nil, "song_id is required")
+	}
+	if event.UserID == nil && event.SessionID == "" {
+		return time.Time{}, appError.NewBadRequestError(nil, "session_id is required for anonymous plays")
+	}
+	if event.PositionSeconds < 0 {
+		return time.Time{}, appError.NewBadRequestError(nil, "position_seconds must not be negative")
+	}
+
+	switch event.Type {
+	case model.PlayEventStart, model.PlayEventProgress, model.PlayEventEnd:
+	case model.PlayEventSkip:
+		if event.SkipReason == "" {
+			event.SkipReason = model.SkipReasonUserSkip
+		}
+		if !event.SkipReason.IsValid() {
+			return time.Time{}, appError.NewBadRequestError(nil, fmt.Sprintf("unknown skip_reason %q", event.SkipReason))
+		}
+	default:
+		return time.Time{}, appError.NewBadRequestError(nil, fmt.Sprintf("unknown event type %q", event.Type))
+	}
+
+	now := time.Now().UTC()
+	if event.OccurredAt == nil {
+		return now, nil
+	}
+	occurredAt := event.OccurredAt.UTC()
+	if occurredAt.After(now.Add(maxClientClockSkew)) {
+		return time.Time{}, appError.NewBadRequestError(nil, "occurred_at is in the future")
+	}
+	if occurredAt.Before(now.Add(-maxOfflineEventAge)) {
+		return time.Time{}, appError.NewBadRequestError(nil, "occurred_at is too old to be recorded")
+	}
+	return occurredAt, nil
+}
+
+// isPlayMilestone matches 100, 250, 500, 1000, 2500, 5000, 10000, ...
+func isPlayMilestone(playCount int64) bool {
+	for base := int64(100); base > 0 && base <= playCount; base *= 10 {
+		if playCount == base || playCount == base*5/2 || playCount == base*5 {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *PlaybackService) publishSongMilestone(ctx context.Context, song *musicModel.Song, playCount int64) {
+	if err := s.realtimeService.PublishArtistEvent(ctx, song.ArtistID, realtimeModel.EventSongMilestone, realtimeModel.SongMilestoneData{
+		SongID:    song.ID,
+		Title:     song.Title,
+		PlayCount: playCount,
+	}); err != nil {
+		log.Printf("Failed to publish milestone for song %d: %v", song.ID, err)
+	}
+}
@@ -1,13 +1,50 @@
 package application
 
-import "music-app-backend/internal/playback/adapters/repository"
+import (
+	analyticsModuleSvc "music-app-backend/internal/analytics/application"
+	musicModuleSvc "music-app-backend/internal/music/application"
+	"music-app-backend/internal/playback/adapters/repository"
+	realtimeModuleSvc "music-app-backend/internal/realtime/application"
+	"music-app-backend/pkg/ratelimit"
+	"time"
+
+	goflakeid "github.com/capy-engineer/go-flakeid"
+)
 
 type PlaybackService struct {
-	repository *repository.PlaybackRepository
+	repository       *repository.PlaybackRepository
+	generator        *goflakeid.Generator
+	musicService     musicModuleSvc.IMusicService
+	analyticsService analyticsModuleSvc.IAnalyticsService
+	realtimeService  realtimeModuleSvc.IRealtimeService
+	presence         *repository.PresenceStore
+	sessionTimeout   time.Duration
+	// Play event requests per listener (user, or device for anonymous listeners) and per
+	// IP address of anonymous listeners
+	listenerLimiter *ratelimit.Limiter
+	ipLimiter       *ratelimit.Limiter
 }
 
-func NewPlaybackService(repository *repository.PlaybackRepository) *PlaybackService {
-	return &PlaybackService{repository: repository}
+func NewPlaybackService(
+	repository *repository.PlaybackRepository,
+	generator *goflakeid.Generator,
+	musicService musicModuleSvc.IMusicService,
+	analyticsService analyticsModuleSvc.IAnalyticsService,
+	realtimeService realtimeModuleSvc.IRealtimeService,
+	presence *repository.PresenceStore,
+	sessionTimeout time.Duration,
+	listenerLimiter *ratelimit.Limiter,
+	ipLimiter *ratelimit.Limiter,
+) *PlaybackService {
+	return &PlaybackService{
+		repository:       repository,
+		generator:        generator,
+		musicService:     musicService,
+		analyticsService: analyticsService,
+		realtimeService:  realtimeService,
+		presence:         presence,
+		sessionTimeout:   sessionTimeout,
+		listenerLimiter:  listenerLimiter,
+		ipLimiter:        ipLimiter,
+	}
 }
-
-func (s *PlaybackService) IMockService() {}
\ No newline at end of file
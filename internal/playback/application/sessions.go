@@ -0,0 +1,368 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log"
+	model "music-app-backend/internal/playback/domain"
+	realtimeModel "music-app-backend/internal/realtime/domain"
+	appError "music-app-backend/pkg/error"
+	baseModel "music-app-backend/pkg/model"
+	"sort"
+	"time"
+)
+
+// StartListeningSession opens a live session for the listener. Any session still open on
+// the same client is ended first, so a device is only ever counted once.
+func (s *PlaybackService) StartListeningSession(ctx context.Context, request *model.ListeningSessionDTO) (*model.ListeningSession, error) {
+	clientSessionID, err := resolveClientSessionID(request)
+	if err != nil {
+		return nil, err
+	}
+
+	song, err := s.musicService.GetSongByID(ctx, request.SongID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load song")
+	}
+	if song == nil || !song.IsActive {
+		return nil, appError.NewNotFoundError(nil, "song not found")
+	}
+	if err := s.musicService.CheckSongAccess(ctx, song, request.UserID); err != nil {
+		return nil, err
+	}
+
+	previous, err := s.repository.EndClientSessions(ctx, clientSessionID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to end previous session")
+	}
+	for _, session := range previous {
+		s.removePresence(ctx, &session)
+		s.publishListenerCounts(ctx, session.ArtistID, session.SongID)
+	}
+
+	_base, err := baseModel.NewBaseModel(s.generator)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	session := &model.ListeningSession{
+		BaseModel:     *_base,
+		UserID:        request.UserID,
+		SessionID:     clientSessionID,
+		SongID:        song.ID,
+		ArtistID:      song.ArtistID,
+		CountryCode:   request.CountryCode,
+		City:          request.City,
+		StartedAt:     now,
+		LastHeartbeat: now,
+		IsActive:      true,
+	}
+	if err := s.repository.CreateListeningSession(ctx, session); err != nil {
+		return nil, appError.NewInternalError(err, "failed to start session")
+	}
+
+	if err := s.presence.Touch(ctx, session, now); err != nil {
+		return nil, appError.NewInternalError(err, "failed to record presence")
+	}
+	s.publishListenerJoined(ctx, session)
+
+	return session, nil
+}
+
+// HeartbeatListeningSession keeps the session live and follows the listener to a new song
+func (s *PlaybackService) HeartbeatListeningSession(ctx context.Context, request *model.ListeningSessionDTO) (*model.ListeningSession, error) {
+	session, err := s.loadOwnedSession(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	songChanged := false
+	if !session.IsActive || now.Sub(session.LastHeartbeat) > s.sessionTimeout {
+		return nil, appError.NewBadRequestError(nil, "session has expired, start a new one")
+	}
+
+	if request.SongID != 0 && request.SongID != session.SongID {
+		song, err := s.musicService.GetSongByID(ctx, request.SongID)
+		if err != nil {
+			return nil, appError.NewInternalError(err, "failed to load song")
+		}
+		if song == nil || !song.IsActive {
+			return nil, appError.NewNotFoundError(nil, "song not found")
+		}
+		if err := s.musicService.CheckSongAccess(ctx, song, request.UserID); err != nil {
+			return nil, err
+		}
+
+		s.removePresence(ctx, session)
+		s.publishListenerCounts(ctx, session.ArtistID, session.SongID)
+		session.SongID = song.ID
+		session.ArtistID = song.ArtistID
+		songChanged = true
+	}
+
+	session.LastHeartbeat = now
+	session.UpdatedAt = now
+	if err := s.repository.UpdateListeningSession(ctx, session); err != nil {
+		return nil, appError.NewInternalError(err, "failed to update session")
+	}
+
+	if err := s.presence.Touch(ctx, session, now); err != nil {
+		return nil, appError.NewInternalError(err, "failed to record presence")
+	}
+	if songChanged {
+		s.publishListenerJoined(ctx, session)
+	}
+
+	return session, nil
+}
+
+// StopListeningSession ends the session immediately instead of waiting for it to expire
+func (s *PlaybackService) StopListeningSession(ctx context.Context, request *model.ListeningSessionDTO) error {
+	session, err := s.loadOwnedSession(ctx, request)
+	if err != nil {
+		return err
+	}
+	if !session.IsActive {
+		return nil
+	}
+
+	session.IsActive = false
+	session.UpdatedAt = time.Now().UTC()
+	if err := s.repository.UpdateListeningSession(ctx, session); err != nil {
+		return appError.NewInternalError(err, "failed to stop session")
+	}
+
+	s.removePresence(ctx, session)
+	s.publishListenerCounts(ctx, session.ArtistID, session.SongID)
+	return nil
+}
+
+// GetLiveListeners returns who is listening to the artist owned by userID right now
+func (s *PlaybackService) GetLiveListeners(ctx context.Context, userID uint64) (*model.LiveListeners, error) {
+	artist, err := s.musicService.GetArtistByUserID(ctx, userID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load artist")
+	}
+	if artist == nil {
+		return nil, appError.NewNotFoundError(nil, "artist profile not found")
+	}
+
+	return s.liveListenersForArtist(ctx, artist.ID)
+}
+
+func (s *PlaybackService) liveListenersForArtist(ctx context.Context, artistID uint64) (*model.LiveListeners, error) {
+	now := time.Now().UTC()
+	sessions, err := s.presence.ListArtistSessions(ctx, artistID, now)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load live listeners")
+	}
+
+	songCounts := map[uint64]int{}
+	countryCounts := map[string]int{}
+	cityCounts := map[string]map[string]int{}
+	for _, session := range sessions {
+		songCounts[session.SongID]++
+
+		country := session.CountryCode
+		if country == "" {
+			country = "unknown"
+		}
+		countryCounts[country]++
+		if cityCounts[country] == nil {
+			cityCounts[country] = map[string]int{}
+		}
+		city := session.City
+		if city == "" {
+			city = "unknown"
+		}
+		cityCounts[country][city]++
+	}
+
+	live := &model.LiveListeners{
+		ArtistID:    artistID,
+		Listeners:   len(sessions),
+		Songs:       make([]model.SongListenerCount, 0, len(songCounts)),
+		Countries:   make([]model.CountryListenerCount, 0, len(countryCounts)),
+		GeneratedAt: now,
+	}
+	for songID, count := range songCounts {
+		live.Songs = append(live.Songs, model.SongListenerCount{SongID: songID, Listeners: count})
+	}
+	sort.Slice(live.Songs, func(i, j int) bool {
+		if live.Songs[i].Listeners != live.Songs[j].Listeners {
+			return live.Songs[i].Listeners > live.Songs[j].Listeners
+		}
+		return live.Songs[i].SongID < live.Songs[j].SongID
+	})
+
+	for country, count := range countryCounts {
+		row := model.CountryListenerCount{CountryCode: country, Listeners: count}
+		for city, cityCount := range cityCounts[country] {
+			row.Cities = append(row.Cities, model.CityListenerCount{City: city, Listeners: cityCount})
+		}
+		sort.Slice(row.Cities, func(i, j int) bool {
+			if row.Cities[i].Listeners != row.Cities[j].Listeners {
+				return row.Cities[i].Listeners > row.Cities[j].Listeners
+			}
+			return row.Cities[i].City < row.Cities[j].City
+		})
+		live.Countries = append(live.Countries, row)
+	}
+	sort.Slice(live.Countries, func(i, j int) bool {
+		if live.Countries[i].Listeners != live.Countries[j].Listeners {
+			return live.Countries[i].Listeners > live.Countries[j].Listeners
+		}
+		return live.Countries[i].CountryCode < live.Countries[j].CountryCode
+	})
+
+	return live, nil
+}
+
+// GetSongLiveListeners returns the number of listeners on a song right now
+func (s *PlaybackService) GetSongLiveListeners(ctx context.Context, songID uint64, userID *uint64) (*model.SongListenerCount, error) {
+	song, err := s.musicService.GetSongByID(ctx, songID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load song")
+	}
+	if song == nil || !song.IsActive {
+		return nil, appError.NewNotFoundError(nil, "song not found")
+	}
+	if err := s.musicService.CheckSongAccess(ctx, song, userID); err != nil {
+		return nil, err
+	}
+
+	count, err := s.presence.CountSongListeners(ctx, songID, time.Now().UTC())
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to count listeners")
+	}
+	return &model.SongListenerCount{SongID: songID, Listeners: int(count)}, nil
+}
+
+// SweepStaleSessions ends sessions whose heartbeat stopped. Only the replica holding the
+// sweep lock does the work; the others return immediately.
+func (s *PlaybackService) SweepStaleSessions(ctx context.Context, lockTTL time.Duration) error {
+	acquired, err := s.presence.AcquireSweepLock(ctx, lockTTL)
+	if err != nil || !acquired {
+		return err
+	}
+
+	now := time.Now().UTC()
+	expired, err := s.repository.ExpireStaleSessions(ctx, now.Add(-s.sessionTimeout))
+	if err != nil {
+		return fmt.Errorf("failed to expire sessions: %w", err)
+	}
+	changed := map[[2]uint64]bool{}
+	for _, session := range expired {
+		s.removePresence(ctx, &session)
+		changed[[2]uint64{session.ArtistID, session.SongID}] = true
+	}
+	for key := range changed {
+		s.publishListenerCounts(ctx, key[0], key[1])
+	}
+
+	if _, err := s.presence.Sweep(ctx, now); err != nil {
+		return fmt.Errorf("failed to sweep presence: %w", err)
+	}
+	return nil
+}
+
+// StartSessionSweeper runs SweepStaleSessions on every tick until ctx is cancelled
+func (s *PlaybackService) StartSessionSweeper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// Expire the lock just before the next tick so a crashed sweeper is replaced
+				if err := s.SweepStaleSessions(ctx, interval*9/10); err != nil {
+					log.Printf("Listening session sweep failed: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+func (s *PlaybackService) loadOwnedSession(ctx context.Context, request *model.ListeningSessionDTO) (*model.ListeningSession, error) {
+	clientSessionID, err := resolveClientSessionID(request)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.repository.GetListeningSession(ctx, request.ListeningSessionID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load session")
+	}
+	if session == nil || session.SessionID != clientSessionID {
+		return nil, appError.NewNotFoundError(nil, "session not found")
+	}
+	if session.UserID != nil && (request.UserID == nil || *session.UserID != *request.UserID) {
+		return nil, appError.NewNotFoundError(nil, "session not found")
+	}
+	return session, nil
+}
+
+func (s *PlaybackService) removePresence(ctx context.Context, session *model.ListeningSession) {
+	if err := s.presence.Remove(ctx, session.ID, session.ArtistID, session.SongID); err != nil {
+		log.Printf("Failed to remove presence for session %d: %v", session.ID, err)
+	}
+}
+
+// resolveClientSessionID identifies the listener's device. Signed-in listeners that do
+// not send one get a per-user identifier.
+func resolveClientSessionID(request *model.ListeningSessionDTO) (string, error) {
+	if len(request.SessionID) > maxClientIDLength {
+		return "", appError.NewBadRequestError(nil, fmt.Sprintf("session_id must be at most %d characters", maxClientIDLength))
+	}
+	if request.SessionID != "" {
+		return request.SessionID, nil
+	}
+	if request.UserID != nil {
+		return fmt.Sprintf("user-%d", *request.UserID), nil
+	}
+	return "", appError.NewBadRequestError(nil, "session_id is required for anonymous listeners")
+}
+
+// publishListenerJoined tells the artist's dashboard where a new listener is
+func (s *PlaybackService) publishListenerJoined(ctx context.Context, session *model.ListeningSession) {
+	now := time.Now().UTC()
+	listeners, songListeners := s.countListeners(ctx, session.ArtistID, session.SongID, now)
+	if err := s.realtimeService.PublishArtistEvent(ctx, session.ArtistID, realtimeModel.EventListenerJoined, realtimeModel.ListenerJoinedData{
+		SongID:        session.SongID,
+		CountryCode:   session.CountryCode,
+		City:          session.City,
+		Listeners:     listeners,
+		SongListeners: songListeners,
+	}); err != nil {
+		log.Printf("Failed to publish listener joined for artist %d: %v", session.ArtistID, err)
+	}
+}
+
+// publishListenerCounts pushes the current counts after listeners left
+func (s *PlaybackService) publishListenerCounts(ctx context.Context, artistID, songID uint64) {
+	now := time.Now().UTC()
+	listeners, songListeners := s.countListeners(ctx, artistID, songID, now)
+	if err := s.realtimeService.PublishArtistEvent(ctx, artistID, realtimeModel.EventListenersUpdated, realtimeModel.ListenersUpdatedData{
+		Listeners:     listeners,
+		SongID:        songID,
+		SongListeners: songListeners,
+	}); err != nil {
+		log.Printf("Failed to publish listener counts for artist %d: %v", artistID, err)
+	}
+}
+
+func (s *PlaybackService) countListeners(ctx context.Context, artistID, songID uint64, now time.Time) (int64, int64) {
+	listeners, err := s.presence.CountArtistListeners(ctx, artistID, now)
+	if err != nil {
+		log.Printf("Failed to count listeners for artist %d: %v", artistID, err)
+	}
+	songListeners, err := s.presence.CountSongListeners(ctx, songID, now)
+	if err != nil {
+		log.Printf("Failed to count listeners for song %d: %v", songID, err)
+	}
+	return listeners, songListeners
+}
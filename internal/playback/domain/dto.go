@@ -0,0 +1,79 @@
+package model
+
+import "time"
+
+// PlayEventDTO is one client playback event. PlayID groups the events of a single playback.
+type PlayEventDTO struct {
+	ClientEventID   string
+	PlayID          string
+	SongID          uint64
+	Type            PlayEventType
+	PositionSeconds int
+	SkipReason      SkipReason
+	OccurredAt      *time.Time // Client clock; nil means now
+	UserID          *uint64
+	SessionID       string
+	IPAddress       string
+	UserAgent       string
+	CountryCode     string
+	City            string
+}
+
+type PlayEventStatus string
+
+const (
+	PlayEventStatusAccepted  PlayEventStatus = "accepted"
+	PlayEventStatusDuplicate PlayEventStatus = "duplicate"
+	PlayEventStatusRejected  PlayEventStatus = "rejected"
+)
+
+type PlayEventResult struct {
+	ClientEventID string          `json:"client_event_id"`
+	Status        PlayEventStatus `json:"status"`
+	SongPlayID    uint64          `json:"song_play_id,omitempty"`
+	Completed     bool            `json:"completed"`
+	Error         string          `json:"error,omitempty"`
+}
+
+type PlayEventBatchResult struct {
+	Accepted  int               `json:"accepted"`
+	Duplicate int               `json:"duplicate"`
+	Rejected  int               `json:"rejected"`
+	Results   []PlayEventResult `json:"results"`
+}
+
+// ListeningSessionDTO starts a session or reports a heartbeat. SessionID is the
+// client's device/session identifier, required for anonymous listeners.
+type ListeningSessionDTO struct {
+	ListeningSessionID uint64 // Set for heartbeat and stop
+	UserID             *uint64
+	SessionID          string
+	SongID             uint64 // On heartbeat, a different song means the listener moved on
+	CountryCode        string
+	City               string
+}
+
+type SongListenerCount struct {
+	SongID    uint64 `json:"song_id"`
+	Listeners int    `json:"listeners"`
+}
+
+type CityListenerCount struct {
+	City      string `json:"city"`
+	Listeners int    `json:"listeners"`
+}
+
+type CountryListenerCount struct {
+	CountryCode string              `json:"country_code"`
+	Listeners   int                 `json:"listeners"`
+	Cities      []CityListenerCount `json:"cities"`
+}
+
+// LiveListeners is the "listening right now" breakdown for an artist
+type LiveListeners struct {
+	ArtistID    uint64                 `json:"artist_id"`
+	Listeners   int                    `json:"listeners"`
+	Songs       []SongListenerCount    `json:"songs"`
+	Countries   []CountryListenerCount `json:"countries"`
+	GeneratedAt time.Time              `json:"generated_at"`
+}
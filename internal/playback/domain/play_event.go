@@ -0,0 +1,26 @@
+package model
+
+import (
+	"music-app-backend/pkg/model"
+	"time"
+)
+
+type PlayEventType string
+
+const (
+	PlayEventStart    PlayEventType = "start"
+	PlayEventProgress PlayEventType = "progress"
+	PlayEventEnd      PlayEventType = "end"
+	PlayEventSkip     PlayEventType = "skip"
+)
+
+// PlayEvent is the raw client event behind a SongPlay. Its unique ClientEventID makes
+// retries and re-sent offline batches safe.
+type PlayEvent struct {
+	model.BaseModel
+	ClientEventID   string        `json:"client_event_id" gorm:"unique;not null;size:100"`
+	SongPlayID      uint64        `json:"song_play_id" gorm:"not null;index"`
+	EventType       PlayEventType `json:"event_type" gorm:"size:20;not null"`
+	PositionSeconds int           `json:"position_seconds" gorm:"default:0"`
+	OccurredAt      time.Time     `json:"occurred_at" gorm:"not null"`
+}
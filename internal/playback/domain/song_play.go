@@ -2,6 +2,7 @@ package model
 
 import (
 	"music-app-backend/pkg/model"
+	"time"
 )
 
 type SkipReason string
@@ -13,17 +14,37 @@ const (
 	SkipReasonError         SkipReason = "error"
 )
 
+func (r SkipReason) IsValid() bool {
+	switch r {
+	case SkipReasonUserSkip, SkipReasonNextSong, SkipReasonEndOfPlaylist, SkipReasonError:
+		return true
+	}
+	return false
+}
+
+// CompletionThresholdPercent is the share of a song that must be played for the play to count as completed
+const CompletionThresholdPercent = 80
+
 type SongPlay struct {
 	model.BaseModel
 	SongID                uint64      `json:"song_id" gorm:"not null;index"`
 	UserID                *uint64     `json:"user_id" gorm:"index"`
 	SessionID             string      `json:"session_id" gorm:"size:100"`
-	IPAddress             string      `json:"ip_address"`
+	ClientPlayID          string      `json:"client_play_id" gorm:"unique;size:100"`
+	IPAddress             *string     `json:"ip_address" gorm:"type:inet"`
 	UserAgent             string      `json:"user_agent"`
 	CountryCode           string      `json:"country_code" gorm:"size:2"`
 	City                  string      `json:"city" gorm:"size:100"`
 	DurationPlayedSeconds int         `json:"duration_played_seconds" gorm:"default:0"`
 	Completed             bool        `json:"completed" gorm:"default:false"`
 	SkipReason            *SkipReason `json:"skip_reason" gorm:"size:50"`
-	PlayedAt              int64       `json:"played_at" gorm:"autoCreateTime;index"`
+	PlayedAt              time.Time   `json:"played_at" gorm:"index"`
+}
+
+// IsCompleted applies the >80% rule. Without a known duration only an explicit end counts.
+func IsCompleted(playedSeconds int, songDurationSeconds *int, ended bool) bool {
+	if songDurationSeconds == nil || *songDurationSeconds <= 0 {
+		return ended
+	}
+	return playedSeconds*100 > *songDurationSeconds*CompletionThresholdPercent
 }
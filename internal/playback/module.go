@@ -1,26 +1,95 @@
 package playback
 
 import (
+	"context"
+	analyticsModuleSvc "music-app-backend/internal/analytics/application"
+	musicModuleSvc "music-app-backend/internal/music/application"
+	"music-app-backend/internal/playback/adapters/http"
 	"music-app-backend/internal/playback/adapters/repository"
 	"music-app-backend/internal/playback/application"
+	realtimeModuleSvc "music-app-backend/internal/realtime/application"
+	ctx2 "music-app-backend/pkg/context"
+	"music-app-backend/pkg/middleware"
+	"music-app-backend/pkg/ratelimit"
+	"os"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
 type PlaybackModule struct {
 	Repository *repository.PlaybackRepository
 	Service    *application.PlaybackService
+	Handler    *http.PlaybackHandler
+
+	authMiddleware *middleware.AuthMiddleware
 }
 
-func NewPlaybackModule(db *gorm.DB) *PlaybackModule {
-	playbackRepo := repository.NewPlaybackRepository(db)
-	playbackService := application.NewPlaybackService(playbackRepo)
+func NewPlaybackModule(serviceContext *ctx2.ServiceContext, authMiddleware *middleware.AuthMiddleware, musicService musicModuleSvc.IMusicService, analyticsService analyticsModuleSvc.IAnalyticsService, realtimeService realtimeModuleSvc.IRealtimeService) *PlaybackModule {
+	playbackRepo := repository.NewPlaybackRepository(serviceContext.GetDB())
+	// A session stops counting as live once no heartbeat arrived for this long
+	sessionTimeout := durationFromEnv("LISTENING_SESSION_TIMEOUT", 90*time.Second)
+	presence := repository.NewPresenceStore(serviceContext.GetRedisClient(), sessionTimeout)
+	// Play events are accepted from anonymous clients, so they are limited per listener and
+	// per IP; the IP limit is higher because listeners can share an address
+	listenerLimiter := ratelimit.NewLimiter(serviceContext.GetRedisClient(), "play_events",
+		ratelimit.Limit{Max: intFromEnv("PLAY_EVENT_LIMIT_PER_MINUTE", 60), Window: time.Minute},
+	)
+	ipLimiter := ratelimit.NewLimiter(serviceContext.GetRedisClient(), "play_events_ip",
+		ratelimit.Limit{Max: intFromEnv("PLAY_EVENT_IP_LIMIT_PER_MINUTE", 300), Window: time.Minute},
+	)
+	playbackService := application.NewPlaybackService(playbackRepo, serviceContext.GetIDGenerator(), musicService, analyticsService, realtimeService, presence, sessionTimeout, listenerLimiter, ipLimiter)
+	playbackHandler := http.NewPlaybackHandler(playbackService)
 
 	return &PlaybackModule{
-		Repository: playbackRepo,
-		Service:    playbackService,
+		Repository:     playbackRepo,
+		Service:        playbackService,
+		Handler:        playbackHandler,
+		authMiddleware: authMiddleware,
+	}
+}
+
+func (s *PlaybackModule) RegisterRoutes(router *gin.RouterGroup) {
+	playback := router.Group("/playback")
+	// Anonymous listeners identify their plays with session_id
+	playback.Use(s.authMiddleware.OptionalAuth())
+	{
+		playback.POST("/events", s.Handler.RecordPlayEvent)
+		playback.POST("/events/batch", s.Handler.RecordPlayEventBatch)
+
+		playback.POST("/sessions", s.Handler.StartSession)
+		playback.POST("/sessions/:listening_session_id/heartbeat", s.Handler.HeartbeatSession)
+		playback.POST("/sessions/:listening_session_id/stop", s.Handler.StopSession)
+		playback.GET("/songs/:song_id/live", s.Handler.GetSongLiveListeners)
+	}
+
+	live := router.Group("/playback/live")
+	live.Use(s.authMiddleware.RequireAuth(), s.authMiddleware.RequireArtist())
+	{
+		live.GET("", s.Handler.GetLiveListeners)
 	}
 }
 
-func (s *PlaybackModule) RegisterRoutes(router *gin.RouterGroup) {}
+// StartWorkers launches the stale session sweeper (LISTENING_SESSION_SWEEP_INTERVAL, default 30s)
+func (s *PlaybackModule) StartWorkers(ctx context.Context) {
+	s.Service.StartSessionSweeper(ctx, durationFromEnv("LISTENING_SESSION_SWEEP_INTERVAL", 30*time.Second))
+}
+
+func durationFromEnv(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+func intFromEnv(key string, defaultValue int64) int64 {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseInt(value, 10, 64); err == nil && parsed > 0 {
+			return parsed
+		}
+	}
+	return defaultValue
+}
@@ -0,0 +1,221 @@
+package http
+
+import (
+	"log"
+	"music-app-backend/internal/realtime/application"
+	model "music-app-backend/internal/realtime/domain"
+	appError "music-app-backend/pkg/error"
+	jsonResponse "music-app-backend/pkg/json"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	writeWait      = 10 * time.Second
+	pongWait       = 60 * time.Second
+	pingPeriod     = pongWait * 9 / 10
+	maxMessageSize = 512 // Clients only send control frames
+)
+
+type RealtimeHandler struct {
+	realtimeService *application.RealtimeService
+	upgrader        websocket.Upgrader
+}
+
+func NewRealtimeHandler(realtimeService *application.RealtimeService) *RealtimeHandler {
+	allowedOrigins := map[string]bool{}
+	for _, origin := range strings.Split(os.Getenv("REALTIME_ALLOWED_ORIGINS"), ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			allowedOrigins[origin] = true
+		}
+	}
+
+	return &RealtimeHandler{
+		realtimeService: realtimeService,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 4096,
+			CheckOrigin: func(r *http.Request) bool {
+				origin := r.Header.Get("Origin")
+				// Non-browser clients send no Origin; browsers need their origin listed
+				return origin == "" || allowedOrigins[origin]
+			},
+		},
+	}
+}
+
+func (h *RealtimeHandler) HandleError(c *gin.Context, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if appErr, ok := appError.GetAppError(err); ok {
+		jsonResponse.ResponseJSON(c, appErr.StatusCode, appErr.Message, appErr.Data)
+		return true
+	}
+
+	jsonResponse.ResponseInternalError(c, err)
+	return true
+}
+
+// CreateTicket returns a single-use ticket for opening a WebSocket with ?ticket=, so the
+// access token never ends up in a URL
+func (h *RealtimeHandler) CreateTicket(c *gin.Context) {
+	accessToken := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+
+	ticket, err := h.realtimeService.IssueTicket(c.Request.Context(), accessToken)
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseCreated(c, ticket)
+}
+
+// RedeemTicket turns the ticket query parameter of a handshake into the Authorization
+// header RequireAuth checks. A request that already has the header is left alone.
+func (h *RealtimeHandler) RedeemTicket() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ticket := c.Query("ticket")
+		if c.GetHeader("Authorization") != "" || ticket == "" {
+			c.Next()
+			return
+		}
+
+		accessToken, err := h.realtimeService.RedeemTicket(c.Request.Context(), ticket)
+		if err != nil {
+			jsonResponse.ResponseInternalError(c, err)
+			c.Abort()
+			return
+		}
+		if accessToken != "" {
+			c.Request.Header.Set("Authorization", "Bearer "+accessToken)
+		}
+		c.Next()
+	}
+}
+
+// ArtistDashboard upgrades to a WebSocket and streams the current artist's live events.
+// Pass last_event_id (query) or Last-Event-ID (header) to resume after a disconnect.
+func (h *RealtimeHandler) ArtistDashboard(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	artistID, err := h.realtimeService.ResolveArtistID(c.Request.Context(), userID.(uint64))
+	if h.HandleError(c, err) {
+		return
+	}
+
+	h.serveTopic(c, model.ArtistTopic(artistID))
+}
+
+// UserFeed upgrades to a WebSocket and streams the events addressed to the current user,
+// such as artist messages. Resuming works the same way as on the artist dashboard.
+func (h *RealtimeHandler) UserFeed(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	h.serveTopic(c, model.UserTopic(userID.(uint64)))
+}
+
+func (h *RealtimeHandler) serveTopic(c *gin.Context, topic string) {
+	lastEventID := c.Query("last_event_id")
+	if lastEventID == "" {
+		lastEventID = c.GetHeader("Last-Event-ID")
+	}
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// The upgrader has already written the error response
+		return
+	}
+	defer conn.Close()
+
+	// Subscribe before replaying so nothing published in between is missed
+	subscription := h.realtimeService.Subscribe(topic)
+	defer h.realtimeService.Unsubscribe(subscription)
+
+	lastSent := lastEventID
+	if lastEventID != "" {
+		events, err := h.realtimeService.Replay(c.Request.Context(), topic, lastEventID)
+		if err != nil {
+			log.Printf("Failed to replay realtime events for %s: %v", topic, err)
+			closeWith(conn, websocket.CloseInternalServerErr, "replay failed")
+			return
+		}
+		for i := range events {
+			if err := writeEvent(conn, &events[i]); err != nil {
+				return
+			}
+			if events[i].ID != "" {
+				lastSent = events[i].ID
+			}
+		}
+	}
+
+	done := make(chan struct{})
+	go readPump(conn, done)
+
+	ticker := time.NewTicker(pingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-c.Request.Context().Done():
+			return
+		case <-subscription.Overflowed():
+			// The client fell too far behind; it reconnects with last_event_id and catches up from the stream
+			closeWith(conn, websocket.CloseTryAgainLater, "slow consumer, reconnect with last_event_id")
+			return
+		case event := <-subscription.Events:
+			if lastSent != "" && !model.IsAfter(event.ID, lastSent) {
+				continue // Already sent during replay
+			}
+			if err := writeEvent(conn, event); err != nil {
+				return
+			}
+			lastSent = event.ID
+		case <-ticker.C:
+			conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readPump handles pongs and close frames; client messages are ignored
+func readPump(conn *websocket.Conn, done chan struct{}) {
+	defer close(done)
+	conn.SetReadLimit(maxMessageSize)
+	conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+func writeEvent(conn *websocket.Conn, event *model.Event) error {
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	return conn.WriteJSON(event)
+}
+
+func closeWith(conn *websocket.Conn, code int, reason string) {
+	conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(writeWait))
+}
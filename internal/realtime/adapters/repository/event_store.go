@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	model "music-app-backend/internal/realtime/domain"
+	"music-app-backend/pkg/redis"
+	"time"
+)
+
+// Every topic (an artist dashboard or a single user) has a capped Redis stream for replay
+// and a pub/sub channel for live fan-out. Publishing writes the stream first so the entry
+// ID travels with the message.
+const topicChannelPattern = "realtime:*"
+
+// userStreamTTL lets the streams of users who stopped receiving events expire; they only
+// exist so a dropped socket can catch up
+const userStreamTTL = 24 * time.Hour
+
+func streamKey(topic string) string {
+	return "realtime:stream:" + topic
+}
+
+func topicChannel(topic string) string {
+	return "realtime:" + topic
+}
+
+type EventStore struct {
+	client       *redis.Client
+	streamMaxLen int64
+}
+
+func NewEventStore(client *redis.Client, streamMaxLen int64) *EventStore {
+	return &EventStore{
+		client:       client,
+		streamMaxLen: streamMaxLen,
+	}
+}
+
+// Append stores the event in its topic's stream, sets its ID and publishes it
+func (s *EventStore) Append(ctx context.Context, event *model.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	topic := event.Topic()
+	id, err := s.client.XAdd(ctx, streamKey(topic), s.streamMaxLen, map[string]interface{}{"event": payload})
+	if err != nil {
+		return err
+	}
+	event.ID = id
+
+	if event.UserID != 0 {
+		if err := s.client.Expire(ctx, streamKey(topic), userStreamTTL); err != nil {
+			return err
+		}
+	}
+
+	payload, err = json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return s.client.Publish(ctx, topicChannel(topic), payload)
+}
+
+// ReadAfter returns up to count events newer than lastEventID. The boolean is false
+// when lastEventID is older than the oldest retained entry, i.e. events were lost.
+func (s *EventStore) ReadAfter(ctx context.Context, topic string, lastEventID string, count int64) ([]model.Event, bool, error) {
+	stream := streamKey(topic)
+
+	oldest, err := s.client.XRange(ctx, stream, "-", "+", 1)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(oldest) == 0 {
+		return nil, true, nil
+	}
+	complete := oldest[0].ID == lastEventID || !model.IsAfter(oldest[0].ID, lastEventID)
+
+	messages, err := s.client.XRange(ctx, stream, "("+lastEventID, "+", count)
+	if err != nil {
+		return nil, false, err
+	}
+
+	events := make([]model.Event, 0, len(messages))
+	for _, message := range messages {
+		raw, ok := message.Values["event"].(string)
+		if !ok {
+			continue
+		}
+		var event model.Event
+		if err := json.Unmarshal([]byte(raw), &event); err != nil {
+			continue
+		}
+		event.ID = message.ID
+		events = append(events, event)
+	}
+	return events, complete, nil
+}
+
+// Subscribe listens to every topic channel; the hub routes messages by topic
+func (s *EventStore) Subscribe(ctx context.Context) (<-chan *model.Event, func() error) {
+	pubsub := s.client.PSubscribe(ctx, topicChannelPattern)
+	events := make(chan *model.Event, 1024)
+
+	go func() {
+		defer close(events)
+		for message := range pubsub.Channel() {
+			var event model.Event
+			if err := json.Unmarshal([]byte(message.Payload), &event); err != nil {
+				continue
+			}
+			select {
+			case events <- &event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, pubsub.Close
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"music-app-backend/pkg/redis"
+	"time"
+)
+
+func ticketKey(ticket string) string {
+	return "realtime:ticket:" + ticket
+}
+
+// TicketStore keeps the short-lived tickets that open a WebSocket in place of the access
+// token, which browsers could only pass in the URL
+type TicketStore struct {
+	client *redis.Client
+}
+
+func NewTicketStore(client *redis.Client) *TicketStore {
+	return &TicketStore{client: client}
+}
+
+// Save stores the access token under the ticket until ttl runs out
+func (s *TicketStore) Save(ctx context.Context, ticket, accessToken string, ttl time.Duration) error {
+	return s.client.Set(ctx, ticketKey(ticket), accessToken, ttl)
+}
+
+// Redeem returns the access token of the ticket and removes it, so a ticket opens one
+// socket at most; an unknown or expired ticket returns an empty token
+func (s *TicketStore) Redeem(ctx context.Context, ticket string) (string, error) {
+	return s.client.GetDel(ctx, ticketKey(ticket))
+}
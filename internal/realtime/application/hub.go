@@ -0,0 +1,104 @@
+package application
+
+import (
+	"context"
+	"log"
+	"music-app-backend/internal/realtime/adapters/repository"
+	model "music-app-backend/internal/realtime/domain"
+	"sync"
+	"time"
+)
+
+// subscriptionBuffer is how many events a slow connection may fall behind before it is dropped
+const subscriptionBuffer = 256
+
+// Subscription is one WebSocket connection's view of a topic's live events
+type Subscription struct {
+	Topic  string
+	Events chan *model.Event
+
+	overflowOnce sync.Once
+	overflowed   chan struct{}
+}
+
+// Overflowed is closed when the connection could not keep up and missed an event
+func (s *Subscription) Overflowed() <-chan struct{} {
+	return s.overflowed
+}
+
+func (s *Subscription) deliver(event *model.Event) {
+	select {
+	case s.Events <- event:
+	default:
+		s.overflowOnce.Do(func() { close(s.overflowed) })
+	}
+}
+
+// Hub holds the connections of this replica and feeds them from the Redis channel that
+// every replica publishes to
+type Hub struct {
+	store *repository.EventStore
+
+	mu            sync.RWMutex
+	subscriptions map[string]map[*Subscription]struct{}
+}
+
+func NewHub(store *repository.EventStore) *Hub {
+	return &Hub{
+		store:         store,
+		subscriptions: map[string]map[*Subscription]struct{}{},
+	}
+}
+
+func (h *Hub) Subscribe(topic string) *Subscription {
+	subscription := &Subscription{
+		Topic:      topic,
+		Events:     make(chan *model.Event, subscriptionBuffer),
+		overflowed: make(chan struct{}),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.subscriptions[topic] == nil {
+		h.subscriptions[topic] = map[*Subscription]struct{}{}
+	}
+	h.subscriptions[topic][subscription] = struct{}{}
+	return subscription
+}
+
+func (h *Hub) Unsubscribe(subscription *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.subscriptions[subscription.Topic], subscription)
+	if len(h.subscriptions[subscription.Topic]) == 0 {
+		delete(h.subscriptions, subscription.Topic)
+	}
+}
+
+func (h *Hub) dispatch(event *model.Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for subscription := range h.subscriptions[event.Topic()] {
+		subscription.deliver(event)
+	}
+}
+
+// Run consumes the Redis channel until ctx is cancelled, resubscribing if it drops
+func (h *Hub) Run(ctx context.Context) {
+	go func() {
+		for {
+			events, closeSubscription := h.store.Subscribe(ctx)
+			for event := range events {
+				h.dispatch(event)
+			}
+			closeSubscription()
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Second):
+				log.Println("Realtime subscription ended, resubscribing")
+			}
+		}
+	}()
+}
@@ -0,0 +1,129 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	musicModuleSvc "music-app-backend/internal/music/application"
+	"music-app-backend/internal/realtime/adapters/repository"
+	model "music-app-backend/internal/realtime/domain"
+	appError "music-app-backend/pkg/error"
+	"time"
+)
+
+const (
+	// maxReplayEvents bounds how much history a reconnecting client receives
+	maxReplayEvents = 1000
+	// ticketTTL leaves the client time to open the socket right after asking for a ticket
+	ticketTTL = 30 * time.Second
+)
+
+// IRealtimeService is used by other modules to push events to an artist's live dashboard
+// or to a signed-in user's own stream
+type IRealtimeService interface {
+	PublishArtistEvent(ctx context.Context, artistID uint64, eventType model.EventType, data interface{}) error
+	PublishUserEvent(ctx context.Context, userID uint64, eventType model.EventType, data interface{}) error
+}
+
+type RealtimeService struct {
+	store        *repository.EventStore
+	tickets      *repository.TicketStore
+	hub          *Hub
+	musicService musicModuleSvc.IMusicService
+}
+
+func NewRealtimeService(store *repository.EventStore, tickets *repository.TicketStore, hub *Hub, musicService musicModuleSvc.IMusicService) *RealtimeService {
+	return &RealtimeService{
+		store:        store,
+		tickets:      tickets,
+		hub:          hub,
+		musicService: musicService,
+	}
+}
+
+// PublishArtistEvent appends the event to the artist's stream and fans it out to every replica
+func (s *RealtimeService) PublishArtistEvent(ctx context.Context, artistID uint64, eventType model.EventType, data interface{}) error {
+	return s.publish(ctx, &model.Event{Type: eventType, ArtistID: artistID}, data)
+}
+
+// PublishUserEvent appends the event to the user's own stream and fans it out to every replica
+func (s *RealtimeService) PublishUserEvent(ctx context.Context, userID uint64, eventType model.EventType, data interface{}) error {
+	return s.publish(ctx, &model.Event{Type: eventType, UserID: userID}, data)
+}
+
+func (s *RealtimeService) publish(ctx context.Context, event *model.Event, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	event.OccurredAt = time.Now().UTC()
+	event.Data = payload
+	return s.store.Append(ctx, event)
+}
+
+// IssueTicket returns a single-use ticket that stands in for accessToken on the WebSocket
+// handshake. The token is checked again when the ticket is redeemed.
+func (s *RealtimeService) IssueTicket(ctx context.Context, accessToken string) (*model.Ticket, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, appError.NewInternalError(err, "failed to create ticket")
+	}
+	ticket := &model.Ticket{
+		Ticket:    base64.RawURLEncoding.EncodeToString(raw),
+		ExpiresAt: time.Now().UTC().Add(ticketTTL),
+	}
+	if err := s.tickets.Save(ctx, ticket.Ticket, accessToken, ticketTTL); err != nil {
+		return nil, appError.NewInternalError(err, "failed to create ticket")
+	}
+	return ticket, nil
+}
+
+// RedeemTicket returns the access token behind the ticket and invalidates it; an empty
+// token means the ticket is unknown, expired or already used
+func (s *RealtimeService) RedeemTicket(ctx context.Context, ticket string) (string, error) {
+	return s.tickets.Redeem(ctx, ticket)
+}
+
+// ResolveArtistID returns the artist profile owned by userID
+func (s *RealtimeService) ResolveArtistID(ctx context.Context, userID uint64) (uint64, error) {
+	artist, err := s.musicService.GetArtistByUserID(ctx, userID)
+	if err != nil {
+		return 0, appError.NewInternalError(err, "failed to load artist")
+	}
+	if artist == nil {
+		return 0, appError.NewNotFoundError(nil, "artist profile not found")
+	}
+	return artist.ID, nil
+}
+
+// Replay returns the events after lastEventID. When the history no longer reaches back
+// that far, a stream.resync event is prepended so the client reloads its state.
+func (s *RealtimeService) Replay(ctx context.Context, topic string, lastEventID string) ([]model.Event, error) {
+	events, complete, err := s.store.ReadAfter(ctx, topic, lastEventID, maxReplayEvents)
+	if err != nil {
+		return nil, err
+	}
+	if !complete {
+		resync := model.Event{
+			Type:       model.EventStreamResync,
+			OccurredAt: time.Now().UTC(),
+		}
+		events = append([]model.Event{resync}, events...)
+	}
+	return events, nil
+}
+
+func (s *RealtimeService) Subscribe(topic string) *Subscription {
+	return s.hub.Subscribe(topic)
+}
+
+func (s *RealtimeService) Unsubscribe(subscription *Subscription) {
+	s.hub.Unsubscribe(subscription)
+}
+
+// StartHub begins consuming the shared Redis channel
+func (s *RealtimeService) StartHub(ctx context.Context) {
+	s.hub.Run(ctx)
+}
@@ -0,0 +1,137 @@
+package model
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventListenersUpdated EventType = "listeners.updated"
+	EventListenerJoined   EventType = "listener.joined"
+	EventTipReceived      EventType = "tip.received"
+	EventFollowerNew      EventType = "follower.new"
+	EventSongMilestone    EventType = "song.milestone"
+	EventSongProcessed    EventType = "song.processed"
+	EventMessageSent      EventType = "message.sent"
+
+	// Listener events, delivered on the user's own stream
+	EventMessageReceived     EventType = "message.received"
+	EventNotificationCreated EventType = "notification.created"
+
+	// EventStreamResync is sent when the requested last_event_id is no longer retained;
+	// the client should reload the dashboard state over REST
+	EventStreamResync EventType = "stream.resync"
+)
+
+// Event is one message on an artist's dashboard stream or a user's own stream; exactly one
+// of ArtistID and UserID is set. ID is the Redis stream entry ID, which clients send back
+// as last_event_id to resume after a reconnect.
+type Event struct {
+	ID         string          `json:"id"`
+	Type       EventType       `json:"type"`
+	ArtistID   uint64          `json:"artist_id,omitempty"`
+	UserID     uint64          `json:"user_id,omitempty"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data,omitempty"`
+}
+
+// Topic names the stream the event belongs to
+func (e *Event) Topic() string {
+	if e.UserID != 0 {
+		return UserTopic(e.UserID)
+	}
+	return ArtistTopic(e.ArtistID)
+}
+
+func ArtistTopic(artistID uint64) string {
+	return "artist:" + strconv.FormatUint(artistID, 10)
+}
+
+func UserTopic(userID uint64) string {
+	return "user:" + strconv.FormatUint(userID, 10)
+}
+
+// IsAfter reports whether stream ID a sorts after b. IDs have the form <millis>-<seq>.
+func IsAfter(a, b string) bool {
+	aMillis, aSeq := splitStreamID(a)
+	bMillis, bSeq := splitStreamID(b)
+	if aMillis != bMillis {
+		return aMillis > bMillis
+	}
+	return aSeq > bSeq
+}
+
+func splitStreamID(id string) (uint64, uint64) {
+	millis, seq, _ := strings.Cut(id, "-")
+	m, _ := strconv.ParseUint(millis, 10, 64)
+	s, _ := strconv.ParseUint(seq, 10, 64)
+	return m, s
+}
+
+type ListenersUpdatedData struct {
+	Listeners     int64  `json:"listeners"`
+	SongID        uint64 `json:"song_id"`
+	SongListeners int64  `json:"song_listeners"`
+}
+
+type ListenerJoinedData struct {
+	SongID        uint64 `json:"song_id"`
+	CountryCode   string `json:"country_code"`
+	City          string `json:"city"`
+	Listeners     int64  `json:"listeners"`
+	SongListeners int64  `json:"song_listeners"`
+}
+
+type TipReceivedData struct {
+	TipID             uint64  `json:"tip_id"`
+	FromUserID        *uint64 `json:"from_user_id,omitempty"` // Omitted for anonymous tips
+	SongID            *uint64 `json:"song_id"`
+	AmountCents       int     `json:"amount_cents"`
+	Currency          string  `json:"currency"`
+	ArtistPayoutCents int     `json:"artist_payout_cents"`
+	Message           string  `json:"message"`
+}
+
+type FollowerNewData struct {
+	UserID        uint64 `json:"user_id"`
+	FollowerCount int    `json:"follower_count"`
+}
+
+type SongMilestoneData struct {
+	SongID    uint64 `json:"song_id"`
+	Title     string `json:"title"`
+	PlayCount int64  `json:"play_count"`
+}
+
+type SongProcessedData struct {
+	SongID  uint64 `json:"song_id"`
+	Title   string `json:"title"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+type MessageSentData struct {
+	MessageID   uint64 `json:"message_id"`
+	TargetType  string `json:"target_type"`
+	SentToCount int    `json:"sent_to_count"`
+}
+
+type MessageReceivedData struct {
+	MessageID    uint64    `json:"message_id"`
+	ArtistID     uint64    `json:"artist_id"`
+	ArtistName   string    `json:"artist_name"`
+	MessageText  string    `json:"message_text"`
+	TargetType   string    `json:"target_type"`
+	TargetSongID *uint64   `json:"target_song_id,omitempty"`
+	DeliveredAt  time.Time `json:"delivered_at"`
+}
+
+// Ticket opens one WebSocket within its lifetime, see POST /realtime/tickets
+type Ticket struct {
+	Ticket    string    `json:"ticket"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,64 @@
+package realtime
+
+import (
+	"context"
+	musicModuleSvc "music-app-backend/internal/music/application"
+	"music-app-backend/internal/realtime/adapters/http"
+	"music-app-backend/internal/realtime/adapters/repository"
+	"music-app-backend/internal/realtime/application"
+	ctx2 "music-app-backend/pkg/context"
+	"music-app-backend/pkg/middleware"
+	"os"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RealtimeModule struct {
+	Store   *repository.EventStore
+	Service *application.RealtimeService
+	Handler *http.RealtimeHandler
+
+	authMiddleware *middleware.AuthMiddleware
+}
+
+func NewRealtimeModule(serviceContext *ctx2.ServiceContext, authMiddleware *middleware.AuthMiddleware, musicService musicModuleSvc.IMusicService) *RealtimeModule {
+	// Number of events kept per artist for resuming (REALTIME_STREAM_MAXLEN, default 1000)
+	streamMaxLen := int64(1000)
+	if value := os.Getenv("REALTIME_STREAM_MAXLEN"); value != "" {
+		if parsed, err := strconv.ParseInt(value, 10, 64); err == nil && parsed > 0 {
+			streamMaxLen = parsed
+		}
+	}
+
+	eventStore := repository.NewEventStore(serviceContext.GetRedisClient(), streamMaxLen)
+	ticketStore := repository.NewTicketStore(serviceContext.GetRedisClient())
+	realtimeService := application.NewRealtimeService(eventStore, ticketStore, application.NewHub(eventStore), musicService)
+	realtimeHandler := http.NewRealtimeHandler(realtimeService)
+
+	return &RealtimeModule{
+		Store:          eventStore,
+		Service:        realtimeService,
+		Handler:        realtimeHandler,
+		authMiddleware: authMiddleware,
+	}
+}
+
+func (r *RealtimeModule) RegisterRoutes(router *gin.RouterGroup) {
+	realtime := router.Group("/realtime")
+	realtime.POST("/tickets", r.authMiddleware.RequireAuth(), r.Handler.CreateTicket)
+
+	// Browsers cannot set headers on a WebSocket handshake, so they pass a single-use
+	// ticket in the query instead of the access token
+	sockets := realtime.Group("")
+	sockets.Use(r.Handler.RedeemTicket(), r.authMiddleware.RequireAuth())
+	{
+		sockets.GET("/artist", r.authMiddleware.RequireArtist(), r.Handler.ArtistDashboard)
+		sockets.GET("/me", r.Handler.UserFeed)
+	}
+}
+
+// StartWorkers subscribes this replica to the shared event channel
+func (r *RealtimeModule) StartWorkers(ctx context.Context) {
+	r.Service.StartHub(ctx)
+}
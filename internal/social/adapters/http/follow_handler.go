@@ -0,0 +1,151 @@
+package http
+
+import (
+	model "music-app-backend/internal/social/domain"
+	jsonResponse "music-app-backend/pkg/json"
+	"music-app-backend/pkg/pagination"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FollowArtistRequest struct {
+	NotificationEnabled *bool `json:"notification_enabled"`
+}
+
+type FollowNotificationsRequest struct {
+	NotificationEnabled *bool `json:"notification_enabled" binding:"required"`
+}
+
+// FollowArtist follows an artist; repeating the call is harmless
+func (h *SocialHandler) FollowArtist(c *gin.Context) {
+	artistID, err := strconv.ParseUint(c.Param("artist_id"), 10, 64)
+	if err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid artist ID")
+		return
+	}
+
+	// The body is optional
+	request := &FollowArtistRequest{}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(request); err != nil {
+			jsonResponse.ResponseBadRequest(c, "Invalid request: "+err.Error())
+			return
+		}
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	result, err := h.socialService.FollowArtist(c.Request.Context(), &model.FollowArtistDTO{
+		UserID:              userID.(uint64),
+		ArtistID:            artistID,
+		NotificationEnabled: request.NotificationEnabled,
+	})
+	if h.HandleError(c, err) {
+		return
+	}
+
+	if result.Created {
+		jsonResponse.ResponseCreated(c, result)
+		return
+	}
+	jsonResponse.ResponseOK(c, result)
+}
+
+// UnfollowArtist stops following an artist; unfollowing twice is harmless
+func (h *SocialHandler) UnfollowArtist(c *gin.Context) {
+	artistID, err := strconv.ParseUint(c.Param("artist_id"), 10, 64)
+	if err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid artist ID")
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	err = h.socialService.UnfollowArtist(c.Request.Context(), userID.(uint64), artistID)
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseOK(c, nil)
+}
+
+// SetFollowNotifications toggles the artist's broadcasts for the current follower
+func (h *SocialHandler) SetFollowNotifications(c *gin.Context) {
+	artistID, err := strconv.ParseUint(c.Param("artist_id"), 10, 64)
+	if err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid artist ID")
+		return
+	}
+
+	request := &FollowNotificationsRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	follow, err := h.socialService.SetFollowNotifications(c.Request.Context(), userID.(uint64), artistID, *request.NotificationEnabled)
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseOK(c, follow)
+}
+
+// ListFollowedArtists returns the artists the current user follows
+func (h *SocialHandler) ListFollowedArtists(c *gin.Context) {
+	page, err := pagination.CursorFromQuery(c)
+	if err != nil {
+		jsonResponse.ResponseBadRequest(c, err.Error())
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	result, err := h.socialService.ListFollowedArtists(c.Request.Context(), userID.(uint64), page)
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseOK(c, result)
+}
+
+// ListFollowers returns the current artist's followers
+func (h *SocialHandler) ListFollowers(c *gin.Context) {
+	page, err := pagination.CursorFromQuery(c)
+	if err != nil {
+		jsonResponse.ResponseBadRequest(c, err.Error())
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	result, err := h.socialService.ListFollowers(c.Request.Context(), userID.(uint64), page)
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseOK(c, result)
+}
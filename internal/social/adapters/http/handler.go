@@ -0,0 +1,33 @@
+package http
+
+import (
+	"music-app-backend/internal/social/application"
+	appError "music-app-backend/pkg/error"
+	jsonResponse "music-app-backend/pkg/json"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SocialHandler struct {
+	socialService *application.SocialService
+}
+
+func NewSocialHandler(socialService *application.SocialService) *SocialHandler {
+	return &SocialHandler{
+		socialService: socialService,
+	}
+}
+
+func (h *SocialHandler) HandleError(c *gin.Context, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if appErr, ok := appError.GetAppError(err); ok {
+		jsonResponse.ResponseJSON(c, appErr.StatusCode, appErr.Message, appErr.Data)
+		return true
+	}
+
+	jsonResponse.ResponseInternalError(c, err)
+	return true
+}
@@ -0,0 +1,97 @@
+package http
+
+import (
+	model "music-app-backend/internal/social/domain"
+	jsonResponse "music-app-backend/pkg/json"
+	"music-app-backend/pkg/pagination"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SendArtistMessageRequest struct {
+	MessageText  string           `json:"message_text" binding:"required"`
+	TargetType   model.TargetType `json:"target_type" binding:"required"`
+	TargetSongID *uint64          `json:"target_song_id"`
+}
+
+// SendArtistMessage queues a broadcast from the current artist to their listeners or followers
+func (h *SocialHandler) SendArtistMessage(c *gin.Context) {
+	request := &SendArtistMessageRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	message, err := h.socialService.SendArtistMessage(c.Request.Context(), &model.SendArtistMessageDTO{
+		ArtistUserID: userID.(uint64),
+		MessageText:  request.MessageText,
+		TargetType:   request.TargetType,
+		TargetSongID: request.TargetSongID,
+	})
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseCreated(c, message)
+}
+
+// ListSentMessages returns the current artist's broadcasts with delivery and read counts
+func (h *SocialHandler) ListSentMessages(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	result, err := h.socialService.ListSentMessages(c.Request.Context(), userID.(uint64), pagination.FromQuery(c))
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseOK(c, result)
+}
+
+// ListInbox returns the artist messages delivered to the current user
+func (h *SocialHandler) ListInbox(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	result, err := h.socialService.ListInbox(c.Request.Context(), userID.(uint64), pagination.FromQuery(c))
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseOK(c, result)
+}
+
+// MarkMessageRead records a read receipt for one delivered message
+func (h *SocialHandler) MarkMessageRead(c *gin.Context) {
+	messageID, err := strconv.ParseUint(c.Param("message_id"), 10, 64)
+	if err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid message ID")
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	delivery, err := h.socialService.MarkMessageRead(c.Request.Context(), userID.(uint64), messageID)
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseOK(c, delivery)
+}
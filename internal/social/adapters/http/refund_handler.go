@@ -0,0 +1,102 @@
+package http
+
+import (
+	"encoding/json"
+	model "music-app-backend/internal/social/domain"
+	jsonResponse "music-app-backend/pkg/json"
+	"music-app-backend/pkg/payment"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RefundTipRequest struct {
+	AmountCents int                 `json:"amount_cents" binding:"omitempty,gt=0"` // Omit to refund the remaining amount
+	Kind        model.TipRefundKind `json:"kind" binding:"omitempty,oneof=refund chargeback"`
+	Reason      string              `json:"reason" binding:"required"`
+}
+
+// RefundTip refunds a tip on behalf of an admin
+func (h *SocialHandler) RefundTip(c *gin.Context) {
+	tipID, err := strconv.ParseUint(c.Param("tip_id"), 10, 64)
+	if err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid tip ID")
+		return
+	}
+
+	request := &RefundTipRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+	actorUserID := userID.(uint64)
+
+	kind := request.Kind
+	if kind == "" {
+		kind = model.TipRefundKindRefund
+	}
+
+	result, err := h.socialService.RefundTip(c.Request.Context(), &model.RefundTipDTO{
+		TipID:       tipID,
+		AmountCents: request.AmountCents,
+		Kind:        kind,
+		Source:      model.TipRefundSourceAdmin,
+		Reason:      request.Reason,
+		ActorUserID: &actorUserID,
+	})
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseCreated(c, result)
+}
+
+// ListTipRefunds returns the refund history of a tip
+func (h *SocialHandler) ListTipRefunds(c *gin.Context) {
+	tipID, err := strconv.ParseUint(c.Param("tip_id"), 10, 64)
+	if err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid tip ID")
+		return
+	}
+
+	refunds, err := h.socialService.ListTipRefunds(c.Request.Context(), tipID)
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseOK(c, refunds)
+}
+
+// PaymentWebhook receives payment, refund and chargeback events signed with PAYMENT_WEBHOOK_SECRET
+func (h *SocialHandler) PaymentWebhook(webhookSecret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		body, err := c.GetRawData()
+		if err != nil {
+			jsonResponse.ResponseBadRequest(c, "Unable to read request body")
+			return
+		}
+
+		if !payment.VerifyWebhookSignature(webhookSecret, body, c.GetHeader(payment.WebhookSignatureHeader)) {
+			jsonResponse.ResponseUnauthorized(c)
+			return
+		}
+
+		event := &payment.WebhookEvent{}
+		if err := json.Unmarshal(body, event); err != nil {
+			jsonResponse.ResponseBadRequest(c, "Invalid event payload")
+			return
+		}
+
+		if h.HandleError(c, h.socialService.HandlePaymentWebhook(c.Request.Context(), event)) {
+			return
+		}
+
+		jsonResponse.ResponseOK(c, gin.H{"received": true})
+	}
+}
@@ -0,0 +1,102 @@
+package http
+
+import (
+	model "music-app-backend/internal/social/domain"
+	jsonResponse "music-app-backend/pkg/json"
+	"music-app-backend/pkg/pagination"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SendTipRequest struct {
+	ArtistID    uint64  `json:"artist_id" binding:"required"`
+	SongID      *uint64 `json:"song_id"`
+	AmountCents int     `json:"amount_cents" binding:"required,gt=0"`
+	Currency    string  `json:"currency" binding:"omitempty,len=3"`
+	Message     string  `json:"message"`
+	IsAnonymous bool    `json:"is_anonymous"`
+}
+
+// SendTip charges the current user and sends the tip to an artist
+func (h *SocialHandler) SendTip(c *gin.Context) {
+	request := &SendTipRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		jsonResponse.ResponseBadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	currency := request.Currency
+	if currency == "" {
+		currency = "USD"
+	}
+
+	tip, err := h.socialService.SendTip(c.Request.Context(), &model.SendTipDTO{
+		FromUserID:  userID.(uint64),
+		ToArtistID:  request.ArtistID,
+		SongID:      request.SongID,
+		AmountCents: request.AmountCents,
+		Currency:    currency,
+		Message:     request.Message,
+		IsAnonymous: request.IsAnonymous,
+	})
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseCreated(c, tip)
+}
+
+// QuoteTip previews the fee split for an amount without charging anything
+func (h *SocialHandler) QuoteTip(c *gin.Context) {
+	amountCents, err := strconv.Atoi(c.Query("amount_cents"))
+	if err != nil || amountCents <= 0 {
+		jsonResponse.ResponseBadRequest(c, "amount_cents must be a positive integer")
+		return
+	}
+
+	quote, err := h.socialService.QuoteTip(amountCents, c.DefaultQuery("currency", "USD"))
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseOK(c, quote)
+}
+
+// ListSentTips returns the tips the current user has sent
+func (h *SocialHandler) ListSentTips(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	result, err := h.socialService.ListSentTips(c.Request.Context(), userID.(uint64), pagination.FromQuery(c))
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseOK(c, result)
+}
+
+// ListReceivedTips returns the tips received by the current artist
+func (h *SocialHandler) ListReceivedTips(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		jsonResponse.ResponseUnauthorized(c)
+		return
+	}
+
+	result, err := h.socialService.ListReceivedTips(c.Request.Context(), userID.(uint64), pagination.FromQuery(c))
+	if h.HandleError(c, err) {
+		return
+	}
+
+	jsonResponse.ResponseOK(c, result)
+}
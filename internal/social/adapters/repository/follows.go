@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	model "music-app-backend/internal/social/domain"
+	"music-app-backend/pkg/pagination"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CreateFollow inserts the follow unless it already exists and reports whether it was new.
+// The update_follower_count trigger keeps artists.follower_count in step.
+func (r *SocialRepository) CreateFollow(ctx context.Context, follow *model.ArtistFollower) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "artist_id"}, {Name: "follower_user_id"}}, DoNothing: true}).
+		Create(follow)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// RecordFirstFollow remembers the follow and reports whether the user never followed the
+// artist before
+func (r *SocialRepository) RecordFirstFollow(ctx context.Context, follow *model.ArtistFollower) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&model.ArtistFirstFollow{
+			ArtistID:        follow.ArtistID,
+			FollowerUserID:  follow.FollowerUserID,
+			FirstFollowedAt: follow.FollowedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *SocialRepository) GetFollow(ctx context.Context, artistID, userID uint64) (*model.ArtistFollower, error) {
+	var follow model.ArtistFollower
+	err := r.db.WithContext(ctx).Where("artist_id = ? AND follower_user_id = ?", artistID, userID).First(&follow).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &follow, nil
+}
+
+func (r *SocialRepository) UpdateFollowNotifications(ctx context.Context, followID uint64, enabled bool) error {
+	return r.db.WithContext(ctx).Model(&model.ArtistFollower{}).
+		Where("id = ?", followID).
+		Update("notification_enabled", enabled).Error
+}
+
+// DeleteFollow removes the follow and reports whether there was one
+func (r *SocialRepository) DeleteFollow(ctx context.Context, artistID, userID uint64) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Where("artist_id = ? AND follower_user_id = ?", artistID, userID).
+		Delete(&model.ArtistFollower{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ListNotifiedFollowerIDs pages through the followers who left notifications on for the
+// artist, ordered by user ID; explicitAllowedOnly keeps those who allow explicit content
+func (r *SocialRepository) ListNotifiedFollowerIDs(ctx context.Context, artistID, excludeUserID, afterUserID uint64, limit int, explicitAllowedOnly bool) ([]uint64, error) {
+	var userIDs []uint64
+	query := r.db.WithContext(ctx).Model(&model.ArtistFollower{}).
+		Where("artist_id = ? AND notification_enabled = true", artistID).
+		Where("follower_user_id > ? AND follower_user_id <> ?", afterUserID, excludeUserID)
+	if explicitAllowedOnly {
+		// Without a preferences row explicit content keeps its default, which is off
+		query = query.Where(`EXISTS (SELECT 1 FROM user_preferences AS p
+			WHERE p.user_id = artist_followers.follower_user_id AND p.explicit_content_allowed = true)`)
+	}
+	err := query.
+		Order("follower_user_id").
+		Limit(limit).
+		Pluck("follower_user_id", &userIDs).Error
+	return userIDs, err
+}
+
+// ListFollowedArtists returns up to page.Limit+1 rows so the caller can tell whether more follow
+func (r *SocialRepository) ListFollowedArtists(ctx context.Context, userID uint64, page pagination.CursorParams) ([]model.FollowedArtist, error) {
+	query := r.db.WithContext(ctx).Table("artist_followers AS f").
+		Select(`f.id AS follow_id, f.artist_id, a.artist_name, a.profile_image_url, a.is_verified,
+			a.follower_count, f.notification_enabled, f.followed_at`).
+		Joins("JOIN artists AS a ON a.id = f.artist_id").
+		Where("f.follower_user_id = ?", userID)
+	if page.After != nil {
+		query = query.Where("(f.followed_at, f.id) < (?, ?)", page.After.Time, page.After.ID)
+	}
+
+	var artists []model.FollowedArtist
+	err := query.Order("f.followed_at DESC, f.id DESC").Limit(page.Limit + 1).Scan(&artists).Error
+	return artists, err
+}
+
+// ListFollowers returns up to page.Limit+1 rows so the caller can tell whether more follow
+func (r *SocialRepository) ListFollowers(ctx context.Context, artistID uint64, page pagination.CursorParams) ([]model.Follower, error) {
+	query := r.db.WithContext(ctx).Table("artist_followers AS f").
+		Select(`f.id AS follow_id, f.follower_user_id AS user_id, COALESCE(u.display_name, '') AS display_name,
+			COALESCE(u.avatar_url, '') AS avatar_url, f.followed_at`).
+		Joins("JOIN users AS u ON u.id = f.follower_user_id").
+		Where("f.artist_id = ?", artistID)
+	if page.After != nil {
+		query = query.Where("(f.followed_at, f.id) < (?, ?)", page.After.Time, page.After.ID)
+	}
+
+	var followers []model.Follower
+	err := query.Order("f.followed_at DESC, f.id DESC").Limit(page.Limit + 1).Scan(&followers).Error
+	return followers, err
+}
@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	model "music-app-backend/internal/social/domain"
+	"music-app-backend/pkg/pagination"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func (r *SocialRepository) CreateArtistMessage(ctx context.Context, message *model.ArtistMessage) error {
+	return r.db.WithContext(ctx).Create(message).Error
+}
+
+func (r *SocialRepository) GetArtistMessage(ctx context.Context, messageID uint64) (*model.ArtistMessage, error) {
+	var message model.ArtistMessage
+	err := r.db.WithContext(ctx).Where("id = ?", messageID).First(&message).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &message, nil
+}
+
+// ClaimArtistMessage moves a queued message to sending. A message already sending is only
+// taken over when its worker stopped making progress before staleBefore.
+func (r *SocialRepository) ClaimArtistMessage(ctx context.Context, messageID uint64, staleBefore time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&model.ArtistMessage{}).
+		Where("id = ?", messageID).
+		Where("status = ? OR (status = ? AND updated_at < ?)", model.MessageStatusQueued, model.MessageStatusSending, staleBefore).
+		Updates(map[string]interface{}{
+			"status":     model.MessageStatusSending,
+			"updated_at": time.Now().UTC(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ListStalledArtistMessageIDs returns unfinished messages with no progress since staleBefore
+func (r *SocialRepository) ListStalledArtistMessageIDs(ctx context.Context, staleBefore time.Time, limit int) ([]uint64, error) {
+	var ids []uint64
+	err := r.db.WithContext(ctx).Model(&model.ArtistMessage{}).
+		Where("status IN ?", []model.MessageStatus{model.MessageStatusQueued, model.MessageStatusSending}).
+		Where("updated_at < ?", staleBefore).
+		Order("updated_at").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// ListMessageAudience returns the next page of recipient user IDs after afterUserID.
+// Anonymous listeners and the artist's own account are never part of the audience.
+func (r *SocialRepository) ListMessageAudience(ctx context.Context, message *model.ArtistMessage, excludeUserID uint64, afterUserID uint64, limit int) ([]uint64, error) {
+	if message.TargetType == model.TargetTypeFollowers {
+		return r.ListNotifiedFollowerIDs(ctx, message.ArtistID, excludeUserID, afterUserID, limit, false)
+	}
+
+	query := r.db.WithContext(ctx).Table("listening_sessions").
+		Distinct("user_id").
+		Where("artist_id = ? AND is_active = true AND user_id IS NOT NULL", message.ArtistID).
+		Where("user_id > ? AND user_id <> ?", afterUserID, excludeUserID)
+	if message.TargetType == model.TargetTypeSpecificSongListeners && message.TargetSongID != nil {
+		query = query.Where("song_id = ?", *message.TargetSongID)
+	}
+
+	var userIDs []uint64
+	err := query.Order("user_id").Limit(limit).Pluck("user_id", &userIDs).Error
+	return userIDs, err
+}
+
+// CreateMessageDeliveries inserts the batch and returns the users that did not already
+// have the message, so a retried fan-out neither double-counts nor double-notifies
+func (r *SocialRepository) CreateMessageDeliveries(ctx context.Context, deliveries []model.MessageDelivery) ([]uint64, error) {
+	if len(deliveries) == 0 {
+		return nil, nil
+	}
+
+	placeholders := make([]string, 0, len(deliveries))
+	args := make([]interface{}, 0, len(deliveries)*6)
+	for _, delivery := range deliveries {
+		placeholders = append(placeholders, "(?, ?, ?, ?, ?, ?)")
+		args = append(args, delivery.ID, delivery.MessageID, delivery.UserID, delivery.DeliveredAt, delivery.CreatedAt, delivery.UpdatedAt)
+	}
+
+	var inserted []uint64
+	err := r.db.WithContext(ctx).Raw(`
+		INSERT INTO message_deliveries (id, message_id, user_id, delivered_at, created_at, updated_at)
+		VALUES `+strings.Join(placeholders, ", ")+`
+		ON CONFLICT (message_id, user_id) DO NOTHING
+		RETURNING user_id`, args...).Scan(&inserted).Error
+	return inserted, err
+}
+
+// AddMessageRecipients bumps sent_to_count; it also marks the fan-out as still alive
+func (r *SocialRepository) AddMessageRecipients(ctx context.Context, messageID uint64, count int) error {
+	return r.db.WithContext(ctx).Model(&model.ArtistMessage{}).
+		Where("id = ?", messageID).
+		Updates(map[string]interface{}{
+			"sent_to_count": gorm.Expr("sent_to_count + ?", count),
+			"updated_at":    time.Now().UTC(),
+		}).Error
+}
+
+func (r *SocialRepository) FinishArtistMessage(ctx context.Context, messageID uint64, status model.MessageStatus, sentAt *time.Time) error {
+	return r.db.WithContext(ctx).Model(&model.ArtistMessage{}).
+		Where("id = ?", messageID).
+		Updates(map[string]interface{}{
+			"status":     status,
+			"sent_at":    sentAt,
+			"updated_at": time.Now().UTC(),
+		}).Error
+}
+
+func (r *SocialRepository) ListArtistMessages(ctx context.Context, artistID uint64, page pagination.Params) ([]model.ArtistMessage, int64, error) {
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&model.ArtistMessage{}).Where("artist_id = ?", artistID).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var messages []model.ArtistMessage
+	err := r.db.WithContext(ctx).
+		Where("artist_id = ?", artistID).
+		Order("created_at DESC, id DESC").
+		Offset(page.Offset()).
+		Limit(page.Limit).
+		Find(&messages).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return messages, total, nil
+}
+
+func (r *SocialRepository) ListInboxMessages(ctx context.Context, userID uint64, page pagination.Params) ([]model.InboxMessage, int64, error) {
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&model.MessageDelivery{}).Where("user_id = ?", userID).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var messages []model.InboxMessage
+	err := r.db.WithContext(ctx).Table("message_deliveries AS d").
+		Select(`d.message_id, m.artist_id, m.message_text, m.target_type, m.target_song_id, d.delivered_at, d.read_at`).
+		Joins("JOIN artist_messages AS m ON m.id = d.message_id").
+		Where("d.user_id = ?", userID).
+		Order("d.delivered_at DESC, d.id DESC").
+		Offset(page.Offset()).
+		Limit(page.Limit).
+		Scan(&messages).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return messages, total, nil
+}
+
+func (r *SocialRepository) CountUnreadMessages(ctx context.Context, userID uint64) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&model.MessageDelivery{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *SocialRepository) GetMessageDelivery(ctx context.Context, messageID, userID uint64) (*model.MessageDelivery, error) {
+	var delivery model.MessageDelivery
+	err := r.db.WithContext(ctx).Where("message_id = ? AND user_id = ?", messageID, userID).First(&delivery).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// MarkMessageDeliveryRead sets read_at once; it reports false when the delivery was already read
+func (r *SocialRepository) MarkMessageDeliveryRead(ctx context.Context, deliveryID uint64, readAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&model.MessageDelivery{}).
+		Where("id = ? AND read_at IS NULL", deliveryID).
+		Updates(map[string]interface{}{
+			"read_at":    readAt,
+			"updated_at": readAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *SocialRepository) IncrementMessageReadCount(ctx context.Context, messageID uint64) error {
+	return r.db.WithContext(ctx).Model(&model.ArtistMessage{}).
+		Where("id = ?", messageID).
+		UpdateColumn("read_count", gorm.Expr("read_count + 1")).Error
+}
@@ -1,7 +1,13 @@
 package repository
 
 import (
+	"context"
+	model "music-app-backend/internal/social/domain"
+	"music-app-backend/pkg/pagination"
+	"time"
+
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type SocialRepository struct {
@@ -15,4 +21,233 @@ func NewSocialRepository(db *gorm.DB) *SocialRepository {
 	}
 }
 
-func (r *SocialRepository) IMockRepository() {}
+// Transaction runs fn against a repository bound to a single database transaction
+func (r *SocialRepository) Transaction(ctx context.Context, fn func(txRepo *SocialRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&SocialRepository{db: tx})
+	})
+}
+
+func (r *SocialRepository) CreateTip(ctx context.Context, tip *model.Tip) error {
+	return r.db.WithContext(ctx).Create(tip).Error
+}
+
+// SetTipPayment records the provider's payment on a pending tip
+func (r *SocialRepository) SetTipPayment(ctx context.Context, tipID uint64, paymentIntentID, chargeID string) error {
+	return r.db.WithContext(ctx).Model(&model.Tip{}).
+		Where("id = ? AND status = ?", tipID, model.TipStatusPending).
+		Updates(map[string]interface{}{
+			"stripe_payment_intent_id": paymentIntentID,
+			"stripe_charge_id":         chargeID,
+		}).Error
+}
+
+// MarkTipCompleted flips a pending tip to completed and reports whether it did. The
+// update_tip_totals trigger credits the artist and song totals on this transition.
+func (r *SocialRepository) MarkTipCompleted(ctx context.Context, tipID uint64, chargeID string, processedAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&model.Tip{}).
+		Where("id = ? AND status = ?", tipID, model.TipStatusPending).
+		Updates(map[string]interface{}{
+			"status":           model.TipStatusCompleted,
+			"stripe_charge_id": chargeID,
+			"processed_at":     processedAt,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// MarkTipFailed flips a pending tip to failed and reports whether it did
+func (r *SocialRepository) MarkTipFailed(ctx context.Context, tipID uint64) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&model.Tip{}).
+		Where("id = ? AND status = ?", tipID, model.TipStatusPending).
+		Update("status", model.TipStatusFailed)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *SocialRepository) GetTipByID(ctx context.Context, tipID uint64) (*model.Tip, error) {
+	var tip model.Tip
+	err := r.db.WithContext(ctx).Where("id = ?", tipID).First(&tip).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &tip, nil
+}
+
+func (r *SocialRepository) GetTipByPaymentIntentID(ctx context.Context, paymentIntentID string) (*model.Tip, error) {
+	var tip model.Tip
+	err := r.db.WithContext(ctx).Where("stripe_payment_intent_id = ?", paymentIntentID).First(&tip).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &tip, nil
+}
+
+// GetTipForUpdate loads a tip and locks the row for the rest of the transaction
+func (r *SocialRepository) GetTipForUpdate(ctx context.Context, tipID uint64) (*model.Tip, error) {
+	var tip model.Tip
+	err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", tipID).
+		First(&tip).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &tip, nil
+}
+
+func (r *SocialRepository) UpdateTipRefundState(ctx context.Context, tipID uint64, refundedCents int, status model.TipStatus) error {
+	return r.db.WithContext(ctx).Model(&model.Tip{}).
+		Where("id = ?", tipID).
+		Updates(map[string]interface{}{
+			"refunded_cents": refundedCents,
+			"status":         status,
+		}).Error
+}
+
+// CreateTipRefund inserts the refund. Once it has succeeded, the reverse_tip_totals
+// trigger takes the artist payout share back out of the artist and song totals.
+func (r *SocialRepository) CreateTipRefund(ctx context.Context, refund *model.TipRefund) error {
+	return r.db.WithContext(ctx).Create(refund).Error
+}
+
+// CompleteTipRefund records the outcome of a pending refund; it reports false when the
+// refund is no longer pending
+func (r *SocialRepository) CompleteTipRefund(ctx context.Context, refund *model.TipRefund) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&model.TipRefund{}).
+		Where("id = ? AND status = ?", refund.ID, model.TipRefundStatusPending).
+		Updates(map[string]interface{}{
+			"status":              model.TipRefundStatusSucceeded,
+			"external_refund_id":  refund.ExternalRefundID,
+			"platform_fee_cents":  refund.PlatformFeeCents,
+			"artist_payout_cents": refund.ArtistPayoutCents,
+			"fully_refunded":      refund.FullyRefunded,
+			"last_error":          nil,
+			"updated_at":          time.Now().UTC(),
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// SetTipRefundExternalID stores the provider's refund ID on a pending refund, so the
+// provider's webhook finds it if finalizing fails
+func (r *SocialRepository) SetTipRefundExternalID(ctx context.Context, refundID uint64, externalRefundID string) error {
+	return r.db.WithContext(ctx).Model(&model.TipRefund{}).
+		Where("id = ? AND status = ?", refundID, model.TipRefundStatusPending).
+		Updates(map[string]interface{}{
+			"external_refund_id": externalRefundID,
+			"updated_at":         time.Now().UTC(),
+		}).Error
+}
+
+// FailTipRefund releases a pending refund the provider turned down
+func (r *SocialRepository) FailTipRefund(ctx context.Context, refundID uint64, cause error) error {
+	return r.db.WithContext(ctx).Model(&model.TipRefund{}).
+		Where("id = ? AND status = ?", refundID, model.TipRefundStatusPending).
+		Updates(map[string]interface{}{
+			"status":     model.TipRefundStatusFailed,
+			"last_error": cause.Error(),
+			"updated_at": time.Now().UTC(),
+		}).Error
+}
+
+// SumPendingRefundCents returns the amount reserved by the tip's pending refunds other
+// than exceptID
+func (r *SocialRepository) SumPendingRefundCents(ctx context.Context, tipID, exceptID uint64) (int, error) {
+	var total int
+	err := r.db.WithContext(ctx).Model(&model.TipRefund{}).
+		Select("COALESCE(SUM(amount_cents), 0)").
+		Where("tip_id = ? AND status = ? AND id <> ?", tipID, model.TipRefundStatusPending, exceptID).
+		Scan(&total).Error
+	return total, err
+}
+
+func (r *SocialRepository) GetTipRefundByExternalID(ctx context.Context, externalRefundID string) (*model.TipRefund, error) {
+	var refund model.TipRefund
+	err := r.db.WithContext(ctx).Where("external_refund_id = ?", externalRefundID).First(&refund).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &refund, nil
+}
+
+func (r *SocialRepository) ListTipRefunds(ctx context.Context, tipID uint64) ([]model.TipRefund, error) {
+	var refunds []model.TipRefund
+	err := r.db.WithContext(ctx).Where("tip_id = ?", tipID).Order("created_at, id").Find(&refunds).Error
+	return refunds, err
+}
+
+// SumRefundedArtistPayout returns how much of the tip's artist payout was already reversed
+func (r *SocialRepository) SumRefundedArtistPayout(ctx context.Context, tipID uint64) (int, error) {
+	var total int
+	err := r.db.WithContext(ctx).Model(&model.TipRefund{}).
+		Select("COALESCE(SUM(artist_payout_cents), 0)").
+		Where("tip_id = ? AND status = ?", tipID, model.TipRefundStatusSucceeded).
+		Scan(&total).Error
+	return total, err
+}
+
+func (r *SocialRepository) ListTipsSent(ctx context.Context, userID uint64, page pagination.Params) ([]model.Tip, int64, error) {
+	return r.listTips(ctx, "from_user_id = ?", userID, page)
+}
+
+func (r *SocialRepository) ListTipsReceived(ctx context.Context, artistID uint64, page pagination.Params) ([]model.Tip, int64, error) {
+	return r.listTips(ctx, "to_artist_id = ?", artistID, page)
+}
+
+func (r *SocialRepository) listTips(ctx context.Context, condition string, id uint64, page pagination.Params) ([]model.Tip, int64, error) {
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&model.Tip{}).Where(condition, id).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var tips []model.Tip
+	err := r.db.WithContext(ctx).
+		Where(condition, id).
+		Order("created_at DESC, id DESC").
+		Offset(page.Offset()).
+		Limit(page.Limit).
+		Find(&tips).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return tips, total, nil
+}
+
+func (r *SocialRepository) SumTipsSent(ctx context.Context, userID uint64) ([]model.TipTotal, error) {
+	return r.sumTips(ctx, "from_user_id = ?", userID)
+}
+
+func (r *SocialRepository) SumTipsReceived(ctx context.Context, artistID uint64) ([]model.TipTotal, error) {
+	return r.sumTips(ctx, "to_artist_id = ?", artistID)
+}
+
+func (r *SocialRepository) sumTips(ctx context.Context, condition string, id uint64) ([]model.TipTotal, error) {
+	var totals []model.TipTotal
+	err := r.db.WithContext(ctx).Model(&model.Tip{}).
+		Select(`currency,
+			COUNT(*) AS tip_count,
+			COALESCE(SUM(amount_cents), 0) AS amount_cents,
+			COALESCE(SUM(platform_fee_cents), 0) AS platform_fee_cents,
+			COALESCE(SUM(artist_payout_cents), 0) AS artist_payout_cents,
+			COALESCE(SUM(refunded_cents), 0) AS refunded_cents`).
+		Where(condition, id).
+		Where("status IN ?", []model.TipStatus{model.TipStatusCompleted, model.TipStatusPartiallyRefunded, model.TipStatusRefunded}).
+		Group("currency").
+		Order("currency").
+		Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
@@ -0,0 +1,189 @@
+package application
+
+import (
+	"context"
+	"log"
+	analyticsModel "music-app-backend/internal/analytics/domain"
+	realtimeModel "music-app-backend/internal/realtime/domain"
+	"music-app-backend/internal/social/adapters/repository"
+	model "music-app-backend/internal/social/domain"
+	appError "music-app-backend/pkg/error"
+	baseModel "music-app-backend/pkg/model"
+	"music-app-backend/pkg/pagination"
+	"time"
+)
+
+// FollowArtist follows the artist. Following again is a no-op apart from applying the
+// notification toggle, so clients can retry freely.
+func (s *SocialService) FollowArtist(ctx context.Context, request *model.FollowArtistDTO) (*model.FollowResult, error) {
+	artist, err := s.musicService.GetArtistByID(ctx, request.ArtistID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load artist")
+	}
+	if artist == nil {
+		return nil, appError.NewNotFoundError(nil, "artist not found")
+	}
+	if artist.UserID == request.UserID {
+		return nil, appError.NewBadRequestError(nil, "artists cannot follow themselves")
+	}
+
+	_base, err := baseModel.NewBaseModel(s.generator)
+	if err != nil {
+		return nil, err
+	}
+
+	notificationEnabled := true
+	if request.NotificationEnabled != nil {
+		notificationEnabled = *request.NotificationEnabled
+	}
+	follow := &model.ArtistFollower{
+		BaseModel:           *_base,
+		ArtistID:            artist.ID,
+		FollowerUserID:      request.UserID,
+		NotificationEnabled: notificationEnabled,
+		FollowedAt:          _base.CreatedAt,
+	}
+
+	var created, first bool
+	err = s.repository.Transaction(ctx, func(txRepo *repository.SocialRepository) error {
+		var err error
+		if created, err = txRepo.CreateFollow(ctx, follow); err != nil || !created {
+			return err
+		}
+		first, err = txRepo.RecordFirstFollow(ctx, follow)
+		return err
+	})
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to follow artist")
+	}
+
+	if !created {
+		existing, err := s.repository.GetFollow(ctx, artist.ID, request.UserID)
+		if err != nil {
+			return nil, appError.NewInternalError(err, "failed to load follow")
+		}
+		if existing == nil {
+			// Unfollowed concurrently; report it as not following rather than guessing
+			return nil, appError.NewNotFoundError(nil, "follow not found")
+		}
+		if request.NotificationEnabled != nil && existing.NotificationEnabled != *request.NotificationEnabled {
+			if err := s.repository.UpdateFollowNotifications(ctx, existing.ID, *request.NotificationEnabled); err != nil {
+				return nil, appError.NewInternalError(err, "failed to update follow")
+			}
+			existing.NotificationEnabled = *request.NotificationEnabled
+		}
+		return &model.FollowResult{Follow: existing, Created: false}, nil
+	}
+
+	s.afterFollow(ctx, artist.ID, follow, first)
+	return &model.FollowResult{Follow: follow, Created: true}, nil
+}
+
+// afterFollow counts a first follow in the daily stats and announces every follow on the
+// dashboard
+func (s *SocialService) afterFollow(ctx context.Context, artistID uint64, follow *model.ArtistFollower, first bool) {
+	if first {
+		if err := s.analyticsService.RecordArtistStats(ctx, artistID, follow.FollowedAt, &analyticsModel.ArtistStatsDelta{
+			NewFollowers: 1,
+		}); err != nil {
+			log.Printf("Failed to record follow %d in daily artist stats: %v", follow.ID, err)
+		}
+	}
+
+	// Re-read the artist for the count maintained by the follower trigger
+	artist, err := s.musicService.GetArtistByID(ctx, artistID)
+	if err != nil || artist == nil {
+		log.Printf("Failed to load artist %d after follow: %v", artistID, err)
+		return
+	}
+	if err := s.realtimeService.PublishArtistEvent(ctx, artistID, realtimeModel.EventFollowerNew, realtimeModel.FollowerNewData{
+		UserID:        follow.FollowerUserID,
+		FollowerCount: artist.FollowerCount,
+	}); err != nil {
+		log.Printf("Failed to publish follow %d: %v", follow.ID, err)
+	}
+}
+
+// UnfollowArtist removes the follow; unfollowing an artist that is not followed succeeds
+func (s *SocialService) UnfollowArtist(ctx context.Context, userID, artistID uint64) error {
+	if _, err := s.repository.DeleteFollow(ctx, artistID, userID); err != nil {
+		return appError.NewInternalError(err, "failed to unfollow artist")
+	}
+	return nil
+}
+
+// SetFollowNotifications toggles whether the follower receives the artist's broadcasts
+func (s *SocialService) SetFollowNotifications(ctx context.Context, userID, artistID uint64, enabled bool) (*model.ArtistFollower, error) {
+	follow, err := s.repository.GetFollow(ctx, artistID, userID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load follow")
+	}
+	if follow == nil {
+		return nil, appError.NewNotFoundError(nil, "you do not follow this artist")
+	}
+
+	if follow.NotificationEnabled != enabled {
+		if err := s.repository.UpdateFollowNotifications(ctx, follow.ID, enabled); err != nil {
+			return nil, appError.NewInternalError(err, "failed to update follow")
+		}
+		follow.NotificationEnabled = enabled
+		follow.UpdatedAt = time.Now().UTC()
+	}
+	return follow, nil
+}
+
+// ListFollowedArtists returns the artists the user follows, most recent follow first
+func (s *SocialService) ListFollowedArtists(ctx context.Context, userID uint64, page pagination.CursorParams) (*model.FollowedArtistListResult, error) {
+	artists, err := s.repository.ListFollowedArtists(ctx, userID, page)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to list followed artists")
+	}
+
+	fetched := len(artists)
+	if fetched > page.Limit {
+		artists = artists[:page.Limit]
+	}
+	var last pagination.Cursor
+	if len(artists) > 0 {
+		last = pagination.Cursor{Time: artists[len(artists)-1].FollowedAt, ID: artists[len(artists)-1].FollowID}
+	}
+	if artists == nil {
+		artists = []model.FollowedArtist{}
+	}
+
+	return &model.FollowedArtistListResult{Artists: artists, Pagination: page.Meta(fetched, last)}, nil
+}
+
+// ListFollowers returns the followers of the artist owned by userID, newest first
+func (s *SocialService) ListFollowers(ctx context.Context, userID uint64, page pagination.CursorParams) (*model.FollowerListResult, error) {
+	artist, err := s.musicService.GetArtistByUserID(ctx, userID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load artist")
+	}
+	if artist == nil {
+		return nil, appError.NewNotFoundError(nil, "artist profile not found")
+	}
+
+	followers, err := s.repository.ListFollowers(ctx, artist.ID, page)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to list followers")
+	}
+
+	fetched := len(followers)
+	if fetched > page.Limit {
+		followers = followers[:page.Limit]
+	}
+	var last pagination.Cursor
+	if len(followers) > 0 {
+		last = pagination.Cursor{Time: followers[len(followers)-1].FollowedAt, ID: followers[len(followers)-1].FollowID}
+	}
+	if followers == nil {
+		followers = []model.Follower{}
+	}
+
+	return &model.FollowerListResult{
+		Followers:  followers,
+		Total:      artist.FollowerCount,
+		Pagination: page.Meta(fetched, last),
+	}, nil
+}
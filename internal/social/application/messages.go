@@ -0,0 +1,361 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	notificationModel "music-app-backend/internal/notification/domain"
+	realtimeModel "music-app-backend/internal/realtime/domain"
+	"music-app-backend/internal/social/adapters/repository"
+	model "music-app-backend/internal/social/domain"
+	appError "music-app-backend/pkg/error"
+	baseModel "music-app-backend/pkg/model"
+	"music-app-backend/pkg/pagination"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	maxArtistMessageLength = 1000
+
+	// ArtistMessageQueue holds the IDs of messages waiting for fan-out
+	ArtistMessageQueue = "queue:artist_messages"
+
+	messageDeliveryBatchSize = 500
+
+	// A fan-out that made no progress for this long is assumed dead and is taken over
+	messageStaleAfter = 5 * time.Minute
+)
+
+type artistMessageJob struct {
+	MessageID uint64 `json:"message_id"`
+}
+
+// SendArtistMessage queues a broadcast from the artist owned by the current user. The
+// audience is resolved and delivered by the fan-out worker.
+func (s *SocialService) SendArtistMessage(ctx context.Context, request *model.SendArtistMessageDTO) (*model.ArtistMessage, error) {
+	text := strings.TrimSpace(request.MessageText)
+	if text == "" {
+		return nil, appError.NewBadRequestError(nil, "message_text is required")
+	}
+	if utf8.RuneCountInString(text) > maxArtistMessageLength {
+		return nil, appError.NewBadRequestError(nil, fmt.Sprintf("message must be at most %d characters", maxArtistMessageLength))
+	}
+	if !request.TargetType.IsValid() {
+		return nil, appError.NewBadRequestError(nil, "invalid target_type")
+	}
+
+	artist, err := s.musicService.GetArtistByUserID(ctx, request.ArtistUserID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load artist")
+	}
+	if artist == nil {
+		return nil, appError.NewNotFoundError(nil, "artist profile not found")
+	}
+
+	var targetSongID *uint64
+	if request.TargetType == model.TargetTypeSpecificSongListeners {
+		if request.TargetSongID == nil {
+			return nil, appError.NewBadRequestError(nil, "target_song_id is required for specific_song_listeners")
+		}
+		song, err := s.musicService.GetSongByID(ctx, *request.TargetSongID)
+		if err != nil {
+			return nil, appError.NewInternalError(err, "failed to load song")
+		}
+		if song == nil || !song.IsActive {
+			return nil, appError.NewNotFoundError(nil, "song not found")
+		}
+		if song.ArtistID != artist.ID {
+			return nil, appError.NewBadRequestError(nil, "song does not belong to this artist")
+		}
+		targetSongID = &song.ID
+	}
+
+	limit, err := s.messageLimiter.Allow(ctx, strconv.FormatUint(artist.ID, 10))
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to check message rate limit")
+	}
+	if !limit.Allowed {
+		retryAfter := int(limit.RetryAfter.Seconds()) + 1
+		return nil, appError.NewTooManyRequestsError(nil,
+			fmt.Sprintf("message limit of %d per %s reached", limit.Limit.Max, limit.Limit.Window)).
+			WithData(map[string]int{"retry_after_seconds": retryAfter})
+	}
+
+	_base, err := baseModel.NewBaseModel(s.generator)
+	if err != nil {
+		return nil, err
+	}
+
+	message := &model.ArtistMessage{
+		BaseModel:    *_base,
+		ArtistID:     artist.ID,
+		MessageText:  text,
+		TargetType:   request.TargetType,
+		TargetSongID: targetSongID,
+		Status:       model.MessageStatusQueued,
+	}
+	if err := s.repository.CreateArtistMessage(ctx, message); err != nil {
+		return nil, appError.NewInternalError(err, "failed to save message")
+	}
+
+	// If this fails the stalled-message sweep enqueues it again
+	if err := s.redisClient.Enqueue(ctx, ArtistMessageQueue, artistMessageJob{MessageID: message.ID}); err != nil {
+		log.Printf("Failed to enqueue artist message %d: %v", message.ID, err)
+	}
+
+	return message, nil
+}
+
+// ProcessArtistMessage resolves the audience and writes deliveries in batches, pushing
+// each new delivery to the recipient's live stream. It is safe to run again after a crash.
+func (s *SocialService) ProcessArtistMessage(ctx context.Context, messageID uint64) error {
+	claimed, err := s.repository.ClaimArtistMessage(ctx, messageID, time.Now().UTC().Add(-messageStaleAfter))
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return nil // Already sent, or another worker is on it
+	}
+
+	message, err := s.repository.GetArtistMessage(ctx, messageID)
+	if err != nil {
+		return err
+	}
+	if message == nil {
+		return nil
+	}
+
+	artist, err := s.musicService.GetArtistByID(ctx, message.ArtistID)
+	if err != nil {
+		return err
+	}
+	if artist == nil {
+		return s.repository.FinishArtistMessage(ctx, message.ID, model.MessageStatusFailed, nil)
+	}
+
+	var afterUserID uint64
+	for {
+		userIDs, err := s.repository.ListMessageAudience(ctx, message, artist.UserID, afterUserID, messageDeliveryBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(userIDs) == 0 {
+			break
+		}
+
+		if err := s.deliverMessageBatch(ctx, message, artist.ArtistName, userIDs); err != nil {
+			return err
+		}
+		afterUserID = userIDs[len(userIDs)-1]
+	}
+
+	sentAt := time.Now().UTC()
+	if err := s.repository.FinishArtistMessage(ctx, message.ID, model.MessageStatusSent, &sentAt); err != nil {
+		return err
+	}
+
+	sent, err := s.repository.GetArtistMessage(ctx, message.ID)
+	if err == nil && sent != nil {
+		if err := s.realtimeService.PublishArtistEvent(ctx, sent.ArtistID, realtimeModel.EventMessageSent, realtimeModel.MessageSentData{
+			MessageID:   sent.ID,
+			TargetType:  string(sent.TargetType),
+			SentToCount: sent.SentToCount,
+		}); err != nil {
+			log.Printf("Failed to publish message.sent for message %d: %v", sent.ID, err)
+		}
+	}
+	return nil
+}
+
+func (s *SocialService) deliverMessageBatch(ctx context.Context, message *model.ArtistMessage, artistName string, userIDs []uint64) error {
+	now := time.Now().UTC()
+	deliveries := make([]model.MessageDelivery, 0, len(userIDs))
+	for _, userID := range userIDs {
+		_base, err := baseModel.NewBaseModel(s.generator)
+		if err != nil {
+			return err
+		}
+		deliveries = append(deliveries, model.MessageDelivery{
+			BaseModel:   *_base,
+			MessageID:   message.ID,
+			UserID:      userID,
+			DeliveredAt: now,
+		})
+	}
+
+	inserted, err := s.repository.CreateMessageDeliveries(ctx, deliveries)
+	if err != nil {
+		return err
+	}
+	if err := s.repository.AddMessageRecipients(ctx, message.ID, len(inserted)); err != nil {
+		return err
+	}
+
+	data := realtimeModel.MessageReceivedData{
+		MessageID:    message.ID,
+		ArtistID:     message.ArtistID,
+		ArtistName:   artistName,
+		MessageText:  message.MessageText,
+		TargetType:   string(message.TargetType),
+		TargetSongID: message.TargetSongID,
+		DeliveredAt:  now,
+	}
+	failed := 0
+	for _, userID := range inserted {
+		if err := s.realtimeService.PublishUserEvent(ctx, userID, realtimeModel.EventMessageReceived, data); err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		// Recipients still find the message in their inbox
+		log.Printf("Failed to push message %d to %d of %d recipients", message.ID, failed, len(inserted))
+	}
+
+	if _, err := s.notificationService.Notify(ctx, &notificationModel.NotifyDTO{
+		Type:        notificationModel.NotificationTypeArtistMessage,
+		Title:       "New message from " + artistName,
+		Body:        message.MessageText,
+		ArtistID:    &message.ArtistID,
+		SongID:      message.TargetSongID,
+		ReferenceID: &message.ID,
+		DedupKey:    "artist_message:" + strconv.FormatUint(message.ID, 10),
+	}, inserted...); err != nil {
+		log.Printf("Failed to create notifications for message %d: %v", message.ID, err)
+	}
+	return nil
+}
+
+// ListSentMessages returns the broadcasts of the artist owned by userID
+func (s *SocialService) ListSentMessages(ctx context.Context, userID uint64, page pagination.Params) (*model.ArtistMessageListResult, error) {
+	artist, err := s.musicService.GetArtistByUserID(ctx, userID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load artist")
+	}
+	if artist == nil {
+		return nil, appError.NewNotFoundError(nil, "artist profile not found")
+	}
+
+	messages, total, err := s.repository.ListArtistMessages(ctx, artist.ID, page)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to list messages")
+	}
+
+	return &model.ArtistMessageListResult{Messages: messages, Pagination: page.Meta(total)}, nil
+}
+
+// ListInbox returns the messages delivered to the current user, newest first
+func (s *SocialService) ListInbox(ctx context.Context, userID uint64, page pagination.Params) (*model.InboxResult, error) {
+	messages, total, err := s.repository.ListInboxMessages(ctx, userID, page)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to list messages")
+	}
+
+	unread, err := s.repository.CountUnreadMessages(ctx, userID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to count unread messages")
+	}
+
+	return &model.InboxResult{Messages: messages, UnreadCount: unread, Pagination: page.Meta(total)}, nil
+}
+
+// MarkMessageRead records the read receipt. Only the first read counts towards ReadCount.
+func (s *SocialService) MarkMessageRead(ctx context.Context, userID, messageID uint64) (*model.MessageDelivery, error) {
+	delivery, err := s.repository.GetMessageDelivery(ctx, messageID, userID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load message")
+	}
+	if delivery == nil {
+		return nil, appError.NewNotFoundError(nil, "message not found")
+	}
+	if delivery.ReadAt != nil {
+		return delivery, nil
+	}
+
+	readAt := time.Now().UTC()
+	err = s.repository.Transaction(ctx, func(txRepo *repository.SocialRepository) error {
+		marked, err := txRepo.MarkMessageDeliveryRead(ctx, delivery.ID, readAt)
+		if err != nil {
+			return err
+		}
+		if !marked {
+			return nil // A concurrent request recorded the receipt first
+		}
+		return txRepo.IncrementMessageReadCount(ctx, messageID)
+	})
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to mark message as read")
+	}
+
+	delivery.ReadAt = &readAt
+	return delivery, nil
+}
+
+// StartMessageWorkers runs the fan-out consumers and a sweep that re-enqueues messages
+// whose fan-out never started or stalled, e.g. because a replica died mid-send
+func (s *SocialService) StartMessageWorkers(ctx context.Context, workers int, sweepInterval time.Duration) {
+	for i := 0; i < workers; i++ {
+		go s.consumeArtistMessages(ctx)
+	}
+
+	ticker := time.NewTicker(sweepInterval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.requeueStalledMessages(ctx); err != nil {
+					log.Printf("Failed to requeue stalled artist messages: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+func (s *SocialService) consumeArtistMessages(ctx context.Context) {
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		payload, err := s.redisClient.Dequeue(ctx, ArtistMessageQueue, 5*time.Second)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("Failed to read artist message queue: %v", err)
+				time.Sleep(time.Second)
+			}
+			continue
+		}
+		if payload == nil {
+			continue
+		}
+
+		var job artistMessageJob
+		if err := json.Unmarshal(payload, &job); err != nil {
+			log.Printf("Dropping malformed artist message job: %v", err)
+			continue
+		}
+		if err := s.ProcessArtistMessage(ctx, job.MessageID); err != nil {
+			// Left in sending; the sweep retries it once it goes stale
+			log.Printf("Fan-out of artist message %d failed: %v", job.MessageID, err)
+		}
+	}
+}
+
+func (s *SocialService) requeueStalledMessages(ctx context.Context) error {
+	ids, err := s.repository.ListStalledArtistMessageIDs(ctx, time.Now().UTC().Add(-messageStaleAfter), 100)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := s.redisClient.Enqueue(ctx, ArtistMessageQueue, artistMessageJob{MessageID: id}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	musicModuleSvc "music-app-backend/internal/music/application"
+	notificationModuleSvc "music-app-backend/internal/notification/application"
+	notificationModel "music-app-backend/internal/notification/domain"
+	model "music-app-backend/internal/social/domain"
+	"strconv"
+)
+
+// TipNotifier tells artists about the tips they received and later changes to them
+type TipNotifier interface {
+	NotifyTipReceived(ctx context.Context, tip *model.Tip) error
+	NotifyTipRefunded(ctx context.Context, tip *model.Tip, refund *model.TipRefund) error
+}
+
+// inboxTipNotifier puts tip notifications in the artist's in-app inbox
+type inboxTipNotifier struct {
+	musicService        musicModuleSvc.IMusicService
+	notificationService notificationModuleSvc.INotificationService
+}
+
+func (n inboxTipNotifier) NotifyTipReceived(ctx context.Context, tip *model.Tip) error {
+	amount := formatCents(tip.AmountCents, tip.Currency)
+	body := amount
+	if tip.Message != "" {
+		body += ": " + tip.Message
+	}
+
+	emailData := map[string]string{
+		"amount":  amount,
+		"message": tip.Message,
+	}
+	if tip.SongID != nil {
+		if song, err := n.musicService.GetSongByID(ctx, *tip.SongID); err == nil && song != nil {
+			emailData["song_title"] = song.Title
+		}
+	}
+
+	return n.notifyArtist(ctx, tip.ToArtistID, &notificationModel.NotifyDTO{
+		Type:        notificationModel.NotificationTypeTipReceived,
+		Title:       "You received a tip",
+		Body:        body,
+		ArtistID:    &tip.ToArtistID,
+		SongID:      tip.SongID,
+		ReferenceID: &tip.ID,
+		DedupKey:    "tip_received:" + strconv.FormatUint(tip.ID, 10),
+		EmailData:   emailData,
+	})
+}
+
+func (n inboxTipNotifier) NotifyTipRefunded(ctx context.Context, tip *model.Tip, refund *model.TipRefund) error {
+	title := "A tip was refunded"
+	if refund.Kind == model.TipRefundKindChargeback {
+		title = "A tip was charged back"
+	}
+
+	return n.notifyArtist(ctx, tip.ToArtistID, &notificationModel.NotifyDTO{
+		Type:        notificationModel.NotificationTypeTipRefunded,
+		Title:       title,
+		Body:        fmt.Sprintf("%s of a %s tip: %s", formatCents(refund.AmountCents, refund.Currency), formatCents(tip.AmountCents, tip.Currency), refund.Reason),
+		ArtistID:    &tip.ToArtistID,
+		SongID:      tip.SongID,
+		ReferenceID: &tip.ID,
+		DedupKey:    "tip_refunded:" + strconv.FormatUint(refund.ID, 10),
+	})
+}
+
+func (n inboxTipNotifier) notifyArtist(ctx context.Context, artistID uint64, notification *notificationModel.NotifyDTO) error {
+	artist, err := n.musicService.GetArtistByID(ctx, artistID)
+	if err != nil {
+		return err
+	}
+	if artist == nil {
+		return fmt.Errorf("artist %d not found", artistID)
+	}
+
+	_, err = n.notificationService.Notify(ctx, notification, artist.UserID)
+	return err
+}
+
+func formatCents(cents int, currency string) string {
+	return fmt.Sprintf("%d.%02d %s", cents/100, cents%100, currency)
+}
@@ -0,0 +1,348 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log"
+	analyticsModel "music-app-backend/internal/analytics/domain"
+	earningsModel "music-app-backend/internal/earnings/domain"
+	"music-app-backend/internal/social/adapters/repository"
+	model "music-app-backend/internal/social/domain"
+	"music-app-backend/pkg/audit"
+	appError "music-app-backend/pkg/error"
+	baseModel "music-app-backend/pkg/model"
+	"music-app-backend/pkg/payment"
+	"strconv"
+	"strings"
+)
+
+const maxRefundReasonLength = 500
+
+// RefundTip reverses all or part of a tip. An admin refund is first recorded as pending,
+// which reserves its amount, then sent to the payment provider with the refund ID as
+// idempotency key, and finalized once the provider accepted it. Webhook refunds were
+// already executed by the provider and carry its refund ID, which also makes redelivered
+// webhooks a no-op and completes an admin refund whose finalization failed.
+func (s *SocialService) RefundTip(ctx context.Context, request *model.RefundTipDTO) (*model.TipRefundResult, error) {
+	reason := strings.TrimSpace(request.Reason)
+	if reason == "" {
+		return nil, appError.NewBadRequestError(nil, "a refund reason is required")
+	}
+	if len(reason) > maxRefundReasonLength {
+		return nil, appError.NewBadRequestError(nil, fmt.Sprintf("reason must be at most %d characters", maxRefundReasonLength))
+	}
+	if request.AmountCents < 0 {
+		return nil, appError.NewBadRequestError(nil, "amount_cents must not be negative")
+	}
+
+	tip, err := s.repository.GetTipByID(ctx, request.TipID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load tip")
+	}
+	if tip == nil {
+		return nil, appError.NewNotFoundError(nil, "tip not found")
+	}
+
+	if request.ExternalRefundID != "" {
+		existing, err := s.repository.GetTipRefundByExternalID(ctx, request.ExternalRefundID)
+		if err != nil {
+			return nil, appError.NewInternalError(err, "failed to load refund")
+		}
+		if existing != nil && existing.Status == model.TipRefundStatusPending {
+			return s.finalizeTipRefund(ctx, existing, request.ExternalRefundID)
+		}
+		if existing != nil {
+			return &model.TipRefundResult{Tip: model.NewTipView(tip, false), Refund: existing}, nil
+		}
+	}
+
+	if !tip.IsRefundable() {
+		return nil, appError.NewBadRequestError(nil, fmt.Sprintf("tip is %s and cannot be refunded", tip.Status))
+	}
+	remaining := tip.AmountCents - tip.RefundedCents
+	amount := request.AmountCents
+	if amount == 0 {
+		amount = remaining
+	}
+	if amount > remaining {
+		return nil, appError.NewBadRequestError(nil, fmt.Sprintf("at most %d cents can still be refunded", remaining))
+	}
+
+	_base, err := baseModel.NewBaseModel(s.generator)
+	if err != nil {
+		return nil, err
+	}
+	refund := &model.TipRefund{
+		BaseModel:   *_base,
+		TipID:       tip.ID,
+		AmountCents: amount,
+		Currency:    tip.Currency,
+		Kind:        request.Kind,
+		Source:      request.Source,
+		Reason:      reason,
+		ActorUserID: request.ActorUserID,
+		Status:      model.TipRefundStatusPending,
+	}
+
+	if request.ExternalRefundID != "" {
+		return s.finalizeTipRefund(ctx, refund, request.ExternalRefundID)
+	}
+
+	if tip.StripePaymentIntentID == nil {
+		return nil, appError.NewBadRequestError(nil, "tip has no payment to refund")
+	}
+	err = s.repository.Transaction(ctx, func(txRepo *repository.SocialRepository) error {
+		locked, err := txRepo.GetTipForUpdate(ctx, tip.ID)
+		if err != nil {
+			return err
+		}
+		if err := checkRefundable(ctx, txRepo, locked, refund); err != nil {
+			return err
+		}
+		return txRepo.CreateTipRefund(ctx, refund)
+	})
+	if err != nil {
+		if appError.IsAppError(err) {
+			return nil, err
+		}
+		return nil, appError.NewInternalError(err, "failed to record refund")
+	}
+
+	providerRefund, err := s.paymentProvider.RefundPayment(ctx, &payment.RefundRequest{
+		PaymentIntentID: *tip.StripePaymentIntentID,
+		AmountCents:     amount,
+		Reason:          reason,
+		IdempotencyKey:  strconv.FormatUint(refund.ID, 10),
+	})
+	if err != nil {
+		if failErr := s.repository.FailTipRefund(ctx, refund.ID, err); failErr != nil {
+			log.Printf("Failed to release refund %d of tip %d: %v", refund.ID, tip.ID, failErr)
+		}
+		return nil, appError.NewBadRequestError(err, "refund could not be processed")
+	}
+	if err := s.repository.SetTipRefundExternalID(ctx, refund.ID, providerRefund.RefundID); err != nil {
+		log.Printf("Failed to store provider refund %s on refund %d: %v", providerRefund.RefundID, refund.ID, err)
+	}
+
+	result, err := s.finalizeTipRefund(ctx, refund, providerRefund.RefundID)
+	if err != nil {
+		// The money is back with the tipper; the provider's webhook completes the record
+		log.Printf("Provider refund %s for tip %d left pending as refund %d: %v", providerRefund.RefundID, tip.ID, refund.ID, err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// finalizeTipRefund splits the refund between platform fee and artist payout, records
+// it as succeeded together with the tip's new refund state, then propagates it. A
+// pending refund is completed in place; any other is inserted.
+func (s *SocialService) finalizeTipRefund(ctx context.Context, refund *model.TipRefund, externalRefundID string) (*model.TipRefundResult, error) {
+	var tip *model.Tip
+	var before model.Tip
+	pending := refund.Status == model.TipRefundStatusPending
+	err := s.repository.Transaction(ctx, func(txRepo *repository.SocialRepository) error {
+		locked, err := txRepo.GetTipForUpdate(ctx, refund.TipID)
+		if err != nil {
+			return err
+		}
+		if err := checkRefundable(ctx, txRepo, locked, refund); err != nil {
+			return err
+		}
+
+		before = *locked
+
+		refundedPayout, err := txRepo.SumRefundedArtistPayout(ctx, locked.ID)
+		if err != nil {
+			return err
+		}
+
+		refundedCents := locked.RefundedCents + refund.AmountCents
+		fullyRefunded := refundedCents == locked.AmountCents
+		// The last refund takes whatever payout is left so rounding never drifts
+		artistShare := locked.ArtistPayoutCents - refundedPayout
+		status := model.TipStatusRefunded
+		if !fullyRefunded {
+			artistShare = proportionalShare(refund.AmountCents, locked.ArtistPayoutCents, locked.AmountCents)
+			status = model.TipStatusPartiallyRefunded
+		}
+
+		if err := txRepo.UpdateTipRefundState(ctx, locked.ID, refundedCents, status); err != nil {
+			return err
+		}
+
+		refund.PlatformFeeCents = refund.AmountCents - artistShare
+		refund.ArtistPayoutCents = artistShare
+		refund.ExternalRefundID = &externalRefundID
+		refund.FullyRefunded = fullyRefunded
+		refund.Status = model.TipRefundStatusSucceeded
+		if pending {
+			completed, err := txRepo.CompleteTipRefund(ctx, refund)
+			if err != nil {
+				return err
+			}
+			if !completed {
+				return appError.NewBadRequestError(nil, "refund is no longer pending")
+			}
+		} else if err := txRepo.CreateTipRefund(ctx, refund); err != nil {
+			return err
+		}
+
+		locked.RefundedCents = refundedCents
+		locked.Status = status
+		tip = locked
+		return nil
+	})
+	if err != nil {
+		if appError.IsAppError(err) {
+			return nil, err
+		}
+		return nil, appError.NewInternalError(err, "failed to record refund")
+	}
+
+	entry := &audit.Entry{
+		Action:     "tip.refund",
+		TargetType: "tip",
+		TargetID:   strconv.FormatUint(tip.ID, 10),
+		Before:     map[string]interface{}{"status": before.Status, "refunded_cents": before.RefundedCents},
+		After:      map[string]interface{}{"status": tip.Status, "refunded_cents": tip.RefundedCents},
+		Metadata: map[string]interface{}{
+			"refund_id":          refund.ID,
+			"amount_cents":       refund.AmountCents,
+			"kind":               refund.Kind,
+			"source":             refund.Source,
+			"reason":             refund.Reason,
+			"external_refund_id": externalRefundID,
+		},
+	}
+	// Provider webhooks carry no user; the refund was made by the provider
+	if refund.Source == model.TipRefundSourceWebhook {
+		entry.ActorType = audit.ActorTypeSystem
+	}
+	audit.Write(ctx, s.auditLog, entry)
+
+	s.afterTipRefund(ctx, tip, refund)
+
+	return &model.TipRefundResult{Tip: model.NewTipView(tip, false), Refund: refund}, nil
+}
+
+// checkRefundable makes sure the locked tip still covers the refund once the amounts
+// reserved by other pending refunds are set aside
+func checkRefundable(ctx context.Context, txRepo *repository.SocialRepository, locked *model.Tip, refund *model.TipRefund) error {
+	if locked == nil || !locked.IsRefundable() {
+		return appError.NewBadRequestError(nil, "refund exceeds the remaining tip amount")
+	}
+	reserved, err := txRepo.SumPendingRefundCents(ctx, locked.ID, refund.ID)
+	if err != nil {
+		return err
+	}
+	if refund.AmountCents > locked.AmountCents-locked.RefundedCents-reserved {
+		return appError.NewBadRequestError(nil, "refund exceeds the remaining tip amount")
+	}
+	return nil
+}
+
+// afterTipRefund propagates a recorded refund. Failures are logged only: the ledger
+// is backfilled by the payout job and the refund itself is already committed.
+func (s *SocialService) afterTipRefund(ctx context.Context, tip *model.Tip, refund *model.TipRefund) {
+	if err := s.earningsService.RecordRefund(ctx, &earningsModel.RefundLedgerInput{
+		RefundID:          refund.ID,
+		TipID:             tip.ID,
+		ArtistID:          tip.ToArtistID,
+		Currency:          refund.Currency,
+		AmountCents:       int64(refund.AmountCents),
+		PlatformFeeCents:  int64(refund.PlatformFeeCents),
+		ArtistPayoutCents: int64(refund.ArtistPayoutCents),
+	}); err != nil {
+		log.Printf("Failed to post refund %d to the earnings ledger: %v", refund.ID, err)
+	}
+
+	// Reverse the stats on the day the tip was counted
+	day := tip.CreatedAt
+	if tip.ProcessedAt != nil {
+		day = *tip.ProcessedAt
+	}
+	delta := &analyticsModel.ArtistStatsDelta{TipsReceivedCents: -refund.ArtistPayoutCents}
+	if refund.FullyRefunded {
+		delta.TipCount = -1
+	}
+	if err := s.analyticsService.RecordArtistStats(ctx, tip.ToArtistID, day, delta); err != nil {
+		log.Printf("Failed to reverse refund %d in daily artist stats: %v", refund.ID, err)
+	}
+
+	if err := s.tipNotifier.NotifyTipRefunded(ctx, tip, refund); err != nil {
+		log.Printf("Failed to notify artist %d about refund %d: %v", tip.ToArtistID, refund.ID, err)
+	}
+}
+
+// HandlePaymentWebhook settles pending tips and applies refunds and chargebacks reported
+// by the payment provider. Refunds of unknown payments and events of other types are
+// acknowledged and ignored.
+func (s *SocialService) HandlePaymentWebhook(ctx context.Context, event *payment.WebhookEvent) error {
+	var kind model.TipRefundKind
+	var externalRefundID, defaultReason string
+	switch event.Type {
+	case payment.EventPaymentSucceeded, payment.EventPaymentFailed:
+		return s.applyPaymentResult(ctx, event)
+	case payment.EventChargeRefunded:
+		kind, externalRefundID, defaultReason = model.TipRefundKindRefund, event.RefundID, "Refunded by payment provider"
+	case payment.EventChargebackOpened:
+		kind, externalRefundID, defaultReason = model.TipRefundKindChargeback, event.DisputeID, "Chargeback opened by cardholder"
+	default:
+		return nil
+	}
+	if externalRefundID == "" || event.PaymentIntentID == "" {
+		return appError.NewBadRequestError(nil, "event is missing payment or refund reference")
+	}
+
+	tip, err := s.repository.GetTipByPaymentIntentID(ctx, event.PaymentIntentID)
+	if err != nil {
+		return appError.NewInternalError(err, "failed to load tip")
+	}
+	if tip == nil {
+		log.Printf("Payment webhook %s references unknown payment %s", event.ID, event.PaymentIntentID)
+		return nil
+	}
+
+	reason := strings.TrimSpace(event.Reason)
+	if reason == "" {
+		reason = defaultReason
+	}
+
+	_, err = s.RefundTip(ctx, &model.RefundTipDTO{
+		TipID:            tip.ID,
+		AmountCents:      event.AmountCents,
+		Kind:             kind,
+		Source:           model.TipRefundSourceWebhook,
+		Reason:           reason,
+		ExternalRefundID: externalRefundID,
+	})
+	return err
+}
+
+// ListTipRefunds returns the refund history of a tip
+func (s *SocialService) ListTipRefunds(ctx context.Context, tipID uint64) ([]model.TipRefund, error) {
+	tip, err := s.repository.GetTipByID(ctx, tipID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load tip")
+	}
+	if tip == nil {
+		return nil, appError.NewNotFoundError(nil, "tip not found")
+	}
+
+	refunds, err := s.repository.ListTipRefunds(ctx, tipID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to list refunds")
+	}
+	if refunds == nil {
+		refunds = []model.TipRefund{}
+	}
+	return refunds, nil
+}
+
+// proportionalShare returns part*amount/total rounded half up
+func proportionalShare(amount, part, total int) int {
+	if total == 0 {
+		return 0
+	}
+	return int((int64(amount)*int64(part)*2 + int64(total)) / (int64(total) * 2))
+}
@@ -0,0 +1,70 @@
+package application
+
+import (
+	"context"
+	"log"
+	musicModel "music-app-backend/internal/music/domain"
+	notificationModel "music-app-backend/internal/notification/domain"
+	"strconv"
+)
+
+// SongProcessed notifies the artist's followers of a new release once the song can be
+// streamed; an explicit song only reaches followers who allow explicit content. It implements the music module's ProcessingListener; the fan-out runs in the
+// background so the processing callback is not held up by large follower lists.
+func (s *SocialService) SongProcessed(ctx context.Context, song *musicModel.Song, success bool) {
+	if !success || !song.IsActive {
+		return
+	}
+	// Collaboration and archive tiers are not releases
+	if song.Tier != musicModel.ContentTierPublicDiscovery && song.Tier != musicModel.ContentTierFanExclusives {
+		return
+	}
+
+	go s.notifyNewRelease(context.WithoutCancel(ctx), song)
+}
+
+func (s *SocialService) notifyNewRelease(ctx context.Context, song *musicModel.Song) {
+	artist, err := s.musicService.GetArtistByID(ctx, song.ArtistID)
+	if err != nil || artist == nil {
+		log.Printf("Failed to load artist %d for release notification: %v", song.ArtistID, err)
+		return
+	}
+
+	notification := &notificationModel.NotifyDTO{
+		Type:        notificationModel.NotificationTypeNewRelease,
+		Title:       "New release from " + artist.ArtistName,
+		Body:        song.Title,
+		ArtistID:    &artist.ID,
+		SongID:      &song.ID,
+		ReferenceID: &song.ID,
+		DedupKey:    "new_release:" + strconv.FormatUint(song.ID, 10),
+		EmailData: map[string]string{
+			"artist_name": artist.ArtistName,
+			"song_id":     strconv.FormatUint(song.ID, 10),
+			"song_title":  song.Title,
+		},
+	}
+
+	var afterUserID uint64
+	notified := 0
+	for {
+		userIDs, err := s.repository.ListNotifiedFollowerIDs(ctx, artist.ID, artist.UserID, afterUserID, messageDeliveryBatchSize, song.IsExplicit)
+		if err != nil {
+			log.Printf("Failed to list followers of artist %d: %v", artist.ID, err)
+			return
+		}
+		if len(userIDs) == 0 {
+			break
+		}
+
+		count, err := s.notificationService.Notify(ctx, notification, userIDs...)
+		notified += count
+		if err != nil {
+			log.Printf("Failed to notify followers of song %d: %v", song.ID, err)
+			return
+		}
+		afterUserID = userIDs[len(userIDs)-1]
+	}
+
+	log.Printf("Notified %d followers of new release %d", notified, song.ID)
+}
@@ -1,13 +1,69 @@
 package application
 
-import "music-app-backend/internal/social/adapters/repository"
+import (
+	analyticsModuleSvc "music-app-backend/internal/analytics/application"
+	earningsModuleSvc "music-app-backend/internal/earnings/application"
+	musicModuleSvc "music-app-backend/internal/music/application"
+	notificationModuleSvc "music-app-backend/internal/notification/application"
+	realtimeModuleSvc "music-app-backend/internal/realtime/application"
+	"music-app-backend/internal/social/adapters/repository"
+	model "music-app-backend/internal/social/domain"
+	"music-app-backend/pkg/audit"
+	"music-app-backend/pkg/payment"
+	"music-app-backend/pkg/ratelimit"
+	"music-app-backend/pkg/redis"
+
+	goflakeid "github.com/capy-engineer/go-flakeid"
+)
 
 type SocialService struct {
-	repository *repository.SocialRepository
+	repository          *repository.SocialRepository
+	generator           *goflakeid.Generator
+	musicService        musicModuleSvc.IMusicService
+	earningsService     earningsModuleSvc.IEarningsService
+	analyticsService    analyticsModuleSvc.IAnalyticsService
+	realtimeService     realtimeModuleSvc.IRealtimeService
+	notificationService notificationModuleSvc.INotificationService
+	paymentProvider     payment.Provider
+	tipFees             model.TipFeeSchedule
+	tipNotifier         TipNotifier
+	redisClient         *redis.Client
+	messageLimiter      *ratelimit.Limiter
+	auditLog            audit.Logger
 }
 
-func NewSocialService(repository *repository.SocialRepository) *SocialService {
-	return &SocialService{repository: repository}
+func NewSocialService(
+	repository *repository.SocialRepository,
+	generator *goflakeid.Generator,
+	musicService musicModuleSvc.IMusicService,
+	earningsService earningsModuleSvc.IEarningsService,
+	analyticsService analyticsModuleSvc.IAnalyticsService,
+	realtimeService realtimeModuleSvc.IRealtimeService,
+	notificationService notificationModuleSvc.INotificationService,
+	paymentProvider payment.Provider,
+	tipFees model.TipFeeSchedule,
+	redisClient *redis.Client,
+	messageLimiter *ratelimit.Limiter,
+	auditLog audit.Logger,
+) *SocialService {
+	return &SocialService{
+		repository:          repository,
+		generator:           generator,
+		musicService:        musicService,
+		earningsService:     earningsService,
+		analyticsService:    analyticsService,
+		realtimeService:     realtimeService,
+		notificationService: notificationService,
+		paymentProvider:     paymentProvider,
+		tipFees:             tipFees,
+		tipNotifier:         inboxTipNotifier{musicService: musicService, notificationService: notificationService},
+		redisClient:         redisClient,
+		messageLimiter:      messageLimiter,
+		auditLog:            auditLog,
+	}
 }
 
-func (s *SocialService) IMockService() {}
+// SetTipNotifier replaces the default in-app inbox notifier
+func (s *SocialService) SetTipNotifier(notifier TipNotifier) {
+	s.tipNotifier = notifier
+}
@@ -0,0 +1,291 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log"
+	analyticsModel "music-app-backend/internal/analytics/domain"
+	earningsModel "music-app-backend/internal/earnings/domain"
+	realtimeModel "music-app-backend/internal/realtime/domain"
+	model "music-app-backend/internal/social/domain"
+	appError "music-app-backend/pkg/error"
+	baseModel "music-app-backend/pkg/model"
+	"music-app-backend/pkg/pagination"
+	"music-app-backend/pkg/payment"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const maxTipMessageLength = 500
+
+// QuoteTip returns the platform fee and artist payout for a prospective tip
+func (s *SocialService) QuoteTip(amountCents int, currency string) (*model.TipFeeQuote, error) {
+	currency = strings.ToUpper(currency)
+	platformFee, artistPayout, err := s.tipFees.Calculate(amountCents, currency)
+	if err != nil {
+		return nil, appError.NewBadRequestError(err, err.Error())
+	}
+
+	return &model.TipFeeQuote{
+		AmountCents:       amountCents,
+		Currency:          currency,
+		PlatformFeeCents:  platformFee,
+		ArtistPayoutCents: artistPayout,
+	}, nil
+}
+
+// SendTip records the tip as pending, charges the listener, then completes or fails the
+// tip. Inserting first leaves a row to reconcile or refund whatever happens after the
+// charge, and the completed transition fires the tip-totals trigger. A charge the provider
+// has not settled yet leaves the tip pending until the payment webhook reports the result.
+func (s *SocialService) SendTip(ctx context.Context, request *model.SendTipDTO) (*model.TipView, error) {
+	if len(request.Message) > maxTipMessageLength {
+		return nil, appError.NewBadRequestError(nil, fmt.Sprintf("message must be at most %d characters", maxTipMessageLength))
+	}
+
+	artist, err := s.musicService.GetArtistByID(ctx, request.ToArtistID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load artist")
+	}
+	if artist == nil {
+		return nil, appError.NewNotFoundError(nil, "artist not found")
+	}
+	if artist.UserID == request.FromUserID {
+		return nil, appError.NewBadRequestError(nil, "artists cannot tip themselves")
+	}
+
+	if request.SongID != nil {
+		song, err := s.musicService.GetSongByID(ctx, *request.SongID)
+		if err != nil {
+			return nil, appError.NewInternalError(err, "failed to load song")
+		}
+		if song == nil || !song.IsActive {
+			return nil, appError.NewNotFoundError(nil, "song not found")
+		}
+		if song.ArtistID != artist.ID {
+			return nil, appError.NewBadRequestError(nil, "song does not belong to this artist")
+		}
+	}
+
+	quote, err := s.QuoteTip(request.AmountCents, request.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	_base, err := baseModel.NewBaseModel(s.generator)
+	if err != nil {
+		return nil, err
+	}
+
+	tip := &model.Tip{
+		BaseModel:         *_base,
+		FromUserID:        request.FromUserID,
+		ToArtistID:        artist.ID,
+		SongID:            request.SongID,
+		AmountCents:       quote.AmountCents,
+		Currency:          quote.Currency,
+		PlatformFeeCents:  quote.PlatformFeeCents,
+		ArtistPayoutCents: quote.ArtistPayoutCents,
+		Status:            model.TipStatusPending,
+		Message:           strings.TrimSpace(request.Message),
+		IsAnonymous:       request.IsAnonymous,
+	}
+	if err := s.repository.CreateTip(ctx, tip); err != nil {
+		return nil, appError.NewInternalError(err, "failed to record tip")
+	}
+
+	// The tip ID is the idempotency key, so a charge can always be matched to its row
+	charge, err := s.paymentProvider.CreatePayment(ctx, &payment.PaymentRequest{
+		AmountCents:    quote.AmountCents,
+		Currency:       quote.Currency,
+		Description:    fmt.Sprintf("Tip for %s", artist.ArtistName),
+		IdempotencyKey: strconv.FormatUint(tip.ID, 10),
+		Metadata: map[string]string{
+			"tip_id":    strconv.FormatUint(tip.ID, 10),
+			"artist_id": strconv.FormatUint(artist.ID, 10),
+		},
+	})
+	if err != nil {
+		if _, err := s.repository.MarkTipFailed(ctx, tip.ID); err != nil {
+			log.Printf("Failed to mark tip %d failed: %v", tip.ID, err)
+		}
+		return nil, appError.NewBadRequestError(err, "payment could not be processed")
+	}
+
+	if err := s.repository.SetTipPayment(ctx, tip.ID, charge.IntentID, charge.ChargeID); err != nil {
+		return nil, appError.NewInternalError(err, "failed to record payment")
+	}
+	tip.StripePaymentIntentID = &charge.IntentID
+	tip.StripeChargeID = charge.ChargeID
+
+	switch charge.Status {
+	case payment.StatusSucceeded:
+		if _, err := s.completeTip(ctx, tip, charge.ChargeID); err != nil {
+			return nil, err
+		}
+	case payment.StatusFailed:
+		if _, err := s.failTip(ctx, tip); err != nil {
+			return nil, err
+		}
+	case payment.StatusPending:
+		// Completed or failed later by HandlePaymentWebhook
+	}
+
+	view := model.NewTipView(tip, false)
+	return &view, nil
+}
+
+// ListSentTips returns the tips a listener has sent, newest first
+func (s *SocialService) ListSentTips(ctx context.Context, userID uint64, page pagination.Params) (*model.TipListResult, error) {
+	tips, total, err := s.repository.ListTipsSent(ctx, userID, page)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to list tips")
+	}
+
+	totals, err := s.repository.SumTipsSent(ctx, userID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to total tips")
+	}
+
+	return buildTipListResult(tips, totals, total, page, false), nil
+}
+
+// ListReceivedTips returns the tips sent to the artist owned by userID
+func (s *SocialService) ListReceivedTips(ctx context.Context, userID uint64, page pagination.Params) (*model.TipListResult, error) {
+	artist, err := s.musicService.GetArtistByUserID(ctx, userID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load artist")
+	}
+	if artist == nil {
+		return nil, appError.NewNotFoundError(nil, "artist profile not found")
+	}
+
+	tips, total, err := s.repository.ListTipsReceived(ctx, artist.ID, page)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to list tips")
+	}
+
+	totals, err := s.repository.SumTipsReceived(ctx, artist.ID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to total tips")
+	}
+
+	return buildTipListResult(tips, totals, total, page, true), nil
+}
+
+// completeTip marks a pending tip completed and applies its side effects: the ledger
+// posting, daily stats, the live dashboard event and the artist notification. It reports
+// false without side effects when the tip was no longer pending.
+func (s *SocialService) completeTip(ctx context.Context, tip *model.Tip, chargeID string) (bool, error) {
+	processedAt := time.Now().UTC()
+	completed, err := s.repository.MarkTipCompleted(ctx, tip.ID, chargeID, processedAt)
+	if err != nil {
+		return false, appError.NewInternalError(err, "failed to complete tip")
+	}
+	if !completed {
+		return false, nil
+	}
+	tip.Status = model.TipStatusCompleted
+	tip.StripeChargeID = chargeID
+	tip.ProcessedAt = &processedAt
+
+	// A failed posting is picked up by the payout job's backfill, so it must not fail the tip
+	if err := s.earningsService.RecordTip(ctx, &earningsModel.TipLedgerInput{
+		TipID:             tip.ID,
+		ArtistID:          tip.ToArtistID,
+		Currency:          tip.Currency,
+		AmountCents:       int64(tip.AmountCents),
+		PlatformFeeCents:  int64(tip.PlatformFeeCents),
+		ArtistPayoutCents: int64(tip.ArtistPayoutCents),
+	}); err != nil {
+		log.Printf("Failed to post tip %d to the earnings ledger: %v", tip.ID, err)
+	}
+	if err := s.analyticsService.RecordArtistStats(ctx, tip.ToArtistID, processedAt, &analyticsModel.ArtistStatsDelta{
+		TipCount:          1,
+		TipsReceivedCents: tip.ArtistPayoutCents,
+	}); err != nil {
+		log.Printf("Failed to record tip %d in daily artist stats: %v", tip.ID, err)
+	}
+	s.publishTipReceived(ctx, tip)
+	if err := s.tipNotifier.NotifyTipReceived(ctx, tip); err != nil {
+		log.Printf("Failed to notify artist %d about tip %d: %v", tip.ToArtistID, tip.ID, err)
+	}
+	return true, nil
+}
+
+// failTip marks a pending tip failed and reports whether it was still pending
+func (s *SocialService) failTip(ctx context.Context, tip *model.Tip) (bool, error) {
+	failed, err := s.repository.MarkTipFailed(ctx, tip.ID)
+	if err != nil {
+		return false, appError.NewInternalError(err, "failed to update tip")
+	}
+	if failed {
+		tip.Status = model.TipStatusFailed
+	}
+	return failed, nil
+}
+
+// applyPaymentResult completes or fails the tip behind a charge the provider settled
+// after SendTip returned. Tips that are no longer pending are left alone, so redelivered
+// events and charges SendTip already settled have no further effect.
+func (s *SocialService) applyPaymentResult(ctx context.Context, event *payment.WebhookEvent) error {
+	if event.PaymentIntentID == "" {
+		return appError.NewBadRequestError(nil, "event is missing payment reference")
+	}
+
+	tip, err := s.repository.GetTipByPaymentIntentID(ctx, event.PaymentIntentID)
+	if err != nil {
+		return appError.NewInternalError(err, "failed to load tip")
+	}
+	if tip == nil {
+		// SendTip may not have stored the payment yet; failing makes the provider redeliver
+		return appError.NewNotFoundError(nil, "payment not found")
+	}
+	if tip.Status != model.TipStatusPending {
+		return nil
+	}
+
+	if event.Type == payment.EventPaymentFailed {
+		_, err = s.failTip(ctx, tip)
+		return err
+	}
+	chargeID := event.ChargeID
+	if chargeID == "" {
+		chargeID = tip.StripeChargeID
+	}
+	_, err = s.completeTip(ctx, tip, chargeID)
+	return err
+}
+
+// publishTipReceived pushes the tip to the artist's live dashboard, hiding anonymous senders
+func (s *SocialService) publishTipReceived(ctx context.Context, tip *model.Tip) {
+	view := model.NewTipView(tip, true)
+	if err := s.realtimeService.PublishArtistEvent(ctx, tip.ToArtistID, realtimeModel.EventTipReceived, realtimeModel.TipReceivedData{
+		TipID:             tip.ID,
+		FromUserID:        view.FromUserID,
+		SongID:            tip.SongID,
+		AmountCents:       tip.AmountCents,
+		Currency:          tip.Currency,
+		ArtistPayoutCents: tip.ArtistPayoutCents,
+		Message:           tip.Message,
+	}); err != nil {
+		log.Printf("Failed to publish tip %d: %v", tip.ID, err)
+	}
+}
+
+func buildTipListResult(tips []model.Tip, totals []model.TipTotal, total int64, page pagination.Params, hideSender bool) *model.TipListResult {
+	views := make([]model.TipView, len(tips))
+	for i := range tips {
+		views[i] = model.NewTipView(&tips[i], hideSender)
+	}
+	if totals == nil {
+		totals = []model.TipTotal{}
+	}
+
+	return &model.TipListResult{
+		Tips:       views,
+		Totals:     totals,
+		Pagination: page.Meta(total),
+	}
+}
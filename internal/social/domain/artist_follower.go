@@ -2,12 +2,25 @@ package model
 
 import (
 	"music-app-backend/pkg/model"
+	"time"
 )
 
 type ArtistFollower struct {
 	model.BaseModel
-	ArtistID            uint64 `json:"artist_id" gorm:"not null;uniqueIndex:idx_artist_follower"`
-	FollowerUserID      uint64 `json:"follower_user_id" gorm:"not null;uniqueIndex:idx_artist_follower"`
-	NotificationEnabled bool   `json:"notification_enabled" gorm:"default:true"`
-	FollowedAt          int64  `json:"followed_at"`
+	ArtistID            uint64    `json:"artist_id" gorm:"not null;uniqueIndex:idx_artist_follower"`
+	FollowerUserID      uint64    `json:"follower_user_id" gorm:"not null;uniqueIndex:idx_artist_follower"`
+	NotificationEnabled bool      `json:"notification_enabled" gorm:"default:true"`
+	FollowedAt          time.Time `json:"followed_at"`
+}
+
+// ArtistFirstFollow remembers that a user followed an artist, even after they unfollow,
+// so only their first follow counts as a new follower
+type ArtistFirstFollow struct {
+	ArtistID        uint64    `gorm:"primaryKey"`
+	FollowerUserID  uint64    `gorm:"primaryKey"`
+	FirstFollowedAt time.Time `gorm:"not null"`
+}
+
+func (ArtistFirstFollow) TableName() string {
+	return "artist_first_follows"
 }
@@ -2,6 +2,7 @@ package model
 
 import (
 	"music-app-backend/pkg/model"
+	"time"
 )
 
 type TargetType string
@@ -12,12 +13,33 @@ const (
 	TargetTypeFollowers             TargetType = "followers"
 )
 
+func (t TargetType) IsValid() bool {
+	switch t {
+	case TargetTypeAllActiveListeners, TargetTypeSpecificSongListeners, TargetTypeFollowers:
+		return true
+	}
+	return false
+}
+
+type MessageStatus string
+
+const (
+	MessageStatusQueued  MessageStatus = "queued"
+	MessageStatusSending MessageStatus = "sending"
+	MessageStatusSent    MessageStatus = "sent"
+	MessageStatusFailed  MessageStatus = "failed"
+)
+
+// ArtistMessage is a broadcast from an artist. The audience is resolved when the fan-out
+// worker picks the message up, so "active listeners" means listeners at send time.
 type ArtistMessage struct {
 	model.BaseModel
-	ArtistID     uint64     `json:"artist_id" gorm:"not null"`
-	MessageText  string     `json:"message_text" gorm:"not null"`
-	TargetType   TargetType `json:"target_type" gorm:"not null;size:50"`
-	TargetSongID *uint64    `json:"target_song_id"`
-	SentToCount  int        `json:"sent_to_count" gorm:"default:0"`
-	ReadCount    int        `json:"read_count" gorm:"default:0"`
+	ArtistID     uint64        `json:"artist_id" gorm:"not null"`
+	MessageText  string        `json:"message_text" gorm:"not null"`
+	TargetType   TargetType    `json:"target_type" gorm:"not null;size:50"`
+	TargetSongID *uint64       `json:"target_song_id"`
+	SentToCount  int           `json:"sent_to_count" gorm:"default:0"`
+	ReadCount    int           `json:"read_count" gorm:"default:0"`
+	Status       MessageStatus `json:"status" gorm:"not null;size:20;default:queued"`
+	SentAt       *time.Time    `json:"sent_at"`
 }
@@ -0,0 +1,170 @@
+package model
+
+import (
+	"music-app-backend/pkg/pagination"
+	"time"
+)
+
+type SendTipDTO struct {
+	FromUserID  uint64
+	ToArtistID  uint64
+	SongID      *uint64
+	AmountCents int
+	Currency    string
+	Message     string
+	IsAnonymous bool
+}
+
+type TipFeeQuote struct {
+	AmountCents       int    `json:"amount_cents"`
+	Currency          string `json:"currency"`
+	PlatformFeeCents  int    `json:"platform_fee_cents"`
+	ArtistPayoutCents int    `json:"artist_payout_cents"`
+}
+
+// TipView is the shape returned by the listing endpoints. FromUserID is omitted
+// on the artist side when the tipper asked to stay anonymous.
+type TipView struct {
+	ID                uint64     `json:"id"`
+	FromUserID        *uint64    `json:"from_user_id,omitempty"`
+	ToArtistID        uint64     `json:"to_artist_id"`
+	SongID            *uint64    `json:"song_id"`
+	AmountCents       int        `json:"amount_cents"`
+	Currency          string     `json:"currency"`
+	PlatformFeeCents  int        `json:"platform_fee_cents"`
+	ArtistPayoutCents int        `json:"artist_payout_cents"`
+	Status            TipStatus  `json:"status"`
+	Message           string     `json:"message"`
+	IsAnonymous       bool       `json:"is_anonymous"`
+	ProcessedAt       *time.Time `json:"processed_at"`
+	RefundedCents     int        `json:"refunded_cents"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+// TipTotal aggregates charged tips for one currency. Amounts are gross; RefundedCents
+// is the part of AmountCents that was later refunded or charged back.
+type TipTotal struct {
+	Currency          string `json:"currency"`
+	TipCount          int64  `json:"tip_count"`
+	AmountCents       int64  `json:"amount_cents"`
+	PlatformFeeCents  int64  `json:"platform_fee_cents"`
+	ArtistPayoutCents int64  `json:"artist_payout_cents"`
+	RefundedCents     int64  `json:"refunded_cents"`
+}
+
+type TipListResult struct {
+	Tips       []TipView       `json:"tips"`
+	Totals     []TipTotal      `json:"totals"`
+	Pagination pagination.Meta `json:"pagination"`
+}
+
+func NewTipView(tip *Tip, hideSender bool) TipView {
+	view := TipView{
+		ID:                tip.ID,
+		ToArtistID:        tip.ToArtistID,
+		SongID:            tip.SongID,
+		AmountCents:       tip.AmountCents,
+		Currency:          tip.Currency,
+		PlatformFeeCents:  tip.PlatformFeeCents,
+		ArtistPayoutCents: tip.ArtistPayoutCents,
+		Status:            tip.Status,
+		Message:           tip.Message,
+		IsAnonymous:       tip.IsAnonymous,
+		ProcessedAt:       tip.ProcessedAt,
+		RefundedCents:     tip.RefundedCents,
+		CreatedAt:         tip.CreatedAt,
+	}
+	if !(hideSender && tip.IsAnonymous) {
+		fromUserID := tip.FromUserID
+		view.FromUserID = &fromUserID
+	}
+	return view
+}
+
+// RefundTipDTO requests a refund of AmountCents (zero means the remaining balance)
+type RefundTipDTO struct {
+	TipID            uint64
+	AmountCents      int
+	Kind             TipRefundKind
+	Source           TipRefundSource
+	Reason           string
+	ActorUserID      *uint64
+	ExternalRefundID string // Set when the provider already refunded, e.g. from a webhook
+}
+
+type TipRefundResult struct {
+	Tip    TipView    `json:"tip"`
+	Refund *TipRefund `json:"refund"`
+}
+
+type SendArtistMessageDTO struct {
+	ArtistUserID uint64
+	MessageText  string
+	TargetType   TargetType
+	TargetSongID *uint64
+}
+
+// InboxMessage is one delivered message as the listener sees it
+type InboxMessage struct {
+	MessageID    uint64     `json:"message_id"`
+	ArtistID     uint64     `json:"artist_id"`
+	MessageText  string     `json:"message_text"`
+	TargetType   TargetType `json:"target_type"`
+	TargetSongID *uint64    `json:"target_song_id"`
+	DeliveredAt  time.Time  `json:"delivered_at"`
+	ReadAt       *time.Time `json:"read_at"`
+}
+
+type InboxResult struct {
+	Messages    []InboxMessage  `json:"messages"`
+	UnreadCount int64           `json:"unread_count"`
+	Pagination  pagination.Meta `json:"pagination"`
+}
+
+type ArtistMessageListResult struct {
+	Messages   []ArtistMessage `json:"messages"`
+	Pagination pagination.Meta `json:"pagination"`
+}
+
+type FollowArtistDTO struct {
+	UserID              uint64
+	ArtistID            uint64
+	NotificationEnabled *bool // Nil keeps the current setting (enabled for a new follow)
+}
+
+type FollowResult struct {
+	Follow  *ArtistFollower `json:"follow"`
+	Created bool            `json:"created"`
+}
+
+// FollowedArtist is one row of the "artists I follow" list
+type FollowedArtist struct {
+	FollowID            uint64    `json:"follow_id"`
+	ArtistID            uint64    `json:"artist_id"`
+	ArtistName          string    `json:"artist_name"`
+	ProfileImageURL     *string   `json:"profile_image_url"`
+	IsVerified          bool      `json:"is_verified"`
+	FollowerCount       int       `json:"follower_count"`
+	NotificationEnabled bool      `json:"notification_enabled"`
+	FollowedAt          time.Time `json:"followed_at"`
+}
+
+// Follower is one row of an artist's follower list
+type Follower struct {
+	FollowID    uint64    `json:"follow_id"`
+	UserID      uint64    `json:"user_id"`
+	DisplayName string    `json:"display_name"`
+	AvatarURL   string    `json:"avatar_url"`
+	FollowedAt  time.Time `json:"followed_at"`
+}
+
+type FollowedArtistListResult struct {
+	Artists    []FollowedArtist      `json:"artists"`
+	Pagination pagination.CursorMeta `json:"pagination"`
+}
+
+type FollowerListResult struct {
+	Followers  []Follower            `json:"followers"`
+	Total      int                   `json:"total"`
+	Pagination pagination.CursorMeta `json:"pagination"`
+}
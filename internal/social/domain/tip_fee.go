@@ -0,0 +1,79 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// TipFeeRule describes how a tip in one currency is split between the platform and the artist
+type TipFeeRule struct {
+	Currency       string `json:"currency"`
+	PercentBps     int    `json:"percent_bps"` // Platform share in basis points (500 = 5%)
+	FixedCents     int    `json:"fixed_cents"` // Flat platform fee added on top of the percentage
+	MinAmountCents int    `json:"min_amount_cents"`
+	MaxAmountCents int    `json:"max_amount_cents"`
+}
+
+// TipFeeSchedule holds one rule per ISO 4217 currency code
+type TipFeeSchedule map[string]TipFeeRule
+
+// DefaultTipFeeSchedule applies the 5% platform fee from the MVP spec
+func DefaultTipFeeSchedule() TipFeeSchedule {
+	return TipFeeSchedule{
+		"USD": {Currency: "USD", PercentBps: 500, FixedCents: 0, MinAmountCents: 100, MaxAmountCents: 50000},
+		"EUR": {Currency: "EUR", PercentBps: 500, FixedCents: 0, MinAmountCents: 100, MaxAmountCents: 50000},
+		"GBP": {Currency: "GBP", PercentBps: 500, FixedCents: 0, MinAmountCents: 100, MaxAmountCents: 50000},
+	}
+}
+
+// NewTipFeeScheduleFromEnv reads TIP_FEE_RULES (a JSON array of rules) and falls back to the defaults
+func NewTipFeeScheduleFromEnv() (TipFeeSchedule, error) {
+	raw := os.Getenv("TIP_FEE_RULES")
+	if raw == "" {
+		return DefaultTipFeeSchedule(), nil
+	}
+
+	var rules []TipFeeRule
+	if err := json.Unmarshal([]byte(raw), &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse TIP_FEE_RULES: %w", err)
+	}
+
+	schedule := TipFeeSchedule{}
+	for _, rule := range rules {
+		rule.Currency = strings.ToUpper(rule.Currency)
+		if len(rule.Currency) != 3 {
+			return nil, fmt.Errorf("invalid currency in TIP_FEE_RULES: %q", rule.Currency)
+		}
+		if rule.PercentBps < 0 || rule.PercentBps > 10000 || rule.FixedCents < 0 {
+			return nil, fmt.Errorf("invalid fee rule for %s", rule.Currency)
+		}
+		schedule[rule.Currency] = rule
+	}
+
+	return schedule, nil
+}
+
+// Calculate splits amountCents into the platform fee and the artist payout.
+// The percentage is rounded half up and the fee never exceeds the tip itself.
+func (s TipFeeSchedule) Calculate(amountCents int, currency string) (platformFeeCents int, artistPayoutCents int, err error) {
+	rule, ok := s[strings.ToUpper(currency)]
+	if !ok {
+		return 0, 0, fmt.Errorf("unsupported currency: %s", currency)
+	}
+
+	if amountCents < rule.MinAmountCents {
+		return 0, 0, fmt.Errorf("tip must be at least %d cents", rule.MinAmountCents)
+	}
+	if rule.MaxAmountCents > 0 && amountCents > rule.MaxAmountCents {
+		return 0, 0, fmt.Errorf("tip must be at most %d cents", rule.MaxAmountCents)
+	}
+
+	platformFeeCents = (amountCents*rule.PercentBps+5000)/10000 + rule.FixedCents
+	if platformFeeCents > amountCents {
+		platformFeeCents = amountCents
+	}
+
+	return platformFeeCents, amountCents - platformFeeCents, nil
+}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"music-app-backend/pkg/model"
+)
+
+type TipRefundKind string
+
+const (
+	TipRefundKindRefund     TipRefundKind = "refund"
+	TipRefundKindChargeback TipRefundKind = "chargeback"
+)
+
+type TipRefundSource string
+
+const (
+	TipRefundSourceAdmin   TipRefundSource = "admin"
+	TipRefundSourceWebhook TipRefundSource = "webhook"
+)
+
+// An admin refund is recorded as pending before the provider is asked to pay it out,
+// which reserves its amount; webhook refunds are recorded as succeeded right away.
+type TipRefundStatus string
+
+const (
+	TipRefundStatusPending   TipRefundStatus = "pending"
+	TipRefundStatusSucceeded TipRefundStatus = "succeeded"
+	TipRefundStatusFailed    TipRefundStatus = "failed"
+)
+
+// TipRefund records one full or partial reversal of a tip. The refunded amount is split
+// between platform fee and artist payout in the same proportion as the original tip.
+type TipRefund struct {
+	model.BaseModel
+	TipID             uint64          `json:"tip_id" gorm:"not null;index"`
+	AmountCents       int             `json:"amount_cents" gorm:"not null"`
+	Currency          string          `json:"currency" gorm:"size:3;not null"`
+	PlatformFeeCents  int             `json:"platform_fee_cents" gorm:"not null"`
+	ArtistPayoutCents int             `json:"artist_payout_cents" gorm:"not null"`
+	Kind              TipRefundKind   `json:"kind" gorm:"size:20;not null"`
+	Source            TipRefundSource `json:"source" gorm:"size:20;not null"`
+	Reason            string          `json:"reason" gorm:"not null"`
+	ActorUserID       *uint64         `json:"actor_user_id"`
+	ExternalRefundID  *string         `json:"external_refund_id" gorm:"unique;size:100"`
+	FullyRefunded     bool            `json:"fully_refunded" gorm:"not null;default:false"`
+	Status            TipRefundStatus `json:"status" gorm:"size:20;not null;default:succeeded"`
+	LastError         *string         `json:"-"`
+}
@@ -2,30 +2,38 @@ package model
 
 import (
 	"music-app-backend/pkg/model"
+	"time"
 )
 
 type TipStatus string
 
 const (
-	TipStatusPending   TipStatus = "pending"
-	TipStatusCompleted TipStatus = "completed"
-	TipStatusFailed    TipStatus = "failed"
-	TipStatusRefunded  TipStatus = "refunded"
+	TipStatusPending           TipStatus = "pending"
+	TipStatusCompleted         TipStatus = "completed"
+	TipStatusFailed            TipStatus = "failed"
+	TipStatusPartiallyRefunded TipStatus = "partially_refunded"
+	TipStatusRefunded          TipStatus = "refunded"
 )
 
 type Tip struct {
 	model.BaseModel
-	FromUserID            uint64    `json:"from_user_id" gorm:"not null"`
-	ToArtistID            uint64    `json:"to_artist_id" gorm:"not null"`
-	SongID                *uint64   `json:"song_id"`
-	AmountCents           int       `json:"amount_cents" gorm:"not null"`
-	Currency              string    `json:"currency" gorm:"default:'USD';size:3"`
-	StripePaymentIntentID string    `json:"stripe_payment_intent_id" gorm:"unique;size:100"`
-	StripeChargeID        string    `json:"stripe_charge_id" gorm:"size:100"`
-	PlatformFeeCents      int       `json:"platform_fee_cents" gorm:"not null"`
-	ArtistPayoutCents     int       `json:"artist_payout_cents" gorm:"not null"`
-	Status                TipStatus `json:"status" gorm:"default:'pending';size:50"`
-	Message               string    `json:"message"`
-	IsAnonymous           bool      `json:"is_anonymous" gorm:"default:false"`
-	ProcessedAt           *int64    `json:"processed_at"`
+	FromUserID            uint64     `json:"from_user_id" gorm:"not null"`
+	ToArtistID            uint64     `json:"to_artist_id" gorm:"not null"`
+	SongID                *uint64    `json:"song_id"`
+	AmountCents           int        `json:"amount_cents" gorm:"not null"`
+	Currency              string     `json:"currency" gorm:"default:'USD';size:3"`
+	StripePaymentIntentID *string    `json:"stripe_payment_intent_id" gorm:"unique;size:100"` // Unset until the charge is created
+	StripeChargeID        string     `json:"stripe_charge_id" gorm:"size:100"`
+	PlatformFeeCents      int        `json:"platform_fee_cents" gorm:"not null"`
+	ArtistPayoutCents     int        `json:"artist_payout_cents" gorm:"not null"`
+	Status                TipStatus  `json:"status" gorm:"default:'pending';size:50"`
+	Message               string     `json:"message"`
+	IsAnonymous           bool       `json:"is_anonymous" gorm:"default:false"`
+	ProcessedAt           *time.Time `json:"processed_at"`
+	RefundedCents         int        `json:"refunded_cents" gorm:"not null;default:0"`
+}
+
+// IsRefundable reports whether part of the tip can still be refunded
+func (t *Tip) IsRefundable() bool {
+	return (t.Status == TipStatusCompleted || t.Status == TipStatusPartiallyRefunded) && t.RefundedCents < t.AmountCents
 }
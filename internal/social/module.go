@@ -1,26 +1,122 @@
 package social
 
 import (
+	"context"
+	"log"
+	analyticsModuleSvc "music-app-backend/internal/analytics/application"
+	earningsModuleSvc "music-app-backend/internal/earnings/application"
+	musicModuleSvc "music-app-backend/internal/music/application"
+	notificationModuleSvc "music-app-backend/internal/notification/application"
+	realtimeModuleSvc "music-app-backend/internal/realtime/application"
+	"music-app-backend/internal/social/adapters/http"
 	"music-app-backend/internal/social/adapters/repository"
 	"music-app-backend/internal/social/application"
+	model "music-app-backend/internal/social/domain"
+	ctx2 "music-app-backend/pkg/context"
+	"music-app-backend/pkg/middleware"
+	"music-app-backend/pkg/payment"
+	"music-app-backend/pkg/ratelimit"
+	"os"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
 type SocialModule struct {
 	Repository *repository.SocialRepository
 	Service    *application.SocialService
+	Handler    *http.SocialHandler
+
+	authMiddleware *middleware.AuthMiddleware
+	webhookSecret  string
 }
 
-func NewSocialModule(db *gorm.DB) *SocialModule {
-	socialRepo := repository.NewSocialRepository(db)
-	socialService := application.NewSocialService(socialRepo)
+func NewSocialModule(serviceContext *ctx2.ServiceContext, authMiddleware *middleware.AuthMiddleware, musicService musicModuleSvc.IMusicService, earningsService earningsModuleSvc.IEarningsService, analyticsService analyticsModuleSvc.IAnalyticsService, realtimeService realtimeModuleSvc.IRealtimeService, notificationService notificationModuleSvc.INotificationService) *SocialModule {
+	paymentProvider, err := payment.NewProviderFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to initialize payment provider: %v", err)
+	}
+
+	tipFees, err := model.NewTipFeeScheduleFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load tip fee rules: %v", err)
+	}
+
+	// Broadcasts per artist (ARTIST_MESSAGE_LIMIT_PER_HOUR / _PER_DAY, default 5 and 20)
+	messageLimiter := ratelimit.NewLimiter(serviceContext.GetRedisClient(), "artist_messages",
+		ratelimit.Limit{Max: intFromEnv("ARTIST_MESSAGE_LIMIT_PER_HOUR", 5), Window: time.Hour},
+		ratelimit.Limit{Max: intFromEnv("ARTIST_MESSAGE_LIMIT_PER_DAY", 20), Window: 24 * time.Hour},
+	)
+
+	socialRepo := repository.NewSocialRepository(serviceContext.GetDB())
+	socialService := application.NewSocialService(socialRepo, serviceContext.GetIDGenerator(), musicService, earningsService, analyticsService, realtimeService, notificationService, paymentProvider, tipFees, serviceContext.GetRedisClient(), messageLimiter, serviceContext.GetAuditLog())
+	socialHandler := http.NewSocialHandler(socialService)
+
+	webhookSecret := os.Getenv("PAYMENT_WEBHOOK_SECRET")
+	if webhookSecret == "" {
+		log.Println("PAYMENT_WEBHOOK_SECRET is not set; payment webhooks will be rejected")
+	}
 
 	return &SocialModule{
-		Repository: socialRepo,
-		Service:    socialService,
+		Repository:     socialRepo,
+		Service:        socialService,
+		Handler:        socialHandler,
+		authMiddleware: authMiddleware,
+		webhookSecret:  webhookSecret,
+	}
+}
+
+func (s *SocialModule) RegisterRoutes(router *gin.RouterGroup) {
+	tips := router.Group("/tips")
+	tips.Use(s.authMiddleware.RequireAuth())
+	{
+		tips.POST("", s.Handler.SendTip)
+		tips.GET("/quote", s.Handler.QuoteTip)
+		tips.GET("/sent", s.Handler.ListSentTips)
+		tips.GET("/received", s.authMiddleware.RequireArtist(), s.Handler.ListReceivedTips)
+		tips.POST("/:tip_id/refunds", s.authMiddleware.RequireAdmin(), s.authMiddleware.RequirePermission(middleware.PermissionRefundTips), s.Handler.RefundTip)
+		tips.GET("/:tip_id/refunds", s.authMiddleware.RequireAdmin(), s.authMiddleware.RequirePermission(middleware.PermissionRefundTips), s.Handler.ListTipRefunds)
 	}
+
+	messages := router.Group("/messages")
+	messages.Use(s.authMiddleware.RequireAuth())
+	{
+		messages.POST("", s.authMiddleware.RequireArtist(), s.Handler.SendArtistMessage)
+		messages.GET("/sent", s.authMiddleware.RequireArtist(), s.Handler.ListSentMessages)
+		messages.GET("/inbox", s.Handler.ListInbox)
+		messages.POST("/:message_id/read", s.Handler.MarkMessageRead)
+	}
+
+	artists := router.Group("/artists")
+	artists.Use(s.authMiddleware.RequireAuth())
+	{
+		artists.POST("/:artist_id/follow", s.Handler.FollowArtist)
+		artists.DELETE("/:artist_id/follow", s.Handler.UnfollowArtist)
+		artists.PATCH("/:artist_id/follow", s.Handler.SetFollowNotifications)
+	}
+
+	me := router.Group("/me")
+	me.Use(s.authMiddleware.RequireAuth())
+	{
+		me.GET("/following", s.Handler.ListFollowedArtists)
+		me.GET("/followers", s.authMiddleware.RequireArtist(), s.Handler.ListFollowers)
+	}
+
+	// Called by the payment provider; authenticated by HMAC signature instead of a session
+	router.POST("/webhooks/payments", s.Handler.PaymentWebhook(s.webhookSecret))
+}
+
+// StartWorkers launches the artist message fan-out (ARTIST_MESSAGE_WORKERS, default 2)
+func (s *SocialModule) StartWorkers(ctx context.Context) {
+	s.Service.StartMessageWorkers(ctx, int(intFromEnv("ARTIST_MESSAGE_WORKERS", 2)), time.Minute)
 }
 
-func (s *SocialModule) RegisterRoutes(router *gin.RouterGroup) {}
+func intFromEnv(key string, defaultValue int64) int64 {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseInt(value, 10, 64); err == nil && parsed > 0 {
+			return parsed
+		}
+	}
+	return defaultValue
+}
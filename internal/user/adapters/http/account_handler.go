@@ -0,0 +1,146 @@
+package http
+
+import (
+	"io"
+	model "music-app-backend/internal/user/domain"
+	json_response "music-app-backend/pkg/json"
+	"music-app-backend/pkg/pagination"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestAccountDeletion schedules the account for deletion after the grace period. The
+// body, with an optional reason, may be omitted.
+func (h *UserHandler) RequestAccountDeletion(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		json_response.ResponseUnauthorized(c)
+		return
+	}
+
+	var request model.RequestAccountDeletionDTO
+	if err := c.ShouldBindJSON(&request); err != nil && err != io.EOF {
+		json_response.ResponseBadRequest(c, err.Error())
+		return
+	}
+
+	deletion, err := h.userService.RequestAccountDeletion(c.Request.Context(), userID.(uint64), &request)
+	if h.HandleError(c, err) {
+		return
+	}
+
+	json_response.ResponseCreated(c, deletion)
+}
+
+// GetAccountDeletion shows when the account will be deleted
+func (h *UserHandler) GetAccountDeletion(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		json_response.ResponseUnauthorized(c)
+		return
+	}
+
+	deletion, err := h.userService.GetAccountDeletion(c.Request.Context(), userID.(uint64))
+	if h.HandleError(c, err) {
+		return
+	}
+
+	json_response.ResponseOK(c, deletion)
+}
+
+// CancelAccountDeletion keeps the account while the grace period lasts
+func (h *UserHandler) CancelAccountDeletion(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		json_response.ResponseUnauthorized(c)
+		return
+	}
+
+	if h.HandleError(c, h.userService.CancelAccountDeletion(c.Request.Context(), userID.(uint64))) {
+		return
+	}
+
+	json_response.ResponseOK(c, gin.H{"cancelled": true})
+}
+
+// ListAccountDeletions lists deletion requests for operators, filtered by ?status=,
+// failed by default
+func (h *UserHandler) ListAccountDeletions(c *gin.Context) {
+	status := model.AccountDeletionStatus(c.Query("status"))
+	result, err := h.userService.ListAccountDeletions(c.Request.Context(), status, pagination.FromQuery(c))
+	if h.HandleError(c, err) {
+		return
+	}
+
+	json_response.ResponseOK(c, result)
+}
+
+// RetryAccountDeletion queues a failed deletion again
+func (h *UserHandler) RetryAccountDeletion(c *gin.Context) {
+	deletionID, err := strconv.ParseUint(c.Param("deletion_id"), 10, 64)
+	if err != nil {
+		json_response.ResponseBadRequest(c, "Invalid deletion ID")
+		return
+	}
+
+	deletion, err := h.userService.RetryAccountDeletion(c.Request.Context(), deletionID)
+	if h.HandleError(c, err) {
+		return
+	}
+
+	json_response.ResponseOK(c, deletion)
+}
+
+// RequestDataExport queues an archive of the user's personal data
+func (h *UserHandler) RequestDataExport(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		json_response.ResponseUnauthorized(c)
+		return
+	}
+
+	export, err := h.userService.RequestDataExport(c.Request.Context(), userID.(uint64))
+	if h.HandleError(c, err) {
+		return
+	}
+
+	json_response.ResponseCreated(c, export)
+}
+
+func (h *UserHandler) ListDataExports(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		json_response.ResponseUnauthorized(c)
+		return
+	}
+
+	exports, err := h.userService.ListDataExports(c.Request.Context(), userID.(uint64))
+	if h.HandleError(c, err) {
+		return
+	}
+
+	json_response.ResponseOK(c, exports)
+}
+
+// GetDataExport returns the export with a download link once it is completed
+func (h *UserHandler) GetDataExport(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		json_response.ResponseUnauthorized(c)
+		return
+	}
+
+	exportID, err := strconv.ParseUint(c.Param("export_id"), 10, 64)
+	if err != nil {
+		json_response.ResponseBadRequest(c, "Invalid export ID")
+		return
+	}
+
+	export, err := h.userService.GetDataExport(c.Request.Context(), userID.(uint64), exportID)
+	if h.HandleError(c, err) {
+		return
+	}
+
+	json_response.ResponseOK(c, export)
+}
@@ -1,10 +1,14 @@
 package http
 
 import (
+	"errors"
+	"log"
 	user_service "music-app-backend/internal/user/application"
 	model "music-app-backend/internal/user/domain"
 	app_error "music-app-backend/pkg/error"
 	json_response "music-app-backend/pkg/json"
+	"music-app-backend/pkg/kratos"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
 )
@@ -33,18 +37,74 @@ func (h *UserHandler) HandleError(c *gin.Context, err error) bool {
 	return true
 }
 
+// AfterRegistration is the Kratos registration web_hook. Failures are answered in Kratos'
+// webhook error format so the registration form shows them next to the right field.
 func (h *UserHandler) AfterRegistration(c *gin.Context) {
 	var request model.AfterRegistrationRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		json_response.ResponseBadRequest(c, err.Error())
+		c.JSON(http.StatusBadRequest, kratos.NewWebhookError("#/", kratos.WebhookMessageInvalid, "invalid registration payload"))
 		return
 	}
 
-	userID, err := h.userService.CreateUserAfterRegistration(&request)
+	userID, err := h.userService.CreateUserAfterRegistration(c.Request.Context(), &request)
 	if err != nil {
-		h.HandleError(c, err)
+		var registrationErr *model.RegistrationError
+		if errors.As(err, &registrationErr) {
+			instancePtr := "#/"
+			if registrationErr.Field != "" {
+				instancePtr = "#/traits/" + registrationErr.Field
+			}
+			c.JSON(http.StatusBadRequest, kratos.NewWebhookError(instancePtr, kratos.WebhookMessageInvalid, registrationErr.Message))
+			return
+		}
+		log.Printf("Failed to create user for identity %s: %v", request.Identity.ID, err)
+		c.JSON(http.StatusInternalServerError, kratos.NewWebhookError("#/", kratos.WebhookMessageInternal, "registration could not be completed, please try again"))
 		return
 	}
 
 	json_response.ResponseOK(c, userID)
 }
+
+// AfterSettings is the Kratos settings web_hook: it syncs the identity's email and display
+// name into the users table
+func (h *UserHandler) AfterSettings(c *gin.Context) {
+	var request model.IdentityHook
+	if err := c.ShouldBindJSON(&request); err != nil {
+		json_response.ResponseBadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	if h.HandleError(c, h.userService.SyncIdentity(c.Request.Context(), request.IdentityID)) {
+		return
+	}
+
+	json_response.ResponseOK(c, gin.H{"synced": true})
+}
+
+// CheckUnsubscribe shows which emails an unsubscribe link covers without applying it
+func (h *UserHandler) CheckUnsubscribe(c *gin.Context) {
+	result, err := h.userService.CheckUnsubscribe(c.Query("token"))
+	if err != nil {
+		h.HandleError(c, err)
+		return
+	}
+
+	json_response.ResponseOK(c, result)
+}
+
+// Unsubscribe applies an unsubscribe link. Mail clients send one-click requests
+// (RFC 8058) as a form post to the List-Unsubscribe URL, so the token comes from the query.
+func (h *UserHandler) Unsubscribe(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		token = c.PostForm("token")
+	}
+
+	result, err := h.userService.Unsubscribe(c.Request.Context(), token)
+	if err != nil {
+		h.HandleError(c, err)
+		return
+	}
+
+	json_response.ResponseOK(c, result)
+}
@@ -0,0 +1,46 @@
+package http
+
+import (
+	model "music-app-backend/internal/user/domain"
+	json_response "music-app-backend/pkg/json"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetPreferences returns the current user's playback, content and notification settings
+func (h *UserHandler) GetPreferences(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		json_response.ResponseUnauthorized(c)
+		return
+	}
+
+	preference, err := h.userService.GetPreferences(c.Request.Context(), userID.(uint64))
+	if h.HandleError(c, err) {
+		return
+	}
+
+	json_response.ResponseOK(c, preference)
+}
+
+// UpdatePreferences changes only the settings present in the body
+func (h *UserHandler) UpdatePreferences(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		json_response.ResponseUnauthorized(c)
+		return
+	}
+
+	var request model.UpdatePreferencesDTO
+	if err := c.ShouldBindJSON(&request); err != nil {
+		json_response.ResponseBadRequest(c, err.Error())
+		return
+	}
+
+	preference, err := h.userService.UpdatePreferences(c.Request.Context(), userID.(uint64), &request)
+	if h.HandleError(c, err) {
+		return
+	}
+
+	json_response.ResponseOK(c, preference)
+}
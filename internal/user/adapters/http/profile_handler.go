@@ -0,0 +1,104 @@
+package http
+
+import (
+	model "music-app-backend/internal/user/domain"
+	json_response "music-app-backend/pkg/json"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *UserHandler) GetProfile(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		json_response.ResponseUnauthorized(c)
+		return
+	}
+
+	user, err := h.userService.GetProfile(c.Request.Context(), userID.(uint64))
+	if h.HandleError(c, err) {
+		return
+	}
+
+	json_response.ResponseOK(c, user)
+}
+
+// UpdateProfile changes only the fields present in the body
+func (h *UserHandler) UpdateProfile(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		json_response.ResponseUnauthorized(c)
+		return
+	}
+
+	var request model.UpdateProfileDTO
+	if err := c.ShouldBindJSON(&request); err != nil {
+		json_response.ResponseBadRequest(c, err.Error())
+		return
+	}
+
+	user, err := h.userService.UpdateProfile(c.Request.Context(), userID.(uint64), &request)
+	if h.HandleError(c, err) {
+		return
+	}
+
+	json_response.ResponseOK(c, user)
+}
+
+// CreateAvatarUploadURL returns the form upload for the new avatar image
+func (h *UserHandler) CreateAvatarUploadURL(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		json_response.ResponseUnauthorized(c)
+		return
+	}
+
+	var request model.AvatarUploadRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		json_response.ResponseBadRequest(c, err.Error())
+		return
+	}
+
+	upload, err := h.userService.CreateAvatarUploadURL(c.Request.Context(), userID.(uint64), &request)
+	if h.HandleError(c, err) {
+		return
+	}
+
+	json_response.ResponseCreated(c, upload)
+}
+
+// ConfirmAvatar makes an uploaded image the avatar once it passes validation
+func (h *UserHandler) ConfirmAvatar(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		json_response.ResponseUnauthorized(c)
+		return
+	}
+
+	var request model.ConfirmAvatarRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		json_response.ResponseBadRequest(c, err.Error())
+		return
+	}
+
+	user, err := h.userService.ConfirmAvatar(c.Request.Context(), userID.(uint64), request.ObjectKey)
+	if h.HandleError(c, err) {
+		return
+	}
+
+	json_response.ResponseOK(c, user)
+}
+
+func (h *UserHandler) RemoveAvatar(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		json_response.ResponseUnauthorized(c)
+		return
+	}
+
+	user, err := h.userService.RemoveAvatar(c.Request.Context(), userID.(uint64))
+	if h.HandleError(c, err) {
+		return
+	}
+
+	json_response.ResponseOK(c, user)
+}
@@ -0,0 +1,254 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	model "music-app-backend/internal/user/domain"
+	"music-app-backend/pkg/pagination"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// openDeletionStatuses are the statuses of a request that has not finished
+var openDeletionStatuses = []model.AccountDeletionStatus{
+	model.AccountDeletionPending,
+	model.AccountDeletionProcessing,
+	model.AccountDeletionFailed,
+}
+
+func (r *UserRepository) CreateAccountDeletion(ctx context.Context, deletion *model.AccountDeletion) error {
+	return r.db.WithContext(ctx).Create(deletion).Error
+}
+
+// GetOpenAccountDeletion returns the user's unfinished deletion request, if any
+func (r *UserRepository) GetOpenAccountDeletion(ctx context.Context, userID uint64) (*model.AccountDeletion, error) {
+	var deletion model.AccountDeletion
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND status IN ?", userID, openDeletionStatuses).
+		First(&deletion).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &deletion, nil
+}
+
+// CancelAccountDeletion cancels the request while it is still pending; it reports false
+// when the request already started
+func (r *UserRepository) CancelAccountDeletion(ctx context.Context, deletionID uint64) (bool, error) {
+	now := time.Now().UTC()
+	result := r.db.WithContext(ctx).Model(&model.AccountDeletion{}).
+		Where("id = ? AND status = ?", deletionID, model.AccountDeletionPending).
+		Updates(map[string]interface{}{
+			"status":       model.AccountDeletionCancelled,
+			"cancelled_at": now,
+			"updated_at":   now,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// ListDueAccountDeletions returns requests whose grace period is over and that may be
+// (re)tried: pending, failed with attempts left, or stuck in processing since before
+// staleBefore
+func (r *UserRepository) ListDueAccountDeletions(ctx context.Context, now, staleBefore time.Time, maxAttempts, limit int) ([]model.AccountDeletion, error) {
+	var deletions []model.AccountDeletion
+	err := r.db.WithContext(ctx).
+		Where("scheduled_for <= ? AND attempts < ?", now, maxAttempts).
+		Where("status IN ? OR (status = ? AND updated_at < ?)",
+			[]model.AccountDeletionStatus{model.AccountDeletionPending, model.AccountDeletionFailed},
+			model.AccountDeletionProcessing, staleBefore).
+		Order("scheduled_for ASC").
+		Limit(limit).
+		Find(&deletions).Error
+	return deletions, err
+}
+
+// ClaimAccountDeletion moves the request to processing unless someone else changed it
+// since it was listed
+func (r *UserRepository) ClaimAccountDeletion(ctx context.Context, deletion *model.AccountDeletion) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&model.AccountDeletion{}).
+		Where("id = ? AND status = ? AND attempts = ?", deletion.ID, deletion.Status, deletion.Attempts).
+		Updates(map[string]interface{}{
+			"status":     model.AccountDeletionProcessing,
+			"attempts":   gorm.Expr("attempts + 1"),
+			"updated_at": time.Now().UTC(),
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *UserRepository) FailAccountDeletion(ctx context.Context, deletionID uint64, cause error) error {
+	return r.db.WithContext(ctx).Model(&model.AccountDeletion{}).
+		Where("id = ?", deletionID).
+		Updates(map[string]interface{}{
+			"status":     model.AccountDeletionFailed,
+			"last_error": cause.Error(),
+			"updated_at": time.Now().UTC(),
+		}).Error
+}
+
+// ListAccountDeletions returns the requests in status, oldest first
+func (r *UserRepository) ListAccountDeletions(ctx context.Context, status model.AccountDeletionStatus, page pagination.Params) ([]model.AccountDeletion, int64, error) {
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&model.AccountDeletion{}).Where("status = ?", status).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var deletions []model.AccountDeletion
+	err := r.db.WithContext(ctx).
+		Where("status = ?", status).
+		Order("scheduled_for ASC, id ASC").
+		Offset(page.Offset()).
+		Limit(page.Limit).
+		Find(&deletions).Error
+	return deletions, total, err
+}
+
+func (r *UserRepository) GetAccountDeletionByID(ctx context.Context, deletionID uint64) (*model.AccountDeletion, error) {
+	var deletion model.AccountDeletion
+	err := r.db.WithContext(ctx).Where("id = ?", deletionID).First(&deletion).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &deletion, nil
+}
+
+// RetryAccountDeletion puts a failed request back in the queue with its attempts reset;
+// it reports false when the request is not failed
+func (r *UserRepository) RetryAccountDeletion(ctx context.Context, deletionID uint64) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&model.AccountDeletion{}).
+		Where("id = ? AND status = ?", deletionID, model.AccountDeletionFailed).
+		Updates(map[string]interface{}{
+			"status":     model.AccountDeletionPending,
+			"attempts":   0,
+			"updated_at": time.Now().UTC(),
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// ListProcessedObjectPaths returns the processed-tracks objects of the artist's songs
+func (r *UserRepository) ListProcessedObjectPaths(ctx context.Context, artistID uint64) ([]string, error) {
+	var paths []string
+	err := r.db.WithContext(ctx).
+		Table("processed_audio_formats").
+		Where("song_id IN (SELECT id FROM songs WHERE artist_id = ?)", artistID).
+		Pluck("object_path", &paths).Error
+	return paths, err
+}
+
+type erasureStep struct {
+	table      string
+	anonymizes bool // false: the statement deletes rows
+	sql        string
+	args       []interface{}
+}
+
+// EraseAccount deletes or anonymizes the personal data of the user, and of their artist
+// when artistID is set, and completes the deletion request with report, all in one
+// transaction. Financial records are kept with the person unlinked.
+func (r *UserRepository) EraseAccount(ctx context.Context, deletionID, userID uint64, artistID *uint64, report *model.AccountDeletionReport) error {
+	now := time.Now().UTC()
+	steps := []erasureStep{
+		{table: "user_preferences", sql: "DELETE FROM user_preferences WHERE user_id = ?", args: []interface{}{userID}},
+		{table: "user_favorites", sql: "DELETE FROM user_favorites WHERE user_id = ?", args: []interface{}{userID}},
+		{table: "artist_followers", sql: "DELETE FROM artist_followers WHERE follower_user_id = ?", args: []interface{}{userID}},
+		{table: "artist_first_follows", sql: "DELETE FROM artist_first_follows WHERE follower_user_id = ?", args: []interface{}{userID}},
+		{table: "notification_mutes", sql: "DELETE FROM notification_mutes WHERE user_id = ?", args: []interface{}{userID}},
+		{table: "notifications", sql: "DELETE FROM notifications WHERE user_id = ?", args: []interface{}{userID}},
+		{table: "message_deliveries", sql: "DELETE FROM message_deliveries WHERE user_id = ?", args: []interface{}{userID}},
+		{table: "upload_sessions", sql: "DELETE FROM upload_sessions WHERE user_id = ?", args: []interface{}{userID}},
+		{table: "playlist_songs", sql: `DELETE FROM playlist_songs WHERE playlist_id IN
+			(SELECT id FROM playlists WHERE created_by_user_id = ? AND playlist_type = 'user_created')`, args: []interface{}{userID}},
+		{table: "playlists", sql: "DELETE FROM playlists WHERE created_by_user_id = ? AND playlist_type = 'user_created'", args: []interface{}{userID}},
+		{table: "auth_refresh_tokens", sql: "DELETE FROM auth_refresh_tokens WHERE session_id IN (SELECT id FROM auth_sessions WHERE user_id = ?)", args: []interface{}{userID}},
+		{table: "auth_sessions", sql: "DELETE FROM auth_sessions WHERE user_id = ?", args: []interface{}{userID}},
+		{table: "data_exports", sql: "DELETE FROM data_exports WHERE user_id = ?", args: []interface{}{userID}},
+
+		{table: "playlist_songs", anonymizes: true, sql: "UPDATE playlist_songs SET added_by_user_id = NULL WHERE added_by_user_id = ?", args: []interface{}{userID}},
+		{table: "playlists", anonymizes: true, sql: "UPDATE playlists SET created_by_user_id = NULL, updated_at = ? WHERE created_by_user_id = ?", args: []interface{}{now, userID}},
+		// The client ID is replaced too, it would link the sessions to the device
+		{table: "listening_sessions", anonymizes: true, sql: `UPDATE listening_sessions
+			SET user_id = NULL, city = NULL, session_id = 'erased-' || id::text, is_active = false, updated_at = ?
+			WHERE user_id = ?`, args: []interface{}{now, userID}},
+		{table: "song_plays", anonymizes: true, sql: `UPDATE song_plays
+			SET user_id = NULL, ip_address = NULL, user_agent = NULL, city = NULL
+			WHERE user_id = ?`, args: []interface{}{userID}},
+		// Kept for accounting; 0 stands for an erased tipper
+		{table: "tips", anonymizes: true, sql: `UPDATE tips
+			SET from_user_id = 0, message = NULL, is_anonymous = true, updated_at = ?
+			WHERE from_user_id = ?`, args: []interface{}{now, userID}},
+	}
+	report.Retained = map[string]string{
+		"tips":      "amounts kept for accounting with the tipper unlinked",
+		"audit_log": "append-only security record; keeps the user ID with the IP address and user agent of their past requests",
+	}
+
+	if artistID != nil {
+		steps = append(steps,
+			erasureStep{table: "artist_followers", sql: "DELETE FROM artist_followers WHERE artist_id = ?", args: []interface{}{*artistID}},
+			erasureStep{table: "artist_first_follows", sql: "DELETE FROM artist_first_follows WHERE artist_id = ?", args: []interface{}{*artistID}},
+			erasureStep{table: "message_deliveries", sql: "DELETE FROM message_deliveries WHERE message_id IN (SELECT id FROM artist_messages WHERE artist_id = ?)", args: []interface{}{*artistID}},
+			erasureStep{table: "artist_messages", sql: "DELETE FROM artist_messages WHERE artist_id = ?", args: []interface{}{*artistID}},
+			erasureStep{table: "artist_verification_requests", sql: "DELETE FROM artist_verification_requests WHERE artist_id = ?", args: []interface{}{*artistID}},
+			erasureStep{table: "upload_sessions", sql: "DELETE FROM upload_sessions WHERE artist_id = ?", args: []interface{}{*artistID}},
+			erasureStep{table: "processed_audio_formats", sql: "DELETE FROM processed_audio_formats WHERE song_id IN (SELECT id FROM songs WHERE artist_id = ?)", args: []interface{}{*artistID}},
+
+			// Songs stay for play and earnings history but are never served again
+			erasureStep{table: "songs", anonymizes: true, sql: `UPDATE songs
+				SET is_active = false, taken_down_at = COALESCE(taken_down_at, ?), takedown_reason = 'account_deleted', updated_at = ?
+				WHERE artist_id = ?`, args: []interface{}{now, now, *artistID}},
+			erasureStep{table: "artists", anonymizes: true, sql: `UPDATE artists
+				SET artist_name = 'Deleted artist', bio = NULL, profile_image_url = NULL, banner_image_url = NULL,
+					website_url = NULL, spotify_url = NULL, instagram_url = NULL, twitter_url = NULL, youtube_url = NULL,
+					is_verified = false, verification_requested_at = NULL, updated_at = ?
+				WHERE id = ?`, args: []interface{}{now, *artistID}},
+		)
+		report.Retained["ledger_transactions"] = "earnings and payout bookkeeping kept for accounting"
+		report.Retained["payouts"] = "kept for accounting"
+	}
+
+	steps = append(steps, erasureStep{table: "users", anonymizes: true, sql: `UPDATE users
+		SET email = ?, display_name = 'Deleted user', avatar_url = '', bio = NULL, location = NULL,
+			kratos_identity_id = ?, is_active = false, identity_state = ?,
+			identity_deleted_at = COALESCE(identity_deleted_at, ?), updated_at = ?
+		WHERE id = ?`,
+		args: []interface{}{fmt.Sprintf("deleted-%d@deleted.invalid", userID), uuid.New(), model.IdentityStateDeleted, now, now, userID}})
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		report.RowsDeleted = map[string]int64{}
+		report.RowsAnonymized = map[string]int64{}
+		for _, step := range steps {
+			result := tx.Exec(step.sql, step.args...)
+			if result.Error != nil {
+				return fmt.Errorf("failed to erase %s: %w", step.table, result.Error)
+			}
+			if step.anonymizes {
+				report.RowsAnonymized[step.table] += result.RowsAffected
+			} else {
+				report.RowsDeleted[step.table] += result.RowsAffected
+			}
+		}
+
+		report.CompletedAt = now
+		payload, err := json.Marshal(report)
+		if err != nil {
+			return err
+		}
+		return tx.Model(&model.AccountDeletion{}).Where("id = ?", deletionID).
+			Updates(map[string]interface{}{
+				"status":       model.AccountDeletionCompleted,
+				"report":       payload,
+				"last_error":   nil,
+				"completed_at": now,
+				"updated_at":   now,
+			}).Error
+	})
+}
@@ -0,0 +1,266 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	model "music-app-backend/internal/user/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// openExportStatuses are the statuses of an export that is still being built
+var openExportStatuses = []model.DataExportStatus{
+	model.DataExportPending,
+	model.DataExportProcessing,
+}
+
+func (r *UserRepository) CreateDataExport(ctx context.Context, export *model.DataExport) error {
+	return r.db.WithContext(ctx).Create(export).Error
+}
+
+// GetOpenDataExport returns the user's export that is still being built, if any
+func (r *UserRepository) GetOpenDataExport(ctx context.Context, userID uint64) (*model.DataExport, error) {
+	var export model.DataExport
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND status IN ?", userID, openExportStatuses).
+		First(&export).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &export, nil
+}
+
+func (r *UserRepository) GetDataExport(ctx context.Context, userID, exportID uint64) (*model.DataExport, error) {
+	var export model.DataExport
+	err := r.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", exportID, userID).
+		First(&export).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &export, nil
+}
+
+func (r *UserRepository) ListDataExports(ctx context.Context, userID uint64, limit int) ([]model.DataExport, error) {
+	var exports []model.DataExport
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&exports).Error
+	return exports, err
+}
+
+// ListDueDataExports returns exports waiting to be built, and those stuck in processing
+// since before staleBefore
+func (r *UserRepository) ListDueDataExports(ctx context.Context, staleBefore time.Time, limit int) ([]model.DataExport, error) {
+	var exports []model.DataExport
+	err := r.db.WithContext(ctx).
+		Where("status = ? OR (status = ? AND updated_at < ?)",
+			model.DataExportPending, model.DataExportProcessing, staleBefore).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&exports).Error
+	return exports, err
+}
+
+// ClaimDataExport moves the export to processing unless someone else changed it since
+// it was listed
+func (r *UserRepository) ClaimDataExport(ctx context.Context, export *model.DataExport) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&model.DataExport{}).
+		Where("id = ? AND status = ? AND attempts = ?", export.ID, export.Status, export.Attempts).
+		Updates(map[string]interface{}{
+			"status":     model.DataExportProcessing,
+			"attempts":   gorm.Expr("attempts + 1"),
+			"updated_at": time.Now().UTC(),
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *UserRepository) CompleteDataExport(ctx context.Context, exportID uint64, objectKey string, size int64, expiresAt time.Time) error {
+	now := time.Now().UTC()
+	return r.db.WithContext(ctx).Model(&model.DataExport{}).
+		Where("id = ?", exportID).
+		Updates(map[string]interface{}{
+			"status":       model.DataExportCompleted,
+			"object_key":   objectKey,
+			"size_bytes":   size,
+			"last_error":   nil,
+			"completed_at": now,
+			"expires_at":   expiresAt,
+			"updated_at":   now,
+		}).Error
+}
+
+// FailDataExport puts the export back in the queue, or marks it failed for good when
+// final is set
+func (r *UserRepository) FailDataExport(ctx context.Context, exportID uint64, cause error, final bool) error {
+	status := model.DataExportPending
+	if final {
+		status = model.DataExportFailed
+	}
+	return r.db.WithContext(ctx).Model(&model.DataExport{}).
+		Where("id = ?", exportID).
+		Updates(map[string]interface{}{
+			"status":     status,
+			"last_error": cause.Error(),
+			"updated_at": time.Now().UTC(),
+		}).Error
+}
+
+// ListExpiredDataExports returns completed exports whose archive is past expires_at
+func (r *UserRepository) ListExpiredDataExports(ctx context.Context, now time.Time, limit int) ([]model.DataExport, error) {
+	var exports []model.DataExport
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND expires_at <= ?", model.DataExportCompleted, now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&exports).Error
+	return exports, err
+}
+
+func (r *UserRepository) ExpireDataExport(ctx context.Context, exportID uint64) error {
+	return r.db.WithContext(ctx).Model(&model.DataExport{}).
+		Where("id = ? AND status = ?", exportID, model.DataExportCompleted).
+		Updates(map[string]interface{}{
+			"status":     model.DataExportExpired,
+			"object_key": nil,
+			"updated_at": time.Now().UTC(),
+		}).Error
+}
+
+type exportQuery struct {
+	section string
+	sql     string
+	args    []interface{}
+}
+
+// CollectExportSections reads the personal data of the user, and of their artist when
+// artistID is set, from one snapshot. Other people appear by artist and song only:
+// tippers are left out of tips received.
+func (r *UserRepository) CollectExportSections(ctx context.Context, userID uint64, artistID *uint64) ([]model.DataExportSection, error) {
+	queries := []exportQuery{
+		{section: "profile", sql: `SELECT id, email, user_type, display_name, avatar_url, bio, location, is_active,
+				last_login_at, created_at, updated_at
+			FROM users WHERE id = ?`, args: []interface{}{userID}},
+		{section: "preferences", sql: `SELECT preferred_genres::text AS preferred_genres, preferred_moods::text AS preferred_moods,
+				auto_play, shuffle_by_default, explicit_content_allowed, notification_new_releases,
+				notification_artist_messages, notification_tips_received, email_notifications, marketing_emails,
+				created_at, updated_at
+			FROM user_preferences WHERE user_id = ?`, args: []interface{}{userID}},
+		{section: "favorites", sql: `SELECT f.song_id, s.title AS song_title, a.artist_name, f.created_at AS favorited_at
+			FROM user_favorites f
+			LEFT JOIN songs s ON s.id = f.song_id
+			LEFT JOIN artists a ON a.id = s.artist_id
+			WHERE f.user_id = ? ORDER BY f.created_at`, args: []interface{}{userID}},
+		{section: "playlists", sql: `SELECT id, name, description, is_public, song_count, created_at, updated_at
+			FROM playlists
+			WHERE created_by_user_id = ? AND playlist_type = 'user_created' ORDER BY created_at`, args: []interface{}{userID}},
+		{section: "playlist_songs", sql: `SELECT ps.playlist_id, p.name AS playlist_name, ps.position, ps.song_id,
+				s.title AS song_title, a.artist_name, ps.added_at
+			FROM playlist_songs ps
+			JOIN playlists p ON p.id = ps.playlist_id
+			LEFT JOIN songs s ON s.id = ps.song_id
+			LEFT JOIN artists a ON a.id = s.artist_id
+			WHERE p.created_by_user_id = ? AND p.playlist_type = 'user_created'
+			ORDER BY ps.playlist_id, ps.position`, args: []interface{}{userID}},
+		{section: "follows", sql: `SELECT f.artist_id, a.artist_name, f.notification_enabled, f.followed_at
+			FROM artist_followers f
+			LEFT JOIN artists a ON a.id = f.artist_id
+			WHERE f.follower_user_id = ? ORDER BY f.followed_at`, args: []interface{}{userID}},
+		{section: "first_follows", sql: `SELECT f.artist_id, a.artist_name, f.first_followed_at
+			FROM artist_first_follows f
+			LEFT JOIN artists a ON a.id = f.artist_id
+			WHERE f.follower_user_id = ? ORDER BY f.first_followed_at`, args: []interface{}{userID}},
+		{section: "play_history", sql: `SELECT p.played_at, p.song_id, s.title AS song_title, a.artist_name,
+				p.duration_played_seconds, p.completed, p.skip_reason, p.country_code, p.city,
+				host(p.ip_address) AS ip_address, p.user_agent
+			FROM song_plays p
+			LEFT JOIN songs s ON s.id = p.song_id
+			LEFT JOIN artists a ON a.id = s.artist_id
+			WHERE p.user_id = ? ORDER BY p.played_at`, args: []interface{}{userID}},
+		{section: "tips_sent", sql: `SELECT t.id, t.to_artist_id, a.artist_name, t.song_id, s.title AS song_title,
+				t.amount_cents, t.currency, t.refunded_cents, t.status, t.message, t.is_anonymous,
+				t.created_at, t.processed_at
+			FROM tips t
+			LEFT JOIN artists a ON a.id = t.to_artist_id
+			LEFT JOIN songs s ON s.id = t.song_id
+			WHERE t.from_user_id = ? ORDER BY t.created_at`, args: []interface{}{userID}},
+		{section: "messages_received", sql: `SELECT m.id AS message_id, m.artist_id, a.artist_name, m.message_text,
+				d.delivered_at, d.read_at
+			FROM message_deliveries d
+			JOIN artist_messages m ON m.id = d.message_id
+			LEFT JOIN artists a ON a.id = m.artist_id
+			WHERE d.user_id = ? ORDER BY d.delivered_at`, args: []interface{}{userID}},
+	}
+
+	if artistID != nil {
+		queries = append(queries,
+			exportQuery{section: "artist", sql: `SELECT id, artist_name, bio, website_url, spotify_url, instagram_url,
+					twitter_url, youtube_url, profile_image_url, banner_image_url, is_verified, created_at, updated_at
+				FROM artists WHERE id = ?`, args: []interface{}{*artistID}},
+			exportQuery{section: "tips_received", sql: `SELECT t.id, t.song_id, s.title AS song_title, t.amount_cents,
+					t.currency, t.platform_fee_cents, t.artist_payout_cents, t.refunded_cents, t.status, t.message,
+					t.created_at, t.processed_at
+				FROM tips t
+				LEFT JOIN songs s ON s.id = t.song_id
+				WHERE t.to_artist_id = ? ORDER BY t.created_at`, args: []interface{}{*artistID}},
+			exportQuery{section: "messages_sent", sql: `SELECT id, message_text, target_type, target_song_id, status,
+					sent_to_count, read_count, created_at, sent_at
+				FROM artist_messages WHERE artist_id = ? ORDER BY created_at`, args: []interface{}{*artistID}},
+		)
+	}
+
+	sections := make([]model.DataExportSection, 0, len(queries))
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, query := range queries {
+			section, err := readExportSection(tx, query)
+			if err != nil {
+				return fmt.Errorf("failed to export %s: %w", query.section, err)
+			}
+			sections = append(sections, *section)
+		}
+		return nil
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	return sections, err
+}
+
+func readExportSection(tx *gorm.DB, query exportQuery) (*model.DataExportSection, error) {
+	rows, err := tx.Raw(query.sql, query.args...).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	section := &model.DataExportSection{Name: query.section, Columns: columns, Rows: [][]interface{}{}}
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+		for i, value := range values {
+			if raw, ok := value.([]byte); ok {
+				values[i] = string(raw)
+			}
+		}
+		section.Rows = append(section.Rows, values)
+	}
+	return section, rows.Err()
+}
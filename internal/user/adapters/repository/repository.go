@@ -1,9 +1,15 @@
 package repository
 
 import (
+	"context"
+	"errors"
+	musicModel "music-app-backend/internal/music/domain"
 	model "music-app-backend/internal/user/domain"
+	"time"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UserRepository struct {
@@ -17,11 +23,151 @@ func NewUserRepository(db *gorm.DB) *UserRepository {
 	}
 }
 
-func (r *UserRepository) CreateUserAfterRegistration(user *model.User) (*model.User, error) {
-	err := r.db.Create(user).Error
+// ErrEmailInUse is returned when another identity already registered the email
+var ErrEmailInUse = errors.New("email already in use")
+
+// CreateUserAfterRegistration inserts the user, and for artist accounts their artist, in
+// one transaction. When a user already exists for the Kratos identity nothing is written
+// and the existing user is returned with false; an email taken by another identity fails
+// with ErrEmailInUse.
+func (r *UserRepository) CreateUserAfterRegistration(ctx context.Context, user *model.User, artist *musicModel.Artist) (*model.User, bool, error) {
+	var existing *model.User
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var taken int64
+		if err := tx.Model(&model.User{}).
+			Where("email = ? AND kratos_identity_id <> ?", user.Email, user.KratosIdentityID).
+			Count(&taken).Error; err != nil {
+			return err
+		}
+		if taken > 0 {
+			return ErrEmailInUse
+		}
+
+		result := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "kratos_identity_id"}}, DoNothing: true}).
+			Create(user)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			existing = &model.User{}
+			return tx.Where("kratos_identity_id = ?", user.KratosIdentityID).First(existing).Error
+		}
+
+		if artist != nil {
+			artist.UserID = user.ID
+			return tx.Create(artist).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if existing != nil {
+		return existing, false, nil
+	}
+	return user, true, nil
+}
+
+func (r *UserRepository) FindByKratosIdentityID(ctx context.Context, identityID uuid.UUID) (*model.User, error) {
+	var user model.User
+	err := r.db.WithContext(ctx).Where("kratos_identity_id = ?", identityID).First(&user).Error
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
 		return nil, err
 	}
+	return &user, nil
+}
+
+func (r *UserRepository) ListUsersByKratosIdentityIDs(ctx context.Context, identityIDs []uuid.UUID) ([]model.User, error) {
+	var users []model.User
+	if len(identityIDs) == 0 {
+		return users, nil
+	}
+	err := r.db.WithContext(ctx).Where("kratos_identity_id IN ?", identityIDs).Find(&users).Error
+	return users, err
+}
+
+// ListUsersWithIdentity pages, by ID, through users created before createdBefore whose
+// identity is not known to be deleted
+func (r *UserRepository) ListUsersWithIdentity(ctx context.Context, afterID uint64, createdBefore time.Time, limit int) ([]model.User, error) {
+	var users []model.User
+	err := r.db.WithContext(ctx).
+		Where("id > ? AND created_at < ? AND identity_state <> ?", afterID, createdBefore, model.IdentityStateDeleted).
+		Order("id ASC").
+		Limit(limit).
+		Find(&users).Error
+	return users, err
+}
+
+// SaveEmailPreferences stores the opt-ins from registration. An existing preferences row
+// keeps its other settings.
+func (r *UserRepository) SaveEmailPreferences(ctx context.Context, id uint64, userID uint64, emailNotifications bool, marketingEmails bool) error {
+	return r.db.WithContext(ctx).Exec(`
+		INSERT INTO user_preferences (id, user_id, email_notifications, marketing_emails, created_at, updated_at)
+		VALUES (?, ?, ?, ?, NOW(), NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+			email_notifications = EXCLUDED.email_notifications,
+			marketing_emails = EXCLUDED.marketing_emails,
+			updated_at = NOW()`,
+		id, userID, emailNotifications, marketingEmails,
+	).Error
+}
+
+// DisableEmailPreference switches one email column off, creating the preferences row with
+// defaults if the user has none yet
+func (r *UserRepository) DisableEmailPreference(ctx context.Context, id uint64, userID uint64, column string) error {
+	return r.db.WithContext(ctx).Exec(`
+		INSERT INTO user_preferences (id, user_id, `+column+`, created_at, updated_at)
+		VALUES (?, ?, false, NOW(), NOW())
+		ON CONFLICT (user_id) DO UPDATE SET `+column+` = false, updated_at = NOW()`,
+		id, userID,
+	).Error
+}
+
+func (r *UserRepository) GetPreference(ctx context.Context, userID uint64) (*model.UserPreference, error) {
+	var preference model.UserPreference
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&preference).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &preference, nil
+}
+
+// UpdatePreference applies updates to the user's row, creating it with the column
+// defaults first if needed
+func (r *UserRepository) UpdatePreference(ctx context.Context, id uint64, userID uint64, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`
+			INSERT INTO user_preferences (id, user_id, created_at, updated_at)
+			VALUES (?, ?, NOW(), NOW())
+			ON CONFLICT (user_id) DO NOTHING`, id, userID).Error
+		if err != nil {
+			return err
+		}
+
+		updates["updated_at"] = time.Now().UTC()
+		return tx.Model(&model.UserPreference{}).Where("user_id = ?", userID).Updates(updates).Error
+	})
+}
+
+func (r *UserRepository) GetUserByID(ctx context.Context, userID uint64) (*model.User, error) {
+	var user model.User
+	err := r.db.WithContext(ctx).Where("id = ?", userID).First(&user).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
 
-	return user, nil
+func (r *UserRepository) UpdateUser(ctx context.Context, userID uint64, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now().UTC()
+	return r.db.WithContext(ctx).Model(&model.User{}).Where("id = ?", userID).Updates(updates).Error
 }
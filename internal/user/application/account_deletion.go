@@ -0,0 +1,330 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log"
+	authModel "music-app-backend/internal/auth/domain"
+	model "music-app-backend/internal/user/domain"
+	"music-app-backend/pkg/audit"
+	appError "music-app-backend/pkg/error"
+	baseModel "music-app-backend/pkg/model"
+	"music-app-backend/pkg/pagination"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	maxDeletionReasonLength = 1000
+	maxDeletionAttempts     = 5
+	deletionBatchSize       = 20
+	// A request left in processing this long is assumed abandoned by a crashed replica
+	deletionStaleAfter  = time.Hour
+	accountDeletionLock = "user:account_deletion:lock"
+)
+
+// RequestAccountDeletion schedules the user's account for deletion after the grace
+// period. Asking again while a request is open returns that request.
+func (s *UserService) RequestAccountDeletion(ctx context.Context, userID uint64, request *model.RequestAccountDeletionDTO) (*model.AccountDeletion, error) {
+	existing, err := s.userRepo.GetOpenAccountDeletion(ctx, userID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load deletion request")
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	var reason *string
+	if request.Reason != nil {
+		value, err := optionalText("reason", *request.Reason, maxDeletionReasonLength)
+		if err != nil {
+			return nil, err
+		}
+		reason = value
+	}
+
+	_base, err := baseModel.NewBaseModel(s.generator)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to schedule deletion")
+	}
+	deletion := &model.AccountDeletion{
+		BaseModel:    *_base,
+		UserID:       userID,
+		Status:       model.AccountDeletionPending,
+		Reason:       reason,
+		ScheduledFor: _base.CreatedAt.Add(s.deletionGracePeriod),
+	}
+	if err := s.userRepo.CreateAccountDeletion(ctx, deletion); err != nil {
+		return nil, appError.NewInternalError(err, "failed to schedule deletion")
+	}
+
+	audit.Write(ctx, s.auditLog, &audit.Entry{
+		Action:     "account.deletion_request",
+		TargetType: "user",
+		TargetID:   strconv.FormatUint(userID, 10),
+		Metadata:   map[string]interface{}{"deletion_id": deletion.ID, "scheduled_for": deletion.ScheduledFor},
+	})
+	return deletion, nil
+}
+
+// GetAccountDeletion returns the user's open deletion request
+func (s *UserService) GetAccountDeletion(ctx context.Context, userID uint64) (*model.AccountDeletion, error) {
+	deletion, err := s.userRepo.GetOpenAccountDeletion(ctx, userID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load deletion request")
+	}
+	if deletion == nil {
+		return nil, appError.NewNotFoundError(nil, "no account deletion is scheduled")
+	}
+	return deletion, nil
+}
+
+// CancelAccountDeletion keeps the account, as long as the grace period is not over
+func (s *UserService) CancelAccountDeletion(ctx context.Context, userID uint64) error {
+	deletion, err := s.GetAccountDeletion(ctx, userID)
+	if err != nil {
+		return err
+	}
+	cancelled, err := s.userRepo.CancelAccountDeletion(ctx, deletion.ID)
+	if err != nil {
+		return appError.NewInternalError(err, "failed to cancel deletion")
+	}
+	if !cancelled {
+		return appError.NewBadRequestError(nil, "account deletion has already started")
+	}
+
+	audit.Write(ctx, s.auditLog, &audit.Entry{
+		Action:     "account.deletion_cancel",
+		TargetType: "user",
+		TargetID:   strconv.FormatUint(userID, 10),
+		Metadata:   map[string]interface{}{"deletion_id": deletion.ID},
+	})
+	return nil
+}
+
+// ProcessDueAccountDeletions erases the accounts whose grace period is over and returns
+// how many were completed
+func (s *UserService) ProcessDueAccountDeletions(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	deletions, err := s.userRepo.ListDueAccountDeletions(ctx, now, now.Add(-deletionStaleAfter), maxDeletionAttempts, deletionBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list due deletions: %w", err)
+	}
+
+	completed := 0
+	for i := range deletions {
+		claimed, err := s.userRepo.ClaimAccountDeletion(ctx, &deletions[i])
+		if err != nil || !claimed {
+			continue
+		}
+		if err := s.eraseAccount(ctx, &deletions[i]); err != nil {
+			log.Printf("Account deletion %d failed: %v", deletions[i].ID, err)
+			if err := s.userRepo.FailAccountDeletion(ctx, deletions[i].ID, err); err != nil {
+				log.Printf("Failed to record failure of account deletion %d: %v", deletions[i].ID, err)
+			}
+			if deletions[i].Attempts+1 >= maxDeletionAttempts {
+				s.reportAbandonedDeletion(ctx, &deletions[i], err)
+			}
+			continue
+		}
+		completed++
+	}
+	return completed, nil
+}
+
+// reportAbandonedDeletion raises the alarm on a request the worker stopped retrying. It
+// stays failed until an operator retries it through RetryAccountDeletion.
+func (s *UserService) reportAbandonedDeletion(ctx context.Context, deletion *model.AccountDeletion, cause error) {
+	log.Printf("ALERT: account deletion %d for user %d gave up after %d attempts and needs an operator: %v",
+		deletion.ID, deletion.UserID, maxDeletionAttempts, cause)
+	audit.Write(ctx, s.auditLog, &audit.Entry{
+		Action:     "account.delete_failed",
+		ActorType:  audit.ActorTypeSystem,
+		TargetType: "user",
+		TargetID:   strconv.FormatUint(deletion.UserID, 10),
+		Metadata:   map[string]interface{}{"deletion_id": deletion.ID, "attempts": maxDeletionAttempts, "error": cause.Error()},
+	})
+}
+
+// ListAccountDeletions returns the deletion requests in status for operators, failed ones
+// by default
+func (s *UserService) ListAccountDeletions(ctx context.Context, status model.AccountDeletionStatus, page pagination.Params) (*model.AccountDeletionListResult, error) {
+	if status == "" {
+		status = model.AccountDeletionFailed
+	}
+	switch status {
+	case model.AccountDeletionPending, model.AccountDeletionProcessing, model.AccountDeletionFailed,
+		model.AccountDeletionCancelled, model.AccountDeletionCompleted:
+	default:
+		return nil, appError.NewBadRequestError(nil, fmt.Sprintf("unknown deletion status %q", status))
+	}
+
+	deletions, total, err := s.userRepo.ListAccountDeletions(ctx, status, page)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to list deletion requests")
+	}
+	result := &model.AccountDeletionListResult{
+		Deletions:  make([]model.AccountDeletionView, 0, len(deletions)),
+		Pagination: page.Meta(total),
+	}
+	for i := range deletions {
+		result.Deletions = append(result.Deletions, model.NewAccountDeletionView(&deletions[i]))
+	}
+	return result, nil
+}
+
+// RetryAccountDeletion queues a failed deletion again with a fresh set of attempts; the
+// worker picks it up on its next run
+func (s *UserService) RetryAccountDeletion(ctx context.Context, deletionID uint64) (*model.AccountDeletionView, error) {
+	deletion, err := s.userRepo.GetAccountDeletionByID(ctx, deletionID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load deletion request")
+	}
+	if deletion == nil {
+		return nil, appError.NewNotFoundError(nil, "deletion request not found")
+	}
+	retried, err := s.userRepo.RetryAccountDeletion(ctx, deletion.ID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to retry deletion")
+	}
+	if !retried {
+		return nil, appError.NewBadRequestError(nil, "only a failed deletion can be retried")
+	}
+
+	audit.Write(ctx, s.auditLog, &audit.Entry{
+		Action:     "account.deletion_retry",
+		TargetType: "user",
+		TargetID:   strconv.FormatUint(deletion.UserID, 10),
+		Before:     map[string]interface{}{"status": deletion.Status, "attempts": deletion.Attempts, "last_error": deletion.LastError},
+		Metadata:   map[string]interface{}{"deletion_id": deletion.ID},
+	})
+
+	deletion.Status = model.AccountDeletionPending
+	deletion.Attempts = 0
+	view := model.NewAccountDeletionView(deletion)
+	return &view, nil
+}
+
+// StartAccountDeletions runs ProcessDueAccountDeletions every interval on one replica
+func (s *UserService) StartAccountDeletions(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// The lock is never released; it expires before the next tick
+				acquired, err := s.redisClient.SetNX(ctx, accountDeletionLock, strconv.FormatInt(time.Now().UnixNano(), 10), interval*9/10)
+				if err != nil || !acquired {
+					continue
+				}
+				completed, err := s.ProcessDueAccountDeletions(ctx)
+				if err != nil {
+					log.Printf("Account deletions failed: %v", err)
+					continue
+				}
+				if completed > 0 {
+					log.Printf("Deleted %d accounts", completed)
+				}
+			}
+		}
+	}()
+}
+
+// eraseAccount signs the user out, removes their stored files and Kratos identity, then
+// erases their data. Every step can be repeated, so a failed deletion is simply retried;
+// the database erasure comes last because the earlier steps need the data it removes.
+func (s *UserService) eraseAccount(ctx context.Context, deletion *model.AccountDeletion) error {
+	user, err := s.userRepo.GetUserByID(ctx, deletion.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to load user: %w", err)
+	}
+	if user == nil {
+		return fmt.Errorf("user %d not found", deletion.UserID)
+	}
+	report := &model.AccountDeletionReport{UserID: user.ID}
+
+	if err := s.authService.InvalidateUserTokens(ctx, user.ID, authModel.SessionRevokeAccountDeleted); err != nil {
+		return fmt.Errorf("failed to sign out: %w", err)
+	}
+
+	artist, err := s.artistService.GetArtistByUserID(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load artist: %w", err)
+	}
+	if artist != nil {
+		report.ArtistID = &artist.ID
+	}
+
+	report.StorageObjectsDeleted, err = s.deleteStoredObjects(ctx, user.ID, report.ArtistID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.kratosClient.DeleteIdentity(ctx, user.KratosIdentityID.String()); err != nil {
+		return fmt.Errorf("failed to delete identity: %w", err)
+	}
+	report.IdentityDeleted = true
+
+	if err := s.userRepo.EraseAccount(ctx, deletion.ID, user.ID, report.ArtistID, report); err != nil {
+		return err
+	}
+	if err := s.authService.InvalidateUserStatus(ctx, user.ID); err != nil {
+		log.Printf("Failed to invalidate status of deleted user %d: %v", user.ID, err)
+	}
+
+	audit.Write(ctx, s.auditLog, &audit.Entry{
+		Action:     "account.delete",
+		ActorType:  audit.ActorTypeSystem,
+		TargetType: "user",
+		TargetID:   strconv.FormatUint(user.ID, 10),
+		After:      report,
+		Metadata:   map[string]interface{}{"deletion_id": deletion.ID},
+	})
+	log.Printf("Account deletion %d completed for user %d", deletion.ID, user.ID)
+	return nil
+}
+
+// deleteStoredObjects removes the user's avatars and data exports and, for artists, their
+// uploads and processed audio
+func (s *UserService) deleteStoredObjects(ctx context.Context, userID uint64, artistID *uint64) (int, error) {
+	type object struct{ bucket, key string }
+	var objects []object
+
+	prefixes := []object{
+		{avatarBucket, avatarKeyPrefix(userID)},
+		{avatarStagingBucket, avatarStagingPrefix(userID)},
+		{dataExportBucket, dataExportKeyPrefix(userID)},
+	}
+	if artistID != nil {
+		prefixes = append(prefixes, object{"tracks", fmt.Sprintf("uploads/%d/", *artistID)})
+	}
+	for _, prefix := range prefixes {
+		found, err := s.storageService.ListFiles(ctx, prefix.bucket, prefix.key)
+		if err != nil {
+			return 0, fmt.Errorf("failed to list %s objects: %w", strings.TrimSuffix(prefix.key, "/"), err)
+		}
+		for _, info := range found {
+			objects = append(objects, object{prefix.bucket, info.Key})
+		}
+	}
+	if artistID != nil {
+		paths, err := s.userRepo.ListProcessedObjectPaths(ctx, *artistID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to list processed audio: %w", err)
+		}
+		for _, path := range paths {
+			objects = append(objects, object{"processed", path})
+		}
+	}
+
+	for _, obj := range objects {
+		if err := s.storageService.DeleteFile(ctx, obj.bucket, obj.key); err != nil {
+			return 0, fmt.Errorf("failed to delete object %s: %w", obj.key, err)
+		}
+	}
+	return len(objects), nil
+}
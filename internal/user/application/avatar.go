@@ -0,0 +1,256 @@
+package application
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"log"
+	model "music-app-backend/internal/user/domain"
+	appError "music-app-backend/pkg/error"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	maxAvatarBytes     = 5 << 20
+	maxAvatarDimension = 4096
+	avatarUploadExpiry = 15 * time.Minute
+	// Avatars live under public/ in the general bucket, the only prefix that storage serves
+	// without a signed link (see scripts/setup-minio.sh)
+	avatarBucket = "general"
+	// Uploads wait outside the public prefix until ConfirmAvatar has checked them
+	avatarStagingBucket = "general"
+	// Unconfirmed uploads older than this are swept
+	avatarStagingMaxAge = time.Hour
+	avatarSweepLock     = "user:avatar_sweep:lock"
+)
+
+// avatarContentTypes maps accepted image types to their file extension
+var avatarContentTypes = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/webp": "webp",
+}
+
+// CreateAvatarUploadURL returns a presigned form upload into a private staging area. The
+// policy pins the object key, content type and size, so storage rejects anything else;
+// the image only becomes the avatar once ConfirmAvatar has checked it.
+func (s *UserService) CreateAvatarUploadURL(ctx context.Context, userID uint64, request *model.AvatarUploadRequest) (*model.AvatarUploadURL, error) {
+	ext, ok := avatarContentTypes[request.ContentType]
+	if !ok {
+		return nil, appError.NewBadRequestError(nil, "avatar must be a JPEG, PNG or WebP image")
+	}
+	if request.Size > maxAvatarBytes {
+		return nil, appError.NewBadRequestError(nil, fmt.Sprintf("avatar must be at most %d MB", maxAvatarBytes>>20))
+	}
+
+	objectKey := fmt.Sprintf("%s%s.%s", avatarStagingPrefix(userID), uuid.NewString(), ext)
+	upload, err := s.storageService.GetPresignedPostPolicy(ctx, avatarStagingBucket, objectKey, request.ContentType, 1, maxAvatarBytes, avatarUploadExpiry)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to create upload URL")
+	}
+
+	return &model.AvatarUploadURL{
+		ObjectKey: objectKey,
+		URL:       upload.URL,
+		Method:    upload.Method,
+		Fields:    upload.Fields,
+		ExpiresAt: upload.ExpiresAt,
+	}, nil
+}
+
+// ConfirmAvatar validates the staged upload, copies it to the public avatars and makes it
+// the user's avatar. A checked upload is deleted from staging whether it passed or not;
+// the sweeper removes the rest.
+func (s *UserService) ConfirmAvatar(ctx context.Context, userID uint64, objectKey string) (*model.User, error) {
+	if !strings.HasPrefix(objectKey, avatarStagingPrefix(userID)) || strings.Contains(objectKey, "..") {
+		return nil, appError.NewBadRequestError(nil, "invalid avatar object key")
+	}
+
+	user, err := s.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateAvatar(ctx, objectKey); err != nil {
+		if appErr, ok := appError.GetAppError(err); ok && appErr.StatusCode == http.StatusBadRequest {
+			s.deleteStagedAvatar(ctx, objectKey)
+		}
+		return nil, err
+	}
+
+	publicKey := avatarKeyPrefix(userID) + path.Base(objectKey)
+	if err := s.storageService.CopyFile(ctx, avatarStagingBucket, objectKey, avatarBucket, publicKey); err != nil {
+		return nil, appError.NewInternalError(err, "failed to publish avatar")
+	}
+	s.deleteStagedAvatar(ctx, objectKey)
+
+	avatarURL := s.storageService.GetObjectURL(avatarBucket, publicKey)
+	if err := s.setAvatar(ctx, user, avatarURL); err != nil {
+		return nil, err
+	}
+	return s.GetProfile(ctx, userID)
+}
+
+// SweepAvatarUploads deletes staged uploads that were never confirmed and returns how many
+func (s *UserService) SweepAvatarUploads(ctx context.Context) (int, error) {
+	objects, err := s.storageService.ListFiles(ctx, avatarStagingBucket, avatarStagingRoot)
+	if err != nil {
+		return 0, err
+	}
+
+	cutoff := time.Now().Add(-avatarStagingMaxAge)
+	deleted := 0
+	for _, object := range objects {
+		if object.LastModified.After(cutoff) {
+			continue
+		}
+		if err := s.storageService.DeleteFile(ctx, avatarStagingBucket, object.Key); err != nil {
+			log.Printf("Failed to delete stale avatar upload %s: %v", object.Key, err)
+			continue
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// StartAvatarUploadSweeper runs SweepAvatarUploads every interval on one replica
+func (s *UserService) StartAvatarUploadSweeper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// The lock is never released; it expires before the next tick
+				acquired, err := s.redisClient.SetNX(ctx, avatarSweepLock, strconv.FormatInt(time.Now().UnixNano(), 10), interval*9/10)
+				if err != nil || !acquired {
+					continue
+				}
+				deleted, err := s.SweepAvatarUploads(ctx)
+				if err != nil {
+					log.Printf("Avatar upload sweep failed: %v", err)
+					continue
+				}
+				if deleted > 0 {
+					log.Printf("Deleted %d unconfirmed avatar uploads", deleted)
+				}
+			}
+		}
+	}()
+}
+
+// RemoveAvatar clears the avatar and deletes the uploaded image
+func (s *UserService) RemoveAvatar(ctx context.Context, userID uint64) (*model.User, error) {
+	user, err := s.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.AvatarURL == "" {
+		return user, nil
+	}
+
+	if err := s.setAvatar(ctx, user, ""); err != nil {
+		return nil, err
+	}
+	return s.GetProfile(ctx, userID)
+}
+
+func (s *UserService) setAvatar(ctx context.Context, user *model.User, avatarURL string) error {
+	var trait interface{}
+	if avatarURL != "" {
+		trait = avatarURL
+	}
+	if err := s.syncTraits(ctx, user, map[string]interface{}{"profile_image": trait}); err != nil {
+		return err
+	}
+	if err := s.userRepo.UpdateUser(ctx, user.ID, map[string]interface{}{"avatar_url": avatarURL}); err != nil {
+		return appError.NewInternalError(err, "failed to update avatar")
+	}
+
+	if user.AvatarURL != avatarURL {
+		s.deleteStoredAvatar(ctx, user.ID, user.AvatarURL)
+	}
+	return nil
+}
+
+// validateAvatar checks the uploaded bytes rather than the type the client declared
+func (s *UserService) validateAvatar(ctx context.Context, objectKey string) error {
+	info, err := s.storageService.GetFileInfo(ctx, avatarStagingBucket, objectKey)
+	if err != nil {
+		return appError.NewBadRequestError(err, "avatar upload not found")
+	}
+
+	object, err := s.storageService.DownloadFile(ctx, avatarStagingBucket, objectKey)
+	if err != nil {
+		return appError.NewInternalError(err, "failed to read avatar upload")
+	}
+	defer object.Close()
+
+	return checkAvatarImage(object, info.Size)
+}
+
+// checkAvatarImage accepts a JPEG, PNG or WebP image of at most maxAvatarBytes and
+// maxAvatarDimension pixels a side
+func checkAvatarImage(r io.Reader, size int64) error {
+	if size <= 0 || size > maxAvatarBytes {
+		return appError.NewBadRequestError(nil, fmt.Sprintf("avatar must be at most %d MB", maxAvatarBytes>>20))
+	}
+
+	reader := bufio.NewReader(io.LimitReader(r, maxAvatarBytes))
+	head, _ := reader.Peek(512)
+	contentType := http.DetectContentType(head)
+	if _, ok := avatarContentTypes[contentType]; !ok {
+		return appError.NewBadRequestError(nil, "uploaded file is not a JPEG, PNG or WebP image")
+	}
+
+	config, _, err := image.DecodeConfig(reader)
+	if err != nil {
+		return appError.NewBadRequestError(err, "uploaded image could not be read")
+	}
+	if config.Width > maxAvatarDimension || config.Height > maxAvatarDimension {
+		return appError.NewBadRequestError(nil, fmt.Sprintf("avatar must be at most %dx%d pixels", maxAvatarDimension, maxAvatarDimension))
+	}
+	return nil
+}
+
+func (s *UserService) deleteStagedAvatar(ctx context.Context, objectKey string) {
+	if err := s.storageService.DeleteFile(ctx, avatarStagingBucket, objectKey); err != nil {
+		log.Printf("Failed to delete staged avatar %s: %v", objectKey, err)
+	}
+}
+
+// deleteStoredAvatar removes a replaced avatar if it was uploaded here; URLs that came
+// from registration traits point elsewhere and are left alone
+func (s *UserService) deleteStoredAvatar(ctx context.Context, userID uint64, avatarURL string) {
+	if avatarURL == "" || !strings.HasPrefix(avatarURL, s.storageService.GetObjectURL(avatarBucket, avatarKeyPrefix(userID))) {
+		return
+	}
+
+	objectKey := strings.TrimPrefix(avatarURL, s.storageService.GetObjectURL(avatarBucket, ""))
+	if err := s.storageService.DeleteFile(ctx, avatarBucket, objectKey); err != nil {
+		log.Printf("Failed to delete old avatar %s: %v", objectKey, err)
+	}
+}
+
+func avatarKeyPrefix(userID uint64) string {
+	return fmt.Sprintf("public/avatars/%d/", userID)
+}
+
+const avatarStagingRoot = "staging/avatars/"
+
+func avatarStagingPrefix(userID uint64) string {
+	return fmt.Sprintf("%s%d/", avatarStagingRoot, userID)
+}
@@ -0,0 +1,92 @@
+package application
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/jpeg"
+	"image/png"
+	appError "music-app-backend/pkg/error"
+	"testing"
+)
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func encodeJPEG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height)), nil); err != nil {
+		t.Fatalf("encode jpeg: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// encodeWebPHeader returns a lossless WebP file header declaring width x height; a
+// config decode stops after it
+func encodeWebPHeader(width, height int) []byte {
+	bits := uint32(width-1) | uint32(height-1)<<14
+	chunk := []byte{0x2f, byte(bits), byte(bits >> 8), byte(bits >> 16), byte(bits >> 24), 0}
+	data := []byte("RIFF")
+	data = binary.LittleEndian.AppendUint32(data, uint32(4+8+len(chunk)))
+	data = append(data, "WEBPVP8L"...)
+	data = binary.LittleEndian.AppendUint32(data, 5)
+	return append(data, chunk...)
+}
+
+func TestCheckAvatarImage(t *testing.T) {
+	validPNG := encodePNG(t, 64, 64)
+	// RIFF container with a WebP signature but no image in it
+	brokenWebP := append([]byte("RIFF\x24\x00\x00\x00WEBPVP8 "), make([]byte, 32)...)
+	// A PNG signature followed by garbage sniffs as PNG but cannot be decoded
+	brokenPNG := append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0xff}, 64)...)
+
+	tests := []struct {
+		name    string
+		data    []byte
+		size    int64 // Zero means len(data)
+		wantErr bool
+	}{
+		{name: "png", data: validPNG},
+		{name: "jpeg", data: encodeJPEG(t, 64, 64)},
+		{name: "webp", data: encodeWebPHeader(64, 64)},
+		{name: "webp at the dimension limit", data: encodeWebPHeader(maxAvatarDimension, 1)},
+		{name: "webp too wide", data: encodeWebPHeader(maxAvatarDimension+1, 1), wantErr: true},
+		{name: "webp too tall", data: encodeWebPHeader(1, maxAvatarDimension+1), wantErr: true},
+		{name: "undecodable webp", data: brokenWebP, wantErr: true},
+		{name: "png at the dimension limit", data: encodePNG(t, maxAvatarDimension, 1)},
+		{name: "png too wide", data: encodePNG(t, maxAvatarDimension+1, 1), wantErr: true},
+		{name: "png too tall", data: encodePNG(t, 1, maxAvatarDimension+1), wantErr: true},
+		{name: "undecodable png", data: brokenPNG, wantErr: true},
+		{name: "gif", data: []byte("GIF89a\x01\x00\x01\x00\x00\x00\x00;"), wantErr: true},
+		{name: "svg", data: []byte(`<svg xmlns="http://www.w3.org/2000/svg"></svg>`), wantErr: true},
+		{name: "html", data: []byte("<!DOCTYPE html><html></html>"), wantErr: true},
+		{name: "empty", data: nil, wantErr: true},
+		{name: "over the size limit", data: validPNG, size: maxAvatarBytes + 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			size := tt.size
+			if size == 0 {
+				size = int64(len(tt.data))
+			}
+			err := checkAvatarImage(bytes.NewReader(tt.data), size)
+			if tt.wantErr {
+				if !appError.IsAppError(err) {
+					t.Fatalf("err = %v, want a bad request error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,351 @@
+package application
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	model "music-app-backend/internal/user/domain"
+	"music-app-backend/pkg/audit"
+	appError "music-app-backend/pkg/error"
+	baseModel "music-app-backend/pkg/model"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	// Exports live in the general bucket outside public/, so they are only served through
+	// presigned links
+	dataExportBucket      = "general"
+	dataExportLinkExpiry  = 15 * time.Minute
+	maxDataExportAttempts = 3
+	dataExportBatchSize   = 5
+	dataExportListLimit   = 20
+	// An export left in processing this long is assumed abandoned by a crashed replica
+	dataExportStaleAfter = 30 * time.Minute
+	dataExportLock       = "user:data_export:lock"
+)
+
+// RequestDataExport queues an export of the user's personal data. Asking again while one
+// is being built returns that export; new exports are rate limited per user.
+func (s *UserService) RequestDataExport(ctx context.Context, userID uint64) (*model.DataExport, error) {
+	existing, err := s.userRepo.GetOpenDataExport(ctx, userID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load data export")
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	limit, err := s.exportLimiter.Allow(ctx, strconv.FormatUint(userID, 10))
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to check data export rate limit")
+	}
+	if !limit.Allowed {
+		retryAfter := int(limit.RetryAfter.Seconds()) + 1
+		return nil, appError.NewTooManyRequestsError(nil,
+			fmt.Sprintf("data export limit of %d per %s reached", limit.Limit.Max, limit.Limit.Window)).
+			WithData(map[string]int{"retry_after_seconds": retryAfter})
+	}
+
+	_base, err := baseModel.NewBaseModel(s.generator)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to queue data export")
+	}
+	export := &model.DataExport{
+		BaseModel:     *_base,
+		UserID:        userID,
+		Status:        model.DataExportPending,
+		FormatVersion: model.DataExportFormatVersion,
+	}
+	if err := s.userRepo.CreateDataExport(ctx, export); err != nil {
+		// A concurrent request may have queued one first
+		if existing, _ := s.userRepo.GetOpenDataExport(ctx, userID); existing != nil {
+			return existing, nil
+		}
+		return nil, appError.NewInternalError(err, "failed to queue data export")
+	}
+
+	audit.Write(ctx, s.auditLog, &audit.Entry{
+		Action:     "account.data_export_request",
+		TargetType: "user",
+		TargetID:   strconv.FormatUint(userID, 10),
+		Metadata:   map[string]interface{}{"export_id": export.ID},
+	})
+	return export, nil
+}
+
+// ListDataExports returns the user's most recent exports
+func (s *UserService) ListDataExports(ctx context.Context, userID uint64) ([]model.DataExport, error) {
+	exports, err := s.userRepo.ListDataExports(ctx, userID, dataExportListLimit)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load data exports")
+	}
+	return exports, nil
+}
+
+// GetDataExport returns one of the user's exports, with a short-lived download link once
+// the archive is ready
+func (s *UserService) GetDataExport(ctx context.Context, userID, exportID uint64) (*model.DataExport, error) {
+	export, err := s.userRepo.GetDataExport(ctx, userID, exportID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load data export")
+	}
+	if export == nil {
+		return nil, appError.NewNotFoundError(nil, "data export not found")
+	}
+
+	now := time.Now().UTC()
+	if export.Status != model.DataExportCompleted || export.ObjectKey == nil || export.ExpiresAt == nil || !now.Before(*export.ExpiresAt) {
+		return export, nil
+	}
+
+	expiry := dataExportLinkExpiry
+	if remaining := export.ExpiresAt.Sub(now); remaining < expiry {
+		expiry = remaining
+	}
+	filename := fmt.Sprintf("data-export-%d.zip", export.ID)
+	export.DownloadURL, err = s.storageService.GetPresignedDownloadURL(ctx, dataExportBucket, *export.ObjectKey, filename, expiry)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to create download link")
+	}
+	linkExpiresAt := now.Add(expiry)
+	export.DownloadURLExpiresAt = &linkExpiresAt
+
+	audit.Write(ctx, s.auditLog, &audit.Entry{
+		Action:     "account.data_export_download",
+		TargetType: "user",
+		TargetID:   strconv.FormatUint(userID, 10),
+		Metadata:   map[string]interface{}{"export_id": export.ID},
+	})
+	return export, nil
+}
+
+// ProcessDataExports removes expired archives, then builds the queued exports, and
+// returns how many were built
+func (s *UserService) ProcessDataExports(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	if err := s.expireDataExports(ctx, now); err != nil {
+		log.Printf("Failed to expire data exports: %v", err)
+	}
+
+	exports, err := s.userRepo.ListDueDataExports(ctx, now.Add(-dataExportStaleAfter), dataExportBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list due data exports: %w", err)
+	}
+
+	completed := 0
+	for i := range exports {
+		claimed, err := s.userRepo.ClaimDataExport(ctx, &exports[i])
+		if err != nil || !claimed {
+			continue
+		}
+		if err := s.buildDataExport(ctx, &exports[i]); err != nil {
+			log.Printf("Data export %d failed: %v", exports[i].ID, err)
+			final := exports[i].Attempts+1 >= maxDataExportAttempts
+			if err := s.userRepo.FailDataExport(ctx, exports[i].ID, err, final); err != nil {
+				log.Printf("Failed to record failure of data export %d: %v", exports[i].ID, err)
+			}
+			continue
+		}
+		completed++
+	}
+	return completed, nil
+}
+
+// StartDataExports runs ProcessDataExports every interval on one replica
+func (s *UserService) StartDataExports(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// The lock is never released; it expires before the next tick
+				acquired, err := s.redisClient.SetNX(ctx, dataExportLock, strconv.FormatInt(time.Now().UnixNano(), 10), interval*9/10)
+				if err != nil || !acquired {
+					continue
+				}
+				completed, err := s.ProcessDataExports(ctx)
+				if err != nil {
+					log.Printf("Data exports failed: %v", err)
+					continue
+				}
+				if completed > 0 {
+					log.Printf("Built %d data exports", completed)
+				}
+			}
+		}
+	}()
+}
+
+// buildDataExport writes the archive to a temporary file, uploads it and completes the
+// export
+func (s *UserService) buildDataExport(ctx context.Context, export *model.DataExport) error {
+	artist, err := s.artistService.GetArtistByUserID(ctx, export.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to load artist: %w", err)
+	}
+	var artistID *uint64
+	if artist != nil {
+		artistID = &artist.ID
+	}
+
+	sections, err := s.userRepo.CollectExportSections(ctx, export.UserID, artistID)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp("", "data-export-*.zip")
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+	defer func() {
+		file.Close()
+		os.Remove(file.Name())
+	}()
+
+	manifest := &model.DataExportManifest{
+		FormatVersion: export.FormatVersion,
+		ExportID:      export.ID,
+		UserID:        export.UserID,
+		GeneratedAt:   time.Now().UTC(),
+	}
+	if err := writeDataExportArchive(file, manifest, sections); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	size, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	objectKey := fmt.Sprintf("%s%d.zip", dataExportKeyPrefix(export.UserID), export.ID)
+	if _, err := s.storageService.UploadFile(ctx, dataExportBucket, objectKey, file, size, "application/zip"); err != nil {
+		return err
+	}
+	expiresAt := time.Now().UTC().Add(s.exportRetention)
+	if err := s.userRepo.CompleteDataExport(ctx, export.ID, objectKey, size, expiresAt); err != nil {
+		return fmt.Errorf("failed to complete export: %w", err)
+	}
+
+	audit.Write(ctx, s.auditLog, &audit.Entry{
+		Action:     "account.data_export",
+		ActorType:  audit.ActorTypeSystem,
+		TargetType: "user",
+		TargetID:   strconv.FormatUint(export.UserID, 10),
+		Metadata:   map[string]interface{}{"export_id": export.ID, "size_bytes": size, "sections": len(sections)},
+	})
+	return nil
+}
+
+// expireDataExports deletes the archives past their retention
+func (s *UserService) expireDataExports(ctx context.Context, now time.Time) error {
+	exports, err := s.userRepo.ListExpiredDataExports(ctx, now, dataExportBatchSize*10)
+	if err != nil {
+		return err
+	}
+	for _, export := range exports {
+		if export.ObjectKey != nil {
+			if err := s.storageService.DeleteFile(ctx, dataExportBucket, *export.ObjectKey); err != nil {
+				log.Printf("Failed to delete archive of data export %d: %v", export.ID, err)
+				continue
+			}
+		}
+		if err := s.userRepo.ExpireDataExport(ctx, export.ID); err != nil {
+			log.Printf("Failed to expire data export %d: %v", export.ID, err)
+		}
+	}
+	return nil
+}
+
+// writeDataExportArchive writes manifest.json, then every section as json/<name>.json, an
+// array of objects, and csv/<name>.csv with a header row
+func writeDataExportArchive(w io.Writer, manifest *model.DataExportManifest, sections []model.DataExportSection) error {
+	archive := zip.NewWriter(w)
+
+	for _, section := range sections {
+		manifest.Sections = append(manifest.Sections, model.DataExportManifestEntry{
+			Name:     section.Name,
+			Rows:     len(section.Rows),
+			JSONFile: "json/" + section.Name + ".json",
+			CSVFile:  "csv/" + section.Name + ".csv",
+			Columns:  section.Columns,
+		})
+	}
+	if err := writeArchiveJSON(archive, "manifest.json", manifest); err != nil {
+		return err
+	}
+
+	for i, section := range sections {
+		records := make([]map[string]interface{}, 0, len(section.Rows))
+		for _, row := range section.Rows {
+			record := make(map[string]interface{}, len(section.Columns))
+			for j, column := range section.Columns {
+				record[column] = row[j]
+			}
+			records = append(records, record)
+		}
+		if err := writeArchiveJSON(archive, manifest.Sections[i].JSONFile, records); err != nil {
+			return err
+		}
+
+		entry, err := archive.Create(manifest.Sections[i].CSVFile)
+		if err != nil {
+			return err
+		}
+		writer := csv.NewWriter(entry)
+		if err := writer.Write(section.Columns); err != nil {
+			return err
+		}
+		for _, row := range section.Rows {
+			fields := make([]string, len(row))
+			for j, value := range row {
+				fields[j] = csvField(value)
+			}
+			if err := writer.Write(fields); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+func writeArchiveJSON(archive *zip.Writer, name string, value interface{}) error {
+	entry, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(entry)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+func csvField(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func dataExportKeyPrefix(userID uint64) string {
+	return fmt.Sprintf("exports/%d/", userID)
+}
@@ -0,0 +1,257 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	authModel "music-app-backend/internal/auth/domain"
+	model "music-app-backend/internal/user/domain"
+	appError "music-app-backend/pkg/error"
+	"music-app-backend/pkg/kratos"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	identityPageSize      = 250
+	identityReconcileLock = "user:identity_reconcile:lock"
+)
+
+// SyncIdentity brings the user of a Kratos identity up to date with it. It runs on the
+// Kratos settings webhook; an identity Kratos no longer has is treated as deleted.
+func (s *UserService) SyncIdentity(ctx context.Context, identityID string) error {
+	id, err := uuid.Parse(identityID)
+	if err != nil {
+		return appError.NewBadRequestError(err, "invalid kratos identity ID")
+	}
+	user, err := s.userRepo.FindByKratosIdentityID(ctx, id)
+	if err != nil {
+		return appError.NewInternalError(err, "failed to load user")
+	}
+	if user == nil {
+		return appError.NewNotFoundError(nil, "user not found")
+	}
+
+	identity, err := s.kratosClient.GetIdentity(ctx, identityID)
+	if err != nil {
+		var kratosErr *kratos.KratosError
+		if errors.As(err, &kratosErr) && kratosErr.Code == http.StatusNotFound {
+			return s.markIdentityDeleted(ctx, user)
+		}
+		return appError.NewInternalError(err, "failed to load identity")
+	}
+	if _, err := s.applyIdentity(ctx, user, identity); err != nil {
+		return appError.NewInternalError(err, "failed to sync identity")
+	}
+	return nil
+}
+
+// ReconcileIdentities pages through every Kratos identity and syncs its user, then marks
+// users whose identity is gone as deleted. It catches what no webhook reports: changes
+// made through the Kratos admin API and deletions.
+func (s *UserService) ReconcileIdentities(ctx context.Context) (*model.IdentityReconcileResult, error) {
+	started := time.Now().UTC()
+	result := &model.IdentityReconcileResult{}
+	seen := make(map[uuid.UUID]struct{})
+
+	pageToken := ""
+	for {
+		page, err := s.kratosClient.ListIdentities(ctx, identityPageSize, pageToken)
+		if err != nil {
+			return result, fmt.Errorf("failed to list identities: %w", err)
+		}
+
+		identities := make(map[uuid.UUID]*kratos.Identity, len(page.Identities))
+		ids := make([]uuid.UUID, 0, len(page.Identities))
+		for i := range page.Identities {
+			id, err := uuid.Parse(page.Identities[i].ID)
+			if err != nil {
+				continue
+			}
+			seen[id] = struct{}{}
+			identities[id] = &page.Identities[i]
+			ids = append(ids, id)
+		}
+
+		users, err := s.userRepo.ListUsersByKratosIdentityIDs(ctx, ids)
+		if err != nil {
+			return result, fmt.Errorf("failed to load users: %w", err)
+		}
+		for i := range users {
+			result.Checked++
+			changed, err := s.applyIdentity(ctx, &users[i], identities[users[i].KratosIdentityID])
+			if err != nil {
+				log.Printf("Failed to sync identity of user %d: %v", users[i].ID, err)
+				continue
+			}
+			if changed {
+				result.Updated++
+			}
+		}
+
+		if page.NextPageToken == "" {
+			break
+		}
+		pageToken = page.NextPageToken
+	}
+
+	// An empty listing more likely means a misconfigured Kratos than that everyone left
+	if len(seen) == 0 {
+		log.Println("Kratos listed no identities; skipping deletion check")
+		return result, nil
+	}
+
+	// Users created after the listing started may have identities it did not include
+	var afterID uint64
+	for {
+		users, err := s.userRepo.ListUsersWithIdentity(ctx, afterID, started, identityPageSize)
+		if err != nil {
+			return result, fmt.Errorf("failed to load users: %w", err)
+		}
+		for i := range users {
+			if _, ok := seen[users[i].KratosIdentityID]; ok {
+				continue
+			}
+			if err := s.markIdentityDeleted(ctx, &users[i]); err != nil {
+				log.Printf("Failed to mark identity of user %d deleted: %v", users[i].ID, err)
+				continue
+			}
+			result.Deleted++
+		}
+		if len(users) < identityPageSize {
+			break
+		}
+		afterID = users[len(users)-1].ID
+	}
+	return result, nil
+}
+
+// StartIdentityReconciliation runs ReconcileIdentities every interval on one replica
+func (s *UserService) StartIdentityReconciliation(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// The lock is never released; it expires before the next tick
+				acquired, err := s.redisClient.SetNX(ctx, identityReconcileLock, strconv.FormatInt(time.Now().UnixNano(), 10), interval*9/10)
+				if err != nil || !acquired {
+					continue
+				}
+				result, err := s.ReconcileIdentities(ctx)
+				if err != nil {
+					log.Printf("Identity reconciliation failed: %v", err)
+					continue
+				}
+				log.Printf("Identity reconciliation checked %d users, updated %d, found %d deleted", result.Checked, result.Updated, result.Deleted)
+			}
+		}
+	}()
+}
+
+// applyIdentity copies email, display name and state from the identity, and the artist
+// name to the user's artist, and reports whether anything changed. Disabling the identity
+// deactivates the user and signs them out, which also takes their artist page and songs
+// out of listings and search; enabling it again restores both, unless an admin
+// deactivated the user since.
+func (s *UserService) applyIdentity(ctx context.Context, user *model.User, identity *kratos.Identity) (bool, error) {
+	if user.IdentityState == model.IdentityStateDeleted {
+		return false, nil
+	}
+
+	updates := map[string]interface{}{}
+	if email := identity.GetEmail(); email != "" && email != user.Email {
+		updates["email"] = email
+	}
+	if name := identity.GetDisplayName(); name != "" && name != user.DisplayName {
+		updates["display_name"] = name
+	}
+
+	state := identity.State
+	if state == "" {
+		state = model.IdentityStateActive
+	}
+	if state != user.IdentityState {
+		updates["identity_state"] = state
+		if state == model.IdentityStateInactive && user.IsActive {
+			updates["is_active"] = false
+			updates["deactivated_by"] = model.DeactivatedByIdentity
+		} else if state == model.IdentityStateActive && !user.IsActive &&
+			user.DeactivatedBy != nil && *user.DeactivatedBy == model.DeactivatedByIdentity {
+			updates["is_active"] = true
+			updates["deactivated_by"] = nil
+		}
+	}
+
+	changed := len(updates) > 0
+	updates["identity_synced_at"] = time.Now().UTC()
+	if err := s.userRepo.UpdateUser(ctx, user.ID, updates); err != nil {
+		return false, err
+	}
+
+	if active, ok := updates["is_active"].(bool); ok {
+		log.Printf("User %d is_active set to %t: Kratos identity is %s", user.ID, active, state)
+		if err := s.accountChanged(ctx, user.ID, !active); err != nil {
+			return changed, err
+		}
+	}
+
+	if name, ok := identityArtistName(user, identity); ok {
+		renamed, err := s.artistService.SyncArtistName(ctx, user.ID, name)
+		if err != nil {
+			return changed, fmt.Errorf("failed to sync artist name: %w", err)
+		}
+		changed = changed || renamed
+	}
+	return changed, nil
+}
+
+// identityArtistName returns the artist name trait when the user is an artist and the
+// identity has one
+func identityArtistName(user *model.User, identity *kratos.Identity) (string, bool) {
+	if user.UserType != "artist" {
+		return "", false
+	}
+	name := strings.TrimSpace(identity.GetArtistName())
+	return name, name != ""
+}
+
+// markIdentityDeleted deactivates the user of a deleted identity and signs them out. Their
+// artist, if any, drops out of listings and search with the deactivated account.
+func (s *UserService) markIdentityDeleted(ctx context.Context, user *model.User) error {
+	now := time.Now().UTC()
+	updates := map[string]interface{}{
+		"identity_state":      model.IdentityStateDeleted,
+		"identity_deleted_at": now,
+		"identity_synced_at":  now,
+		"is_active":           false,
+	}
+	if user.IsActive {
+		updates["deactivated_by"] = model.DeactivatedByIdentity
+	}
+	err := s.userRepo.UpdateUser(ctx, user.ID, updates)
+	if err != nil {
+		return appError.NewInternalError(err, "failed to update user")
+	}
+	log.Printf("User %d deactivated: Kratos identity %s was deleted", user.ID, user.KratosIdentityID)
+	return s.accountChanged(ctx, user.ID, true)
+}
+
+// accountChanged makes the change take effect at once instead of when cached status and
+// access tokens run out
+func (s *UserService) accountChanged(ctx context.Context, userID uint64, signOut bool) error {
+	if signOut {
+		if err := s.authService.InvalidateUserTokens(ctx, userID, authModel.SessionRevokeIdentity); err != nil {
+			return err
+		}
+	}
+	return s.authService.InvalidateUserStatus(ctx, userID)
+}
@@ -0,0 +1,36 @@
+package application
+
+import (
+	model "music-app-backend/internal/user/domain"
+	"music-app-backend/pkg/kratos"
+	"testing"
+)
+
+func TestIdentityArtistName(t *testing.T) {
+	tests := []struct {
+		name     string
+		userType string
+		traits   map[string]interface{}
+		want     string
+		wantOK   bool
+	}{
+		{name: "artist", userType: "artist", traits: map[string]interface{}{"artist_name": "The Band"}, want: "The Band", wantOK: true},
+		{name: "surrounding spaces trimmed", userType: "artist", traits: map[string]interface{}{"artist_name": "  The Band  "}, want: "The Band", wantOK: true},
+		{name: "artist without the trait", userType: "artist", traits: map[string]interface{}{"email": "band@example.com"}},
+		{name: "blank trait", userType: "artist", traits: map[string]interface{}{"artist_name": "   "}},
+		{name: "trait of the wrong type", userType: "artist", traits: map[string]interface{}{"artist_name": 42}},
+		{name: "no traits", userType: "artist"},
+		{name: "listener with a stale trait", userType: "listener", traits: map[string]interface{}{"artist_name": "The Band"}},
+		{name: "admin", userType: "admin", traits: map[string]interface{}{"artist_name": "The Band"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &model.User{UserType: tt.userType}
+			got, ok := identityArtistName(user, &kratos.Identity{Traits: tt.traits})
+			if got != tt.want || ok != tt.wantOK {
+				t.Fatalf("got (%q, %t), want (%q, %t)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
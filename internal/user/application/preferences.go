@@ -0,0 +1,135 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	model "music-app-backend/internal/user/domain"
+	appError "music-app-backend/pkg/error"
+
+	"github.com/lib/pq"
+)
+
+// maxPreferredIDs bounds the genres and moods picked during onboarding
+const maxPreferredIDs = 20
+
+// GetPreferences returns the user's settings, or the defaults when none were saved
+func (s *UserService) GetPreferences(ctx context.Context, userID uint64) (*model.UserPreference, error) {
+	preference, err := s.userRepo.GetPreference(ctx, userID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load preferences")
+	}
+	if preference == nil {
+		return model.DefaultUserPreference(userID), nil
+	}
+
+	if preference.PreferredGenres == nil {
+		preference.PreferredGenres = pq.Int64Array{}
+	}
+	if preference.PreferredMoods == nil {
+		preference.PreferredMoods = pq.Int64Array{}
+	}
+	return preference, nil
+}
+
+// UpdatePreferences applies the fields present in request. Genre and mood IDs must name
+// active genres and moods; duplicates are dropped.
+func (s *UserService) UpdatePreferences(ctx context.Context, userID uint64, request *model.UpdatePreferencesDTO) (*model.UserPreference, error) {
+	updates := map[string]interface{}{}
+
+	if request.PreferredGenres != nil {
+		genres, err := s.validatePreferredIDs(ctx, "genre", *request.PreferredGenres, s.artistService.ListActiveGenreIDs)
+		if err != nil {
+			return nil, err
+		}
+		updates["preferred_genres"] = genres
+	}
+	if request.PreferredMoods != nil {
+		moods, err := s.validatePreferredIDs(ctx, "mood", *request.PreferredMoods, s.artistService.ListActiveMoodIDs)
+		if err != nil {
+			return nil, err
+		}
+		updates["preferred_moods"] = moods
+	}
+
+	switches := map[string]*bool{
+		"auto_play":                    request.AutoPlay,
+		"shuffle_by_default":           request.ShuffleByDefault,
+		"notification_new_releases":    request.NotificationNewReleases,
+		"notification_artist_messages": request.NotificationArtistMessages,
+		"notification_tips_received":   request.NotificationTipsReceived,
+		"explicit_content_allowed":     request.ExplicitContentAllowed,
+		"email_notifications":          request.EmailNotifications,
+		"marketing_emails":             request.MarketingEmails,
+	}
+	for column, value := range switches {
+		if value != nil {
+			updates[column] = *value
+		}
+	}
+
+	if len(updates) == 0 {
+		return s.GetPreferences(ctx, userID)
+	}
+
+	id, err := s.generator.Generate()
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to update preferences")
+	}
+	if err := s.userRepo.UpdatePreference(ctx, id, userID, updates); err != nil {
+		return nil, appError.NewInternalError(err, "failed to update preferences")
+	}
+
+	return s.GetPreferences(ctx, userID)
+}
+
+// ExplicitContentAllowed implements the music module's ContentPolicy
+func (s *UserService) ExplicitContentAllowed(ctx context.Context, userID uint64) (bool, error) {
+	preference, err := s.userRepo.GetPreference(ctx, userID)
+	if err != nil || preference == nil {
+		return false, err
+	}
+	return preference.ExplicitContentAllowed, nil
+}
+
+func (s *UserService) validatePreferredIDs(ctx context.Context, kind string, ids []uint64, listActive func(context.Context, []uint64) ([]uint64, error)) (pq.Int64Array, error) {
+	unique := make([]uint64, 0, len(ids))
+	seen := make(map[uint64]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) > maxPreferredIDs {
+		return nil, appError.NewBadRequestError(nil, fmt.Sprintf("at most %d %ss can be preferred", maxPreferredIDs, kind))
+	}
+
+	result := make(pq.Int64Array, 0, len(unique))
+	if len(unique) == 0 {
+		return result, nil
+	}
+
+	active, err := listActive(ctx, unique)
+	if err != nil {
+		return nil, appError.NewInternalError(err, fmt.Sprintf("failed to validate %ss", kind))
+	}
+	known := make(map[uint64]bool, len(active))
+	for _, id := range active {
+		known[id] = true
+	}
+
+	var unknown []uint64
+	for _, id := range unique {
+		if !known[id] {
+			unknown = append(unknown, id)
+			continue
+		}
+		result = append(result, int64(id))
+	}
+	if len(unknown) > 0 {
+		return nil, appError.NewBadRequestError(nil, fmt.Sprintf("unknown %s IDs", kind)).WithData(map[string]interface{}{
+			"invalid_ids": unknown,
+		})
+	}
+	return result, nil
+}
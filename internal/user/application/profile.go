@@ -0,0 +1,145 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	model "music-app-backend/internal/user/domain"
+	appError "music-app-backend/pkg/error"
+	"music-app-backend/pkg/kratos"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+// Length limits match the Kratos identity schema, which validates the same traits
+const (
+	maxDisplayNameLength = 100
+	maxBioLength         = 500
+	maxLocationLength    = 100
+)
+
+func (s *UserService) GetProfile(ctx context.Context, userID uint64) (*model.User, error) {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to load profile")
+	}
+	if user == nil {
+		return nil, appError.NewNotFoundError(nil, "user not found")
+	}
+	return user, nil
+}
+
+// UpdateProfile applies the fields present in request to both the Kratos identity and
+// the users table
+func (s *UserService) UpdateProfile(ctx context.Context, userID uint64, request *model.UpdateProfileDTO) (*model.User, error) {
+	user, err := s.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := map[string]interface{}{}
+	traits := map[string]interface{}{}
+
+	if request.DisplayName != nil {
+		name := strings.TrimSpace(*request.DisplayName)
+		if name == "" || utf8.RuneCountInString(name) > maxDisplayNameLength {
+			return nil, appError.NewBadRequestError(nil, fmt.Sprintf("display name must be 1 to %d characters", maxDisplayNameLength))
+		}
+		updates["display_name"] = name
+		traits["display_name"] = name
+	}
+	if request.Bio != nil {
+		value, err := optionalText("bio", *request.Bio, maxBioLength)
+		if err != nil {
+			return nil, err
+		}
+		updates["bio"] = value
+		traits["bio"] = traitValue(value)
+	}
+	if request.Location != nil {
+		value, err := optionalText("location", *request.Location, maxLocationLength)
+		if err != nil {
+			return nil, err
+		}
+		updates["location"] = value
+		traits["location"] = traitValue(value)
+	}
+
+	if len(updates) == 0 {
+		return user, nil
+	}
+
+	if err := s.syncTraits(ctx, user, traits); err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.UpdateUser(ctx, userID, updates); err != nil {
+		return nil, appError.NewInternalError(err, "failed to update profile")
+	}
+
+	return s.GetProfile(ctx, userID)
+}
+
+// SetArtistNameTrait writes an artist rename to the user's Kratos identity
+func (s *UserService) SetArtistNameTrait(ctx context.Context, userID uint64, name string) error {
+	user, err := s.GetProfile(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return s.syncTraits(ctx, user, map[string]interface{}{"artist_name": name})
+}
+
+// syncTraits writes profile changes to the Kratos identity before our own table, so a
+// change Kratos rejects never gets stored and the two do not drift. A nil value removes
+// the trait.
+func (s *UserService) syncTraits(ctx context.Context, user *model.User, changes map[string]interface{}) error {
+	identity, err := s.kratosClient.GetIdentity(ctx, user.KratosIdentityID.String())
+	if err != nil {
+		return kratosError(err)
+	}
+
+	traits := make(map[string]interface{}, len(identity.GetTraits())+len(changes))
+	for key, value := range identity.GetTraits() {
+		traits[key] = value
+	}
+	for key, value := range changes {
+		if value == nil {
+			delete(traits, key)
+			continue
+		}
+		traits[key] = value
+	}
+
+	if _, err := s.kratosClient.UpdateIdentityTraits(ctx, identity, traits); err != nil {
+		return kratosError(err)
+	}
+	return nil
+}
+
+// optionalText trims value and returns nil for an empty one, which clears the field
+func optionalText(field, value string, maxLength int) (*string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	if utf8.RuneCountInString(value) > maxLength {
+		return nil, appError.NewBadRequestError(nil, fmt.Sprintf("%s must be at most %d characters", field, maxLength))
+	}
+	return &value, nil
+}
+
+// traitValue turns a cleared field into an untyped nil, which removes the trait
+func traitValue(value *string) interface{} {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
+
+func kratosError(err error) error {
+	var kratosErr *kratos.KratosError
+	if errors.As(err, &kratosErr) && kratosErr.Code == http.StatusBadRequest {
+		return appError.NewBadRequestError(err, "profile was rejected by the identity service")
+	}
+	return appError.NewInternalError(err, "failed to update identity")
+}
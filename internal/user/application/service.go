@@ -1,35 +1,107 @@
 package application
 
 import (
-	"fmt"
+	"context"
+	"errors"
+	"log"
+	authModuleSvc "music-app-backend/internal/auth/application"
 	musicModuleSvc "music-app-backend/internal/music/application"
 	musicModel "music-app-backend/internal/music/domain"
 	"music-app-backend/internal/user/adapters/repository"
 	model "music-app-backend/internal/user/domain"
+	"music-app-backend/pkg/audit"
+	appError "music-app-backend/pkg/error"
+	"music-app-backend/pkg/kratos"
+	"music-app-backend/pkg/mail"
 	baseModel "music-app-backend/pkg/model"
+	"music-app-backend/pkg/ratelimit"
+	"music-app-backend/pkg/redis"
+	"music-app-backend/pkg/storage"
+	"strings"
+	"time"
 
 	goflakeid "github.com/capy-engineer/go-flakeid"
 	"github.com/google/uuid"
 )
 
+// emailPreferenceColumns maps unsubscribe scopes to the user_preferences column they clear
+var emailPreferenceColumns = map[string]string{
+	mail.ScopeNotifications: "email_notifications",
+	mail.ScopeMarketing:     "marketing_emails",
+}
+
 type UserService struct {
-	userRepo      *repository.UserRepository
-	generator     *goflakeid.Generator
-	artistService musicModuleSvc.IMusicService
+	userRepo          *repository.UserRepository
+	generator         *goflakeid.Generator
+	artistService     musicModuleSvc.IMusicService
+	unsubscribeTokens *mail.UnsubscribeTokens
+	storageService    *storage.MinIOService
+	kratosClient      *kratos.Client
+	authService       *authModuleSvc.AuthService
+	redisClient       *redis.Client
+	auditLog          audit.Logger
+	exportLimiter     *ratelimit.Limiter
+
+	deletionGracePeriod time.Duration
+	exportRetention     time.Duration
 }
 
-func NewUserService(userRepo *repository.UserRepository, generator *goflakeid.Generator, artistService musicModuleSvc.IMusicService) *UserService {
+func NewUserService(
+	userRepo *repository.UserRepository,
+	generator *goflakeid.Generator,
+	artistService musicModuleSvc.IMusicService,
+	unsubscribeTokens *mail.UnsubscribeTokens,
+	storageService *storage.MinIOService,
+	kratosClient *kratos.Client,
+	authService *authModuleSvc.AuthService,
+	redisClient *redis.Client,
+	auditLog audit.Logger,
+	exportLimiter *ratelimit.Limiter,
+	deletionGracePeriod time.Duration,
+	exportRetention time.Duration,
+) *UserService {
 	return &UserService{
-		userRepo:      userRepo,
-		generator:     generator,
-		artistService: artistService,
+		userRepo:          userRepo,
+		generator:         generator,
+		artistService:     artistService,
+		unsubscribeTokens: unsubscribeTokens,
+		storageService:    storageService,
+		kratosClient:      kratosClient,
+		authService:       authService,
+		redisClient:       redisClient,
+		auditLog:          auditLog,
+		exportLimiter:     exportLimiter,
+
+		deletionGracePeriod: deletionGracePeriod,
+		exportRetention:     exportRetention,
 	}
 }
 
-func (s *UserService) CreateUserAfterRegistration(user *model.AfterRegistrationRequest) (*uint64, error) {
+// CreateUserAfterRegistration creates the user Kratos just registered, and their artist
+// for artist accounts, all or nothing. Invalid traits come back as *model.RegistrationError.
+func (s *UserService) CreateUserAfterRegistration(ctx context.Context, user *model.AfterRegistrationRequest) (*uint64, error) {
 	identityID, err := uuid.Parse(user.Identity.ID)
 	if err != nil {
-		return nil, err
+		return nil, &model.RegistrationError{Message: "invalid identity ID"}
+	}
+	traits := user.Identity.Traits
+
+	var artist *musicModel.Artist
+	switch traits.UserType {
+	case "listener":
+	case "artist":
+		if traits.ArtistName == nil || strings.TrimSpace(*traits.ArtistName) == "" {
+			return nil, &model.RegistrationError{Field: "artist_name", Message: "artist name is required for artist accounts"}
+		}
+		artist, err = s.artistService.NewArtist(&musicModel.CreateArtistDTO{ArtistName: *traits.ArtistName})
+		if err != nil {
+			if appErr, ok := appError.GetAppError(err); ok {
+				return nil, &model.RegistrationError{Field: "artist_name", Message: appErr.Message}
+			}
+			return nil, err
+		}
+	default:
+		return nil, &model.RegistrationError{Field: "user_type", Message: "account type must be listener or artist"}
 	}
 
 	baseModelInstance, err := baseModel.NewBaseModel(s.generator)
@@ -37,35 +109,86 @@ func (s *UserService) CreateUserAfterRegistration(user *model.AfterRegistrationR
 		return nil, err
 	}
 
-	userModel, err := s.userRepo.CreateUserAfterRegistration(&model.User{
+	userModel, created, err := s.userRepo.CreateUserAfterRegistration(ctx, &model.User{
 		BaseModel:        *baseModelInstance,
 		KratosIdentityID: identityID,
-		Email:            user.Identity.Traits.Email,
-		UserType:         user.Identity.Traits.UserType,
-		DisplayName:      user.Identity.Traits.DisplayName,
-		AvatarURL:        "",
+		Email:            traits.Email,
+		UserType:         traits.UserType,
+		DisplayName:      traits.DisplayName,
+		AvatarURL:        stringValue(traits.ProfileImage),
+		Bio:              traits.Bio,
+		Location:         traits.Location,
 		IsActive:         true,
 		LastLoginAt:      nil,
-	})
+	}, artist)
+	if err != nil {
+		// The hook runs before Kratos saves the identity, so its own duplicate check has not run yet
+		if errors.Is(err, repository.ErrEmailInUse) {
+			return nil, &model.RegistrationError{Field: "email", Message: "an account with this email already exists"}
+		}
+		return nil, err
+	}
+	// Kratos retries webhooks that time out; the retry gets the user the first call created
+	if !created {
+		return &userModel.ID, nil
+	}
 
+	preferences := traits.Preferences
+	preferenceID, err := s.generator.Generate()
 	if err != nil {
 		return nil, err
 	}
+	if err := s.userRepo.SaveEmailPreferences(ctx, preferenceID, userModel.ID, preferences.EmailNotifications, preferences.MarketingEmails); err != nil {
+		// The account exists already; the user keeps the default email settings
+		log.Printf("Failed to save email preferences for user %d: %v", userModel.ID, err)
+	}
 
-	if user.Identity.Traits.UserType == "artist" {
-		artist := musicModel.CreateArtistDTO{
-			UserID:     userModel.ID,
-			ArtistName: *user.Identity.Traits.ArtistName,
-		}
+	return &userModel.ID, nil
+}
+
+// CheckUnsubscribe reports what the token would switch off without changing anything, so
+// the unsubscribe page can ask for confirmation
+func (s *UserService) CheckUnsubscribe(token string) (*model.UnsubscribeResult, error) {
+	_, scope, err := s.verifyUnsubscribeToken(token)
+	if err != nil {
+		return nil, err
+	}
+	return &model.UnsubscribeResult{Scope: scope}, nil
+}
 
-		fmt.Println("artist", artist)
+// Unsubscribe switches off the emails covered by the token. It needs no session, so links
+// in emails and one-click List-Unsubscribe requests both work; repeating it is harmless.
+func (s *UserService) Unsubscribe(ctx context.Context, token string) (*model.UnsubscribeResult, error) {
+	userID, scope, err := s.verifyUnsubscribeToken(token)
+	if err != nil {
+		return nil, err
+	}
 
-		err = s.artistService.InsertArtist(&artist)
-		fmt.Println("err", err)
-		if err != nil {
-			return nil, err
-		}
+	id, err := s.generator.Generate()
+	if err != nil {
+		return nil, appError.NewInternalError(err, "failed to update email preferences")
+	}
+	if err := s.userRepo.DisableEmailPreference(ctx, id, userID, emailPreferenceColumns[scope]); err != nil {
+		return nil, appError.NewInternalError(err, "failed to update email preferences")
 	}
 
-	return &userModel.ID, nil
+	return &model.UnsubscribeResult{Scope: scope, Unsubscribed: true}, nil
+}
+
+func (s *UserService) verifyUnsubscribeToken(token string) (uint64, string, error) {
+	userID, scope, err := s.unsubscribeTokens.Verify(token)
+	if err != nil {
+		return 0, "", appError.NewBadRequestError(err, "invalid unsubscribe link")
+	}
+	if _, ok := emailPreferenceColumns[scope]; !ok {
+		return 0, "", appError.NewBadRequestError(nil, "invalid unsubscribe link")
+	}
+	return userID, scope, nil
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
 }
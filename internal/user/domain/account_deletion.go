@@ -0,0 +1,84 @@
+package model
+
+import (
+	"encoding/json"
+	"music-app-backend/pkg/model"
+	"music-app-backend/pkg/pagination"
+	"time"
+)
+
+type AccountDeletionStatus string
+
+const (
+	AccountDeletionPending    AccountDeletionStatus = "pending"
+	AccountDeletionCancelled  AccountDeletionStatus = "cancelled"
+	AccountDeletionProcessing AccountDeletionStatus = "processing"
+	AccountDeletionFailed     AccountDeletionStatus = "failed"
+	AccountDeletionCompleted  AccountDeletionStatus = "completed"
+)
+
+// AccountDeletion is a user's request to delete their account. It runs once
+// ScheduledFor has passed, unless the user cancelled it before.
+type AccountDeletion struct {
+	model.BaseModel
+	UserID       uint64                `json:"-" gorm:"not null;index"`
+	Status       AccountDeletionStatus `json:"status" gorm:"not null;size:20"`
+	Reason       *string               `json:"reason,omitempty"`
+	ScheduledFor time.Time             `json:"scheduled_for" gorm:"not null"`
+	Attempts     int                   `json:"-" gorm:"not null;default:0"`
+	LastError    *string               `json:"-"`
+	Report       json.RawMessage       `json:"-" gorm:"type:jsonb"`
+	CancelledAt  *time.Time            `json:"cancelled_at,omitempty"`
+	CompletedAt  *time.Time            `json:"completed_at,omitempty"`
+}
+
+func (AccountDeletion) TableName() string {
+	return "account_deletions"
+}
+
+// AccountDeletionView is how operators see a deletion request, with the fields its owner
+// is not shown
+type AccountDeletionView struct {
+	ID           uint64                `json:"id"`
+	UserID       uint64                `json:"user_id"`
+	Status       AccountDeletionStatus `json:"status"`
+	ScheduledFor time.Time             `json:"scheduled_for"`
+	Attempts     int                   `json:"attempts"`
+	LastError    *string               `json:"last_error"`
+	CreatedAt    time.Time             `json:"created_at"`
+	UpdatedAt    time.Time             `json:"updated_at"`
+}
+
+type AccountDeletionListResult struct {
+	Deletions  []AccountDeletionView `json:"deletions"`
+	Pagination pagination.Meta       `json:"pagination"`
+}
+
+func NewAccountDeletionView(deletion *AccountDeletion) AccountDeletionView {
+	return AccountDeletionView{
+		ID:           deletion.ID,
+		UserID:       deletion.UserID,
+		Status:       deletion.Status,
+		ScheduledFor: deletion.ScheduledFor,
+		Attempts:     deletion.Attempts,
+		LastError:    deletion.LastError,
+		CreatedAt:    deletion.CreatedAt,
+		UpdatedAt:    deletion.UpdatedAt,
+	}
+}
+
+type RequestAccountDeletionDTO struct {
+	Reason *string `json:"reason"`
+}
+
+// AccountDeletionReport records what erasing an account did, table by table
+type AccountDeletionReport struct {
+	UserID                uint64            `json:"user_id"`
+	ArtistID              *uint64           `json:"artist_id,omitempty"`
+	RowsDeleted           map[string]int64  `json:"rows_deleted"`
+	RowsAnonymized        map[string]int64  `json:"rows_anonymized"`
+	StorageObjectsDeleted int               `json:"storage_objects_deleted"`
+	IdentityDeleted       bool              `json:"identity_deleted"`
+	Retained              map[string]string `json:"retained"` // Table to the reason its rows were kept
+	CompletedAt           time.Time         `json:"completed_at"`
+}
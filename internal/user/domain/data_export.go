@@ -0,0 +1,65 @@
+package model
+
+import (
+	"music-app-backend/pkg/model"
+	"time"
+)
+
+// DataExportFormatVersion is bumped whenever the archive layout or a section changes
+const DataExportFormatVersion = 1
+
+type DataExportStatus string
+
+const (
+	DataExportPending    DataExportStatus = "pending"
+	DataExportProcessing DataExportStatus = "processing"
+	DataExportFailed     DataExportStatus = "failed"
+	DataExportCompleted  DataExportStatus = "completed"
+	DataExportExpired    DataExportStatus = "expired"
+)
+
+// DataExport is an archive of the personal data of a user, built in the background
+type DataExport struct {
+	model.BaseModel
+	UserID        uint64           `json:"-" gorm:"not null;index"`
+	Status        DataExportStatus `json:"status" gorm:"not null;size:20"`
+	FormatVersion int              `json:"format_version" gorm:"not null"`
+	ObjectKey     *string          `json:"-"`
+	SizeBytes     *int64           `json:"size_bytes,omitempty"`
+	Attempts      int              `json:"-" gorm:"not null;default:0"`
+	LastError     *string          `json:"-"`
+	CompletedAt   *time.Time       `json:"completed_at,omitempty"`
+	ExpiresAt     *time.Time       `json:"expires_at,omitempty"`
+
+	// Set on completed exports when they are returned to their owner
+	DownloadURL          string     `json:"download_url,omitempty" gorm:"-"`
+	DownloadURLExpiresAt *time.Time `json:"download_url_expires_at,omitempty" gorm:"-"`
+}
+
+func (DataExport) TableName() string {
+	return "data_exports"
+}
+
+// DataExportManifest is manifest.json, the first file of the archive
+type DataExportManifest struct {
+	FormatVersion int                       `json:"format_version"`
+	ExportID      uint64                    `json:"export_id"`
+	UserID        uint64                    `json:"user_id"`
+	GeneratedAt   time.Time                 `json:"generated_at"`
+	Sections      []DataExportManifestEntry `json:"sections"`
+}
+
+type DataExportManifestEntry struct {
+	Name     string   `json:"name"`
+	Rows     int      `json:"rows"`
+	JSONFile string   `json:"json_file"`
+	CSVFile  string   `json:"csv_file"`
+	Columns  []string `json:"columns"`
+}
+
+// DataExportSection is one table of exported data, in column order
+type DataExportSection struct {
+	Name    string
+	Columns []string
+	Rows    [][]interface{}
+}
@@ -56,3 +56,76 @@ type RequestContextData struct {
 	IPAddress *string `json:"ip_address"`
 	Timestamp string  `json:"timestamp"`
 }
+
+// UnsubscribeResult tells the unsubscribe page which emails the token switches off
+type UnsubscribeResult struct {
+	Scope        string `json:"scope"`
+	Unsubscribed bool   `json:"unsubscribed"`
+}
+
+// UpdatePreferencesDTO is a partial update; fields left out of the request stay unchanged
+type UpdatePreferencesDTO struct {
+	PreferredGenres            *[]uint64 `json:"preferred_genres"`
+	PreferredMoods             *[]uint64 `json:"preferred_moods"`
+	AutoPlay                   *bool     `json:"auto_play"`
+	ShuffleByDefault           *bool     `json:"shuffle_by_default"`
+	NotificationNewReleases    *bool     `json:"notification_new_releases"`
+	NotificationArtistMessages *bool     `json:"notification_artist_messages"`
+	NotificationTipsReceived   *bool     `json:"notification_tips_received"`
+	ExplicitContentAllowed     *bool     `json:"explicit_content_allowed"`
+	EmailNotifications         *bool     `json:"email_notifications"`
+	MarketingEmails            *bool     `json:"marketing_emails"`
+}
+
+// UpdateProfileDTO is a partial profile update; an empty bio or location clears it
+type UpdateProfileDTO struct {
+	DisplayName *string `json:"display_name"`
+	Bio         *string `json:"bio"`
+	Location    *string `json:"location"`
+}
+
+// AvatarUploadRequest describes the image the client is about to upload
+type AvatarUploadRequest struct {
+	ContentType string `json:"content_type" binding:"required"`
+	Size        int64  `json:"size" binding:"required,min=1"`
+}
+
+// AvatarUploadURL is where the client POSTs the image, as a multipart form with Fields
+// followed by the file, before confirming ObjectKey
+type AvatarUploadURL struct {
+	ObjectKey string            `json:"object_key"`
+	URL       string            `json:"url"`
+	Method    string            `json:"method"`
+	Fields    map[string]string `json:"fields"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+type ConfirmAvatarRequest struct {
+	ObjectKey string `json:"object_key" binding:"required"`
+}
+
+// RegistrationError rejects a registration because of one identity trait. Field is the
+// trait's name, or empty when the registration as a whole is rejected.
+type RegistrationError struct {
+	Field   string
+	Message string
+}
+
+func (e *RegistrationError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + ": " + e.Message
+}
+
+// IdentityReconcileResult counts what one reconciliation with Kratos did
+type IdentityReconcileResult struct {
+	Checked int `json:"checked"`
+	Updated int `json:"updated"`
+	Deleted int `json:"deleted"`
+}
+
+// IdentityHook is the body of the Kratos webhooks that only name the identity
+type IdentityHook struct {
+	IdentityID string `json:"identity_id" binding:"required"`
+}
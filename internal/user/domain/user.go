@@ -14,6 +14,28 @@ type User struct {
 	UserType         string     `json:"user_type" gorm:"not null;size:20;check:user_type IN ('artist', 'listener', 'admin')"`
 	DisplayName      string     `json:"display_name" gorm:"size:100"`
 	AvatarURL        string     `json:"avatar_url"`
+	Bio              *string    `json:"bio" gorm:"size:500"`
+	Location         *string    `json:"location" gorm:"size:100"`
 	IsActive         bool       `json:"is_active" gorm:"default:true"`
 	LastLoginAt      *time.Time `json:"last_login_at"`
+
+	// Mirrors the Kratos identity, see IdentityState*
+	IdentityState     string     `json:"-" gorm:"not null;size:20;default:active"`
+	IdentityDeletedAt *time.Time `json:"-"`
+	IdentitySyncedAt  *time.Time `json:"-"`
+	// Set while is_active is false, see DeactivatedBy*
+	DeactivatedBy *string `json:"-" gorm:"size:20"`
 }
+
+const (
+	IdentityStateActive   = "active"
+	IdentityStateInactive = "inactive"
+	IdentityStateDeleted  = "deleted"
+)
+
+// Who deactivated a user. Only a deactivation by the identity is undone when the
+// Kratos identity is enabled again.
+const (
+	DeactivatedByAdmin    = "admin"
+	DeactivatedByIdentity = "identity"
+)
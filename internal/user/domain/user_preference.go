@@ -2,17 +2,35 @@ package model
 
 import (
 	"music-app-backend/pkg/model"
+
+	"github.com/lib/pq"
 )
 
 type UserPreference struct {
 	model.BaseModel
-	UserID                     uint64 `json:"user_id" gorm:"not null;unique"`
-	PreferredGenres            []int  `json:"preferred_genres" gorm:"type:integer[]"`
-	PreferredMoods             []int  `json:"preferred_moods" gorm:"type:integer[]"`
-	AutoPlay                   bool   `json:"auto_play" gorm:"default:true"`
-	ShuffleByDefault           bool   `json:"shuffle_by_default" gorm:"default:false"`
-	NotificationNewReleases    bool   `json:"notification_new_releases" gorm:"default:true"`
-	NotificationArtistMessages bool   `json:"notification_artist_messages" gorm:"default:true"`
-	NotificationTipsReceived   bool   `json:"notification_tips_received" gorm:"default:true"`
-	ExplicitContentAllowed     bool   `json:"explicit_content_allowed" gorm:"default:false"`
+	UserID                     uint64        `json:"user_id" gorm:"not null;unique"`
+	PreferredGenres            pq.Int64Array `json:"preferred_genres" gorm:"type:bigint[]"`
+	PreferredMoods             pq.Int64Array `json:"preferred_moods" gorm:"type:bigint[]"`
+	AutoPlay                   bool          `json:"auto_play" gorm:"default:true"`
+	ShuffleByDefault           bool          `json:"shuffle_by_default" gorm:"default:false"`
+	NotificationNewReleases    bool          `json:"notification_new_releases" gorm:"default:true"`
+	NotificationArtistMessages bool          `json:"notification_artist_messages" gorm:"default:true"`
+	NotificationTipsReceived   bool          `json:"notification_tips_received" gorm:"default:true"`
+	ExplicitContentAllowed     bool          `json:"explicit_content_allowed" gorm:"default:false"`
+	EmailNotifications         bool          `json:"email_notifications" gorm:"default:true"`
+	MarketingEmails            bool          `json:"marketing_emails" gorm:"default:false"`
+}
+
+// DefaultUserPreference mirrors the column defaults, for users who never saved any
+func DefaultUserPreference(userID uint64) *UserPreference {
+	return &UserPreference{
+		UserID:                     userID,
+		PreferredGenres:            pq.Int64Array{},
+		PreferredMoods:             pq.Int64Array{},
+		AutoPlay:                   true,
+		NotificationNewReleases:    true,
+		NotificationArtistMessages: true,
+		NotificationTipsReceived:   true,
+		EmailNotifications:         true,
+	}
 }
@@ -1,11 +1,20 @@
 package user
 
 import (
+	"context"
+	authModuleSvc "music-app-backend/internal/auth/application"
 	musicModuleSvc "music-app-backend/internal/music/application"
 	"music-app-backend/internal/user/adapters/http"
 	"music-app-backend/internal/user/adapters/repository"
 	"music-app-backend/internal/user/application"
 	ctx2 "music-app-backend/pkg/context"
+	"music-app-backend/pkg/kratos"
+	"music-app-backend/pkg/mail"
+	"music-app-backend/pkg/middleware"
+	"music-app-backend/pkg/ratelimit"
+	"os"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -16,11 +25,17 @@ type UserModule struct {
 	Handler    *http.UserHandler
 
 	artistService musicModuleSvc.IMusicService
+	authMiddleware *middleware.AuthMiddleware
+	kratosWebhook  gin.HandlerFunc
 }
 
-func NewUserModule(serviceContext *ctx2.ServiceContext, artistService musicModuleSvc.IMusicService) *UserModule {
+func NewUserModule(serviceContext *ctx2.ServiceContext, authMiddleware *middleware.AuthMiddleware, kratosClient *kratos.Client, kratosWebhook gin.HandlerFunc, authService *authModuleSvc.AuthService, artistService musicModuleSvc.IMusicService) *UserModule {
 	userRepo := repository.NewUserRepository(serviceContext.GetDB())
-	userService := application.NewUserService(userRepo, serviceContext.GetIDGenerator(), artistService)
+	exportLimiter := ratelimit.NewLimiter(serviceContext.GetRedisClient(), "data_exports",
+		ratelimit.Limit{Max: intFromEnv("DATA_EXPORT_LIMIT_PER_DAY", 1), Window: 24 * time.Hour},
+		ratelimit.Limit{Max: intFromEnv("DATA_EXPORT_LIMIT_PER_WEEK", 3), Window: 7 * 24 * time.Hour},
+	)
+	userService := application.NewUserService(userRepo, serviceContext.GetIDGenerator(), artistService, mail.NewUnsubscribeTokensFromEnv(), serviceContext.GetStorageService(), kratosClient, authService, serviceContext.GetRedisClient(), serviceContext.GetAuditLog(), exportLimiter, durationFromEnv("ACCOUNT_DELETION_GRACE_PERIOD", 30*24*time.Hour), durationFromEnv("DATA_EXPORT_RETENTION", 7*24*time.Hour))
 	userHandler := http.NewUserHandler(userService)
 
 	return &UserModule{
@@ -28,10 +43,77 @@ func NewUserModule(serviceContext *ctx2.ServiceContext, artistService musicModul
 		Service:    userService,
 		Handler:    userHandler,
 		artistService: artistService,
+		authMiddleware: authMiddleware,
+		kratosWebhook:  kratosWebhook,
 	}
 }
 
 func (u *UserModule) RegisterRoutes(router *gin.RouterGroup) {
 	internal := router.Group("internal")
-	internal.POST("/hooks/after-registration", u.Handler.AfterRegistration)
+	internal.POST("/hooks/after-registration", u.kratosWebhook, u.Handler.AfterRegistration)
+	internal.POST("/hooks/after-settings", u.kratosWebhook, u.Handler.AfterSettings)
+
+	// Opened from emails, so the signed token replaces a session
+	email := router.Group("/email")
+	email.GET("/unsubscribe", u.Handler.CheckUnsubscribe)
+	email.POST("/unsubscribe", u.Handler.Unsubscribe)
+
+	me := router.Group("/me")
+	me.Use(u.authMiddleware.RequireAuth())
+	{
+		me.GET("/profile", u.Handler.GetProfile)
+		me.PATCH("/profile", u.Handler.UpdateProfile)
+		me.POST("/avatar/upload-url", u.Handler.CreateAvatarUploadURL)
+		me.PUT("/avatar", u.Handler.ConfirmAvatar)
+		me.DELETE("/avatar", u.Handler.RemoveAvatar)
+
+		me.GET("/preferences", u.Handler.GetPreferences)
+		me.PATCH("/preferences", u.Handler.UpdatePreferences)
+
+		me.POST("/account/deletion", u.Handler.RequestAccountDeletion)
+		me.GET("/account/deletion", u.Handler.GetAccountDeletion)
+		me.DELETE("/account/deletion", u.Handler.CancelAccountDeletion)
+
+		me.POST("/data-exports", u.Handler.RequestDataExport)
+		me.GET("/data-exports", u.Handler.ListDataExports)
+		me.GET("/data-exports/:export_id", u.Handler.GetDataExport)
+	}
+
+	// Deletions the worker gave up on stay failed until an operator retries them
+	deletions := router.Group("/admin/account-deletions")
+	deletions.Use(u.authMiddleware.RequireAuth(), u.authMiddleware.RequireAdmin(), u.authMiddleware.RequirePermission(middleware.PermissionManageUsers))
+	{
+		deletions.GET("", u.Handler.ListAccountDeletions)
+		deletions.POST("/:deletion_id/retry", u.Handler.RetryAccountDeletion)
+	}
+}
+
+// StartWorkers launches the reconciliation with Kratos identities
+// (IDENTITY_RECONCILE_INTERVAL, default 1h), the erasure of accounts whose deletion
+// grace period is over (ACCOUNT_DELETION_INTERVAL, default 1h), the data export builder
+// (DATA_EXPORT_INTERVAL, default 1m) and the sweep of unconfirmed avatar uploads
+// (AVATAR_UPLOAD_SWEEP_INTERVAL, default 1h)
+func (u *UserModule) StartWorkers(ctx context.Context) {
+	u.Service.StartIdentityReconciliation(ctx, durationFromEnv("IDENTITY_RECONCILE_INTERVAL", time.Hour))
+	u.Service.StartAccountDeletions(ctx, durationFromEnv("ACCOUNT_DELETION_INTERVAL", time.Hour))
+	u.Service.StartDataExports(ctx, durationFromEnv("DATA_EXPORT_INTERVAL", time.Minute))
+	u.Service.StartAvatarUploadSweeper(ctx, durationFromEnv("AVATAR_UPLOAD_SWEEP_INTERVAL", time.Hour))
+}
+
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+			return parsed
+		}
+	}
+	return fallback
+}
+
+func intFromEnv(key string, defaultValue int64) int64 {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseInt(value, 10, 64); err == nil && parsed > 0 {
+			return parsed
+		}
+	}
+	return defaultValue
 }
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Tips are written through the base model, which tracks updated_at
+ALTER TABLE tips ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP;
+
+-- Listing indexes for "tips I sent" and "tips received"
+CREATE INDEX IF NOT EXISTS idx_tips_from_user_created ON tips(from_user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_tips_artist_created ON tips(to_artist_id, created_at DESC);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_tips_artist_created;
+DROP INDEX IF EXISTS idx_tips_from_user_created;
+ALTER TABLE tips DROP COLUMN IF EXISTS updated_at;
+
+-- +goose StatementEnd
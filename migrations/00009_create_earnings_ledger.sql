@@ -0,0 +1,82 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Double-entry ledger: each transaction's entries sum to zero per currency
+CREATE TABLE ledger_transactions (
+    id BIGINT PRIMARY KEY NOT NULL,
+    kind VARCHAR(50) NOT NULL, -- tip, refund, adjustment, payout, payout_settled, payout_failed
+    currency VARCHAR(3) NOT NULL,
+    reference_type VARCHAR(50) NOT NULL, -- tip, refund, adjustment, payout
+    reference_id BIGINT NOT NULL, -- No FK reference
+    description TEXT,
+    created_by_user_id BIGINT, -- No FK reference, set for manual adjustments
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE(kind, reference_type, reference_id)
+);
+
+CREATE TABLE ledger_entries (
+    id BIGINT PRIMARY KEY NOT NULL,
+    transaction_id BIGINT NOT NULL, -- No FK reference
+    account VARCHAR(50) NOT NULL, -- tips_clearing, artist_available, artist_payout_pending, payouts_settled, platform_revenue, adjustments
+    artist_id BIGINT, -- No FK reference, set on artist accounts
+    currency VARCHAR(3) NOT NULL,
+    amount_cents BIGINT NOT NULL, -- Signed; entries of one transaction sum to zero
+    entry_type VARCHAR(50) NOT NULL, -- tip_received, tip_payout, platform_fee, refund, adjustment, payout
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_ledger_entries_transaction_id ON ledger_entries(transaction_id);
+CREATE INDEX idx_ledger_entries_artist_account ON ledger_entries(artist_id, account, currency, created_at);
+CREATE INDEX idx_ledger_entries_account_currency ON ledger_entries(account, currency);
+
+-- Payout batches and the payouts they create
+CREATE TABLE payout_batches (
+    id BIGINT PRIMARY KEY NOT NULL,
+    status VARCHAR(50) NOT NULL, -- completed
+    threshold_cents BIGINT NOT NULL,
+    payout_count INTEGER DEFAULT 0,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE payouts (
+    id BIGINT PRIMARY KEY NOT NULL,
+    batch_id BIGINT NOT NULL, -- No FK reference
+    artist_id BIGINT NOT NULL, -- No FK reference
+    currency VARCHAR(3) NOT NULL,
+    amount_cents BIGINT NOT NULL,
+    status VARCHAR(50) NOT NULL, -- pending, processing, paid, failed
+    ledger_transaction_id BIGINT NOT NULL, -- No FK reference
+    external_reference VARCHAR(100),
+    failure_reason TEXT,
+    paid_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_payouts_batch_id ON payouts(batch_id);
+CREATE INDEX idx_payouts_artist_id ON payouts(artist_id, created_at DESC);
+CREATE INDEX idx_payouts_status ON payouts(status);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_payouts_status;
+DROP INDEX IF EXISTS idx_payouts_artist_id;
+DROP INDEX IF EXISTS idx_payouts_batch_id;
+DROP TABLE IF EXISTS payouts;
+DROP TABLE IF EXISTS payout_batches;
+DROP INDEX IF EXISTS idx_ledger_entries_account_currency;
+DROP INDEX IF EXISTS idx_ledger_entries_artist_account;
+DROP INDEX IF EXISTS idx_ledger_entries_transaction_id;
+DROP TABLE IF EXISTS ledger_entries;
+DROP TABLE IF EXISTS ledger_transactions;
+
+-- +goose StatementEnd
@@ -0,0 +1,67 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Running total of refunded cents; status becomes partially_refunded or refunded
+ALTER TABLE tips ADD COLUMN IF NOT EXISTS refunded_cents INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE tip_refunds (
+    id BIGINT PRIMARY KEY NOT NULL,
+    tip_id BIGINT NOT NULL, -- No FK reference
+    amount_cents INTEGER NOT NULL CHECK (amount_cents > 0),
+    currency VARCHAR(3) NOT NULL,
+    platform_fee_cents INTEGER NOT NULL, -- Share of amount_cents taken back from the platform fee
+    artist_payout_cents INTEGER NOT NULL, -- Share of amount_cents taken back from the artist
+    kind VARCHAR(20) NOT NULL, -- refund, chargeback
+    source VARCHAR(20) NOT NULL, -- admin, webhook
+    reason TEXT NOT NULL,
+    actor_user_id BIGINT, -- No FK reference, set for admin refunds
+    external_refund_id VARCHAR(100) UNIQUE, -- Provider refund or dispute ID
+    fully_refunded BOOLEAN NOT NULL DEFAULT false, -- This refund brought the tip to zero
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_tip_refunds_tip ON tip_refunds(tip_id, created_at);
+
+-- Mirror of update_tip_totals: takes the refunded payout back out of the artist and song
+-- totals, and drops the song's tip count once the tip is fully refunded
+CREATE OR REPLACE FUNCTION reverse_tip_totals()
+RETURNS TRIGGER AS $$
+DECLARE
+    refunded_tip RECORD;
+BEGIN
+    SELECT to_artist_id, song_id INTO refunded_tip FROM tips WHERE id = NEW.tip_id;
+
+    UPDATE artists
+    SET total_earnings = total_earnings - (NEW.artist_payout_cents / 100.0),
+        updated_at = CURRENT_TIMESTAMP
+    WHERE id = refunded_tip.to_artist_id;
+
+    IF refunded_tip.song_id IS NOT NULL THEN
+        UPDATE songs
+        SET tip_count = tip_count - CASE WHEN NEW.fully_refunded THEN 1 ELSE 0 END,
+            total_tips = total_tips - (NEW.artist_payout_cents / 100.0),
+            updated_at = CURRENT_TIMESTAMP
+        WHERE id = refunded_tip.song_id;
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_reverse_tip_totals
+    AFTER INSERT ON tip_refunds
+    FOR EACH ROW
+    EXECUTE FUNCTION reverse_tip_totals();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS trigger_reverse_tip_totals ON tip_refunds;
+DROP FUNCTION IF EXISTS reverse_tip_totals();
+DROP TABLE IF EXISTS tip_refunds;
+ALTER TABLE tips DROP COLUMN IF EXISTS refunded_cents;
+
+-- +goose StatementEnd
@@ -0,0 +1,84 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Song plays are written through the base model and grouped by the client's play ID
+ALTER TABLE song_plays ADD COLUMN IF NOT EXISTS client_play_id VARCHAR(100);
+ALTER TABLE song_plays ADD COLUMN IF NOT EXISTS created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE song_plays ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_song_plays_client_play_id ON song_plays(client_play_id);
+
+-- Raw client events; the unique client_event_id makes ingestion idempotent
+CREATE TABLE play_events (
+    id BIGINT PRIMARY KEY NOT NULL,
+    client_event_id VARCHAR(100) NOT NULL UNIQUE,
+    song_play_id BIGINT NOT NULL, -- No FK reference
+    event_type VARCHAR(20) NOT NULL, -- start, progress, end, skip
+    position_seconds INTEGER DEFAULT 0,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL, -- Client clock
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_play_events_song_play ON play_events(song_play_id);
+
+-- The original function declared a variable named artist_id, which is ambiguous with
+-- songs.artist_id and fails on the first insert
+CREATE OR REPLACE FUNCTION update_artist_totals()
+RETURNS TRIGGER AS $$
+DECLARE
+    v_artist_id BIGINT;
+BEGIN
+    SELECT s.artist_id INTO v_artist_id FROM songs AS s WHERE s.id = NEW.song_id;
+
+    -- Update song
+    UPDATE songs
+    SET play_count = play_count + 1,
+        updated_at = CURRENT_TIMESTAMP
+    WHERE id = NEW.song_id;
+
+    -- Update artist
+    UPDATE artists
+    SET total_plays = total_plays + 1,
+        updated_at = CURRENT_TIMESTAMP
+    WHERE id = v_artist_id;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Put back update_artist_totals exactly as 00005 created it
+CREATE OR REPLACE FUNCTION update_artist_totals()
+RETURNS TRIGGER AS $$
+DECLARE
+    artist_id BIGINT;
+BEGIN
+    SELECT artist_id INTO artist_id FROM songs WHERE id = NEW.song_id;
+
+    -- Update song
+    UPDATE songs
+    SET play_count = play_count + 1,
+        updated_at = CURRENT_TIMESTAMP
+    WHERE id = NEW.song_id;
+
+    -- Update artist
+    UPDATE artists
+    SET total_plays = total_plays + 1,
+        updated_at = CURRENT_TIMESTAMP
+    WHERE id = artist_id;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TABLE IF EXISTS play_events;
+DROP INDEX IF EXISTS idx_song_plays_client_play_id;
+ALTER TABLE song_plays DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE song_plays DROP COLUMN IF EXISTS created_at;
+ALTER TABLE song_plays DROP COLUMN IF EXISTS client_play_id;
+
+-- +goose StatementEnd
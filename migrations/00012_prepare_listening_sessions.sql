@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Listening sessions are written through the base model
+ALTER TABLE listening_sessions ADD COLUMN IF NOT EXISTS created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE listening_sessions ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP;
+
+-- Starting a session ends the open sessions of the same client
+CREATE INDEX IF NOT EXISTS idx_listening_sessions_client_active ON listening_sessions(session_id) WHERE is_active = true;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_listening_sessions_client_active;
+ALTER TABLE listening_sessions DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE listening_sessions DROP COLUMN IF EXISTS created_at;
+
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Messages are queued and fanned out by a worker
+ALTER TABLE artist_messages ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'queued'; -- queued, sending, sent, failed
+ALTER TABLE artist_messages ADD COLUMN IF NOT EXISTS sent_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE artist_messages ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_artist_messages_artist ON artist_messages(artist_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_artist_messages_pending ON artist_messages(updated_at) WHERE status IN ('queued', 'sending');
+
+-- Deliveries are written through the base model
+ALTER TABLE message_deliveries ADD COLUMN IF NOT EXISTS created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE message_deliveries ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_message_deliveries_inbox ON message_deliveries(user_id, delivered_at DESC);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_message_deliveries_inbox;
+ALTER TABLE message_deliveries DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE message_deliveries DROP COLUMN IF EXISTS created_at;
+
+DROP INDEX IF EXISTS idx_artist_messages_pending;
+DROP INDEX IF EXISTS idx_artist_messages_artist;
+ALTER TABLE artist_messages DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE artist_messages DROP COLUMN IF EXISTS sent_at;
+ALTER TABLE artist_messages DROP COLUMN IF EXISTS status;
+
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Follows are written through the base model
+ALTER TABLE artist_followers ADD COLUMN IF NOT EXISTS created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE artist_followers ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP;
+
+-- Cursor pagination of both follow lists, newest first
+CREATE INDEX IF NOT EXISTS idx_artist_followers_artist_followed ON artist_followers(artist_id, followed_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_artist_followers_user_followed ON artist_followers(follower_user_id, followed_at DESC, id DESC);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_artist_followers_user_followed;
+DROP INDEX IF EXISTS idx_artist_followers_artist_followed;
+ALTER TABLE artist_followers DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE artist_followers DROP COLUMN IF EXISTS created_at;
+
+-- +goose StatementEnd
@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- In-app notification inbox
+CREATE TABLE notifications (
+    id BIGINT PRIMARY KEY NOT NULL,
+    user_id BIGINT NOT NULL, -- No FK reference
+    type VARCHAR(50) NOT NULL, -- new_release, artist_message, tip_received, tip_refunded, processing_completed, processing_failed
+    title VARCHAR(200) NOT NULL,
+    body TEXT,
+    artist_id BIGINT, -- No FK reference
+    song_id BIGINT, -- No FK reference
+    reference_id BIGINT, -- Tip, message or song, depending on type
+    dedup_key VARCHAR(100),
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE(user_id, dedup_key)
+);
+
+CREATE INDEX idx_notifications_user_created ON notifications(user_id, created_at DESC, id DESC);
+CREATE INDEX idx_notifications_user_unread ON notifications(user_id) WHERE read_at IS NULL;
+
+-- Per-artist mutes; muted_until NULL means until removed
+CREATE TABLE notification_mutes (
+    id BIGINT PRIMARY KEY NOT NULL,
+    user_id BIGINT NOT NULL, -- No FK reference
+    artist_id BIGINT NOT NULL, -- No FK reference
+    muted_until TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE(user_id, artist_id)
+);
+
+CREATE INDEX idx_notification_mutes_artist ON notification_mutes(artist_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS notification_mutes;
+DROP TABLE IF EXISTS notifications;
+
+-- +goose StatementEnd
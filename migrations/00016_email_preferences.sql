@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Email opt-ins captured at registration; notification emails default on, marketing off
+ALTER TABLE user_preferences ADD COLUMN IF NOT EXISTS email_notifications BOOLEAN DEFAULT true;
+ALTER TABLE user_preferences ADD COLUMN IF NOT EXISTS marketing_emails BOOLEAN DEFAULT false;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE user_preferences DROP COLUMN IF EXISTS marketing_emails;
+ALTER TABLE user_preferences DROP COLUMN IF EXISTS email_notifications;
+
+-- +goose StatementEnd
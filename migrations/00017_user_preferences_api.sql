@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Genre and mood IDs are BIGINT snowflakes and do not fit INTEGER
+ALTER TABLE user_preferences ALTER COLUMN preferred_genres TYPE BIGINT[] USING preferred_genres::BIGINT[];
+ALTER TABLE user_preferences ALTER COLUMN preferred_moods TYPE BIGINT[] USING preferred_moods::BIGINT[];
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE user_preferences ALTER COLUMN preferred_moods TYPE INTEGER[] USING preferred_moods::INTEGER[];
+ALTER TABLE user_preferences ALTER COLUMN preferred_genres TYPE INTEGER[] USING preferred_genres::INTEGER[];
+
+-- +goose StatementEnd
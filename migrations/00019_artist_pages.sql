@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Top songs on public artist pages
+CREATE INDEX IF NOT EXISTS idx_songs_artist_play_count ON songs(artist_id, play_count DESC) WHERE is_active = true;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_songs_artist_play_count;
+
+-- +goose StatementEnd
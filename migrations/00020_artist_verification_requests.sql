@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Every submission is kept, reviewed or not, as the artist's verification history
+CREATE TABLE artist_verification_requests (
+    id BIGINT PRIMARY KEY NOT NULL,
+    artist_id BIGINT NOT NULL, -- No FK reference
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    evidence_links TEXT[] NOT NULL,
+    message TEXT,
+    reviewer_id BIGINT, -- Admin user; no FK reference
+    rejection_reason TEXT,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_artist_verification_requests_artist ON artist_verification_requests(artist_id, created_at DESC);
+CREATE INDEX idx_artist_verification_requests_status ON artist_verification_requests(status, created_at, id);
+-- An artist waits on at most one request at a time
+CREATE UNIQUE INDEX idx_artist_verification_requests_one_pending ON artist_verification_requests(artist_id) WHERE status = 'pending';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS artist_verification_requests;
+
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Every admin request that changed state; rows are never updated or deleted
+CREATE TABLE admin_audit_log (
+    id BIGINT PRIMARY KEY NOT NULL,
+    actor_id BIGINT NOT NULL, -- Admin user; no FK reference so entries outlive the account
+    action VARCHAR(100) NOT NULL,
+    target_type VARCHAR(50),
+    target_id VARCHAR(100),
+    method VARCHAR(10) NOT NULL,
+    path TEXT NOT NULL,
+    status_code INTEGER NOT NULL,
+    ip_address VARCHAR(45),
+    user_agent TEXT,
+    details JSONB,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_admin_audit_log_created ON admin_audit_log(created_at DESC, id DESC);
+CREATE INDEX idx_admin_audit_log_actor ON admin_audit_log(actor_id, created_at DESC);
+CREATE INDEX idx_admin_audit_log_target ON admin_audit_log(target_type, target_id, created_at DESC);
+
+-- Content takedowns keep the song so they can be reversed
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS taken_down_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS takedown_reason TEXT;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE songs DROP COLUMN IF EXISTS takedown_reason;
+ALTER TABLE songs DROP COLUMN IF EXISTS taken_down_at;
+DROP TABLE IF EXISTS admin_audit_log;
+
+-- +goose StatementEnd
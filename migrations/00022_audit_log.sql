@@ -0,0 +1,95 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- The admin audit log becomes the audit log of every security- and money-relevant action,
+-- written by admins, users and the system alike
+ALTER TABLE admin_audit_log RENAME TO audit_log;
+ALTER TABLE audit_log RENAME COLUMN details TO metadata;
+-- JSON keeps the text as written so entry hashes can be recomputed from the row
+ALTER TABLE audit_log ALTER COLUMN metadata TYPE JSON USING metadata::json;
+ALTER TABLE audit_log ALTER COLUMN actor_id DROP NOT NULL;
+ALTER TABLE audit_log ALTER COLUMN method DROP NOT NULL;
+ALTER TABLE audit_log ALTER COLUMN path DROP NOT NULL;
+ALTER TABLE audit_log ALTER COLUMN status_code DROP NOT NULL;
+
+-- Existing rows were all written by admins
+ALTER TABLE audit_log ADD COLUMN actor_type VARCHAR(20) NOT NULL DEFAULT 'admin';
+ALTER TABLE audit_log ALTER COLUMN actor_type DROP DEFAULT;
+ALTER TABLE audit_log ADD COLUMN before_state JSON;
+ALTER TABLE audit_log ADD COLUMN after_state JSON;
+ALTER TABLE audit_log ADD COLUMN request_id VARCHAR(64);
+
+-- Hash chain: each entry stores the hash of the previous one. Rows written before this
+-- migration keep a NULL hash and are reported as unchained by verification.
+ALTER TABLE audit_log ADD COLUMN seq BIGINT;
+ALTER TABLE audit_log ADD COLUMN prev_hash VARCHAR(64);
+ALTER TABLE audit_log ADD COLUMN hash VARCHAR(64);
+
+UPDATE audit_log SET seq = ordered.seq
+FROM (SELECT id, ROW_NUMBER() OVER (ORDER BY created_at, id) AS seq FROM audit_log) ordered
+WHERE audit_log.id = ordered.id;
+ALTER TABLE audit_log ALTER COLUMN seq SET NOT NULL;
+CREATE UNIQUE INDEX idx_audit_log_seq ON audit_log(seq);
+
+ALTER INDEX idx_admin_audit_log_created RENAME TO idx_audit_log_created;
+ALTER INDEX idx_admin_audit_log_actor RENAME TO idx_audit_log_actor;
+ALTER INDEX idx_admin_audit_log_target RENAME TO idx_audit_log_target;
+ALTER INDEX admin_audit_log_pkey RENAME TO audit_log_pkey;
+CREATE INDEX idx_audit_log_action ON audit_log(action, created_at DESC);
+CREATE INDEX idx_audit_log_request ON audit_log(request_id) WHERE request_id IS NOT NULL;
+
+-- Entries are append-only; changing one needs the trigger dropped, which the chain still exposes
+CREATE OR REPLACE FUNCTION reject_audit_log_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only: % is not allowed', TG_OP;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_audit_log_append_only
+    BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW
+    EXECUTE FUNCTION reject_audit_log_change();
+
+CREATE TRIGGER trigger_audit_log_no_truncate
+    BEFORE TRUNCATE ON audit_log
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION reject_audit_log_change();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS trigger_audit_log_no_truncate ON audit_log;
+DROP TRIGGER IF EXISTS trigger_audit_log_append_only ON audit_log;
+DROP FUNCTION IF EXISTS reject_audit_log_change();
+
+-- Entries not written by an admin request have nowhere to go in the old table
+DELETE FROM audit_log WHERE actor_id IS NULL OR method IS NULL OR path IS NULL OR status_code IS NULL;
+
+DROP INDEX IF EXISTS idx_audit_log_request;
+DROP INDEX IF EXISTS idx_audit_log_action;
+DROP INDEX IF EXISTS idx_audit_log_seq;
+ALTER INDEX audit_log_pkey RENAME TO admin_audit_log_pkey;
+ALTER INDEX idx_audit_log_target RENAME TO idx_admin_audit_log_target;
+ALTER INDEX idx_audit_log_actor RENAME TO idx_admin_audit_log_actor;
+ALTER INDEX idx_audit_log_created RENAME TO idx_admin_audit_log_created;
+
+ALTER TABLE audit_log DROP COLUMN hash;
+ALTER TABLE audit_log DROP COLUMN prev_hash;
+ALTER TABLE audit_log DROP COLUMN seq;
+ALTER TABLE audit_log DROP COLUMN request_id;
+ALTER TABLE audit_log DROP COLUMN after_state;
+ALTER TABLE audit_log DROP COLUMN before_state;
+ALTER TABLE audit_log DROP COLUMN actor_type;
+
+ALTER TABLE audit_log ALTER COLUMN status_code SET NOT NULL;
+ALTER TABLE audit_log ALTER COLUMN path SET NOT NULL;
+ALTER TABLE audit_log ALTER COLUMN method SET NOT NULL;
+ALTER TABLE audit_log ALTER COLUMN actor_id SET NOT NULL;
+ALTER TABLE audit_log ALTER COLUMN metadata TYPE JSONB USING metadata::jsonb;
+ALTER TABLE audit_log RENAME COLUMN metadata TO details;
+ALTER TABLE audit_log RENAME TO admin_audit_log;
+
+-- +goose StatementEnd
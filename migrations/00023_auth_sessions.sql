@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- A signed-in device; its refresh tokens form one family that is revoked together
+CREATE TABLE auth_sessions (
+    id BIGINT PRIMARY KEY NOT NULL,
+    user_id BIGINT NOT NULL, -- No FK reference
+    device_name VARCHAR(100),
+    user_agent TEXT,
+    ip_address VARCHAR(45),
+    last_used_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    revoke_reason VARCHAR(50),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_auth_sessions_user_active ON auth_sessions(user_id, last_used_at DESC) WHERE revoked_at IS NULL;
+CREATE INDEX idx_auth_sessions_expires ON auth_sessions(expires_at);
+CREATE INDEX idx_auth_sessions_revoked ON auth_sessions(revoked_at) WHERE revoked_at IS NOT NULL;
+
+-- Only the SHA-256 of a refresh token is stored. Rotated tokens are kept so their reuse is detected.
+CREATE TABLE auth_refresh_tokens (
+    id BIGINT PRIMARY KEY NOT NULL,
+    session_id BIGINT NOT NULL, -- No FK reference
+    token_hash VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    rotated_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_auth_refresh_tokens_hash ON auth_refresh_tokens(token_hash);
+CREATE INDEX idx_auth_refresh_tokens_session ON auth_refresh_tokens(session_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS auth_refresh_tokens;
+DROP TABLE IF EXISTS auth_sessions;
+
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Access token signing keys, shared by every API replica. A key signs from activates_at
+-- until the next key activates and is published in the JWKS from creation until the
+-- tokens it signed have expired.
+CREATE TABLE jwt_signing_keys (
+    kid VARCHAR(64) PRIMARY KEY NOT NULL,
+    algorithm VARCHAR(10) NOT NULL,
+    private_key BYTEA NOT NULL, -- PKCS #8, AES-GCM encrypted with JWT_KEY_ENCRYPTION_KEY
+    public_key BYTEA NOT NULL, -- PKIX
+    activates_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_jwt_signing_keys_activates ON jwt_signing_keys(activates_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS jwt_signing_keys;
+
+-- +goose StatementEnd
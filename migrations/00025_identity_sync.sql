@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Last known state of the user's Kratos identity: active, inactive or deleted. It is kept
+-- apart from is_active so an identity that stays active in Kratos does not undo an admin
+-- deactivation here.
+ALTER TABLE users
+    ADD COLUMN identity_state VARCHAR(20) NOT NULL DEFAULT 'active',
+    ADD COLUMN identity_deleted_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN identity_synced_at TIMESTAMP WITH TIME ZONE;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS identity_synced_at,
+    DROP COLUMN IF EXISTS identity_deleted_at,
+    DROP COLUMN IF EXISTS identity_state;
+
+-- +goose StatementEnd
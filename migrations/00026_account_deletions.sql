@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- User-requested account deletions. A request waits out its grace period as pending and
+-- can be cancelled until then; the report records what the erasure removed or kept.
+CREATE TABLE account_deletions (
+    id BIGINT PRIMARY KEY NOT NULL,
+    user_id BIGINT NOT NULL, -- No FK reference
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'cancelled', 'processing', 'failed', 'completed')),
+    reason TEXT,
+    scheduled_for TIMESTAMP WITH TIME ZONE NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    report JSONB,
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- At most one open request per user
+CREATE UNIQUE INDEX idx_account_deletions_user_open ON account_deletions(user_id)
+    WHERE status IN ('pending', 'processing', 'failed');
+CREATE INDEX idx_account_deletions_due ON account_deletions(scheduled_for)
+    WHERE status IN ('pending', 'processing', 'failed');
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS account_deletions;
+
+-- +goose StatementEnd
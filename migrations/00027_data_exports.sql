@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Personal data exports. A worker builds the archive of a pending export and stores it
+-- until expires_at; after that the object is deleted and the export marked expired. A
+-- failed attempt goes back to pending until the attempts run out.
+CREATE TABLE data_exports (
+    id BIGINT PRIMARY KEY NOT NULL,
+    user_id BIGINT NOT NULL, -- No FK reference
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'processing', 'failed', 'completed', 'expired')),
+    format_version INTEGER NOT NULL,
+    object_key TEXT,
+    size_bytes BIGINT,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_data_exports_user ON data_exports(user_id, created_at DESC);
+-- At most one export in progress per user
+CREATE UNIQUE INDEX idx_data_exports_user_open ON data_exports(user_id)
+    WHERE status IN ('pending', 'processing');
+CREATE INDEX idx_data_exports_due ON data_exports(created_at)
+    WHERE status IN ('pending', 'processing');
+CREATE INDEX idx_data_exports_expiry ON data_exports(expires_at)
+    WHERE status = 'completed';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS data_exports;
+
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Who turned is_active off: 'admin' for a deactivation through the admin API, 'identity'
+-- when the Kratos identity was disabled or deleted. Re-enabling the identity only
+-- reactivates users it deactivated, so it cannot undo an admin's decision. Existing
+-- deactivations cannot be told apart and are attributed to an admin.
+ALTER TABLE users
+    ADD COLUMN deactivated_by VARCHAR(20) CHECK (deactivated_by IN ('admin', 'identity'));
+
+UPDATE users SET deactivated_by = 'admin' WHERE is_active = false;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS deactivated_by;
+
+-- +goose StatementEnd
@@ -0,0 +1,51 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Admin refunds are recorded as pending before the provider is called, with the refund ID
+-- as its idempotency key, and finalized once it answers. A pending refund reserves its
+-- amount; a failed one never took effect. Only succeeded refunds count anywhere.
+ALTER TABLE tip_refunds
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'succeeded' CHECK (status IN ('pending', 'succeeded', 'failed')),
+    ADD COLUMN last_error TEXT;
+
+CREATE INDEX idx_tip_refunds_pending ON tip_refunds(tip_id) WHERE status = 'pending';
+
+-- Totals are reversed when a refund succeeds: on insert for webhook refunds, on the
+-- update that finalizes a pending one
+DROP TRIGGER IF EXISTS trigger_reverse_tip_totals ON tip_refunds;
+
+CREATE TRIGGER trigger_reverse_tip_totals
+    AFTER INSERT ON tip_refunds
+    FOR EACH ROW
+    WHEN (NEW.status = 'succeeded')
+    EXECUTE FUNCTION reverse_tip_totals();
+
+CREATE TRIGGER trigger_reverse_tip_totals_on_success
+    AFTER UPDATE OF status ON tip_refunds
+    FOR EACH ROW
+    WHEN (OLD.status <> 'succeeded' AND NEW.status = 'succeeded')
+    EXECUTE FUNCTION reverse_tip_totals();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS trigger_reverse_tip_totals_on_success ON tip_refunds;
+DROP TRIGGER IF EXISTS trigger_reverse_tip_totals ON tip_refunds;
+
+CREATE TRIGGER trigger_reverse_tip_totals
+    AFTER INSERT ON tip_refunds
+    FOR EACH ROW
+    EXECUTE FUNCTION reverse_tip_totals();
+
+-- Refunds that never took effect cannot be told apart once the column is gone
+DELETE FROM tip_refunds WHERE status <> 'succeeded';
+
+DROP INDEX IF EXISTS idx_tip_refunds_pending;
+
+ALTER TABLE tip_refunds
+    DROP COLUMN IF EXISTS last_error,
+    DROP COLUMN IF EXISTS status;
+
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- One row per artist and user, written the first time the user follows the artist and kept
+-- when they unfollow, so following again is not counted as a new follower in the daily
+-- stats. Existing follows are their own first follow.
+CREATE TABLE artist_first_follows (
+    artist_id BIGINT NOT NULL, -- No FK reference
+    follower_user_id BIGINT NOT NULL, -- No FK reference
+    first_followed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (artist_id, follower_user_id)
+);
+
+INSERT INTO artist_first_follows (artist_id, follower_user_id, first_followed_at)
+SELECT artist_id, follower_user_id, COALESCE(followed_at, CURRENT_TIMESTAMP)
+FROM artist_followers
+ON CONFLICT DO NOTHING;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS artist_first_follows;
+
+-- +goose StatementEnd
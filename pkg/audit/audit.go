@@ -0,0 +1,138 @@
+// Package audit is the append-only log of security- and money-relevant actions. Entries
+// are hash-chained: each stores the hash of the one before it, so editing, removing or
+// reordering an entry breaks every hash after it and Verify reports where.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"reflect"
+	"sync/atomic"
+)
+
+const (
+	ActorTypeSystem = "system" // Workers and provider webhooks
+)
+
+// Entry is what a module reports. Before and After are snapshots of the target, usually
+// small maps or structs; only the top-level fields that differ are stored. Request
+// details and the actor are taken from the context when left empty.
+type Entry struct {
+	ActorID    *uint64
+	ActorType  string
+	Action     string
+	TargetType string
+	TargetID   string
+	Before     interface{}
+	After      interface{}
+	Metadata   map[string]interface{}
+	StatusCode int
+}
+
+// Logger is the interface modules write through
+type Logger interface {
+	Record(ctx context.Context, entry *Entry) error
+}
+
+// Request describes the HTTP request an action happens in. The request middleware puts
+// it in the context; auth fills in the actor once the token is checked.
+type Request struct {
+	ID        string
+	IPAddress string
+	UserAgent string
+	Method    string
+	Path      string
+	ActorID   *uint64
+	ActorType string
+
+	recorded atomic.Bool
+}
+
+// Recorded reports whether anything was written to the audit log for this request
+func (r *Request) Recorded() bool {
+	return r.recorded.Load()
+}
+
+type requestKey struct{}
+
+func WithRequest(ctx context.Context, request *Request) context.Context {
+	return context.WithValue(ctx, requestKey{}, request)
+}
+
+// RequestFrom returns the request in ctx, or nil outside of an HTTP request
+func RequestFrom(ctx context.Context) *Request {
+	request, _ := ctx.Value(requestKey{}).(*Request)
+	return request
+}
+
+// SetActor records who made the request in ctx, if it carries one
+func SetActor(ctx context.Context, userID uint64, userType string) {
+	if request := RequestFrom(ctx); request != nil {
+		request.ActorID = &userID
+		request.ActorType = userType
+	}
+}
+
+// diff keeps the top-level fields of before and after that differ. A nil side (creation
+// or deletion) is stored as null and the other side is kept whole.
+func diff(before, after interface{}) (json.RawMessage, json.RawMessage, error) {
+	beforeFields, err := toFields(before)
+	if err != nil {
+		return nil, nil, err
+	}
+	afterFields, err := toFields(after)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if beforeFields != nil && afterFields != nil {
+		for key, value := range beforeFields {
+			if other, ok := afterFields[key]; ok && reflect.DeepEqual(value, other) {
+				delete(beforeFields, key)
+				delete(afterFields, key)
+			}
+		}
+	}
+
+	beforeJSON, err := marshalFields(beforeFields)
+	if err != nil {
+		return nil, nil, err
+	}
+	afterJSON, err := marshalFields(afterFields)
+	return beforeJSON, afterJSON, err
+}
+
+func toFields(value interface{}) (map[string]interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func marshalFields(fields map[string]interface{}) (json.RawMessage, error) {
+	if fields == nil {
+		return nil, nil
+	}
+	return json.Marshal(fields)
+}
+
+// Write records entry and only logs a failure: callers use it after the action has
+// happened, when failing the request would misreport what was done. A nil logger is
+// allowed so modules work without an audit log in tools and tests.
+func Write(ctx context.Context, logger Logger, entry *Entry) {
+	if logger == nil {
+		return
+	}
+	if err := logger.Record(context.WithoutCancel(ctx), entry); err != nil {
+		log.Printf("Failed to write audit entry %s %s/%s: %v", entry.Action, entry.TargetType, entry.TargetID, err)
+	}
+}
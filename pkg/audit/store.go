@@ -0,0 +1,315 @@
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"music-app-backend/pkg/pagination"
+	"time"
+
+	goflakeid "github.com/capy-engineer/go-flakeid"
+	"gorm.io/gorm"
+)
+
+// chainLockKey serializes appends across API replicas with a transaction-scoped
+// Postgres advisory lock, so two entries never claim the same predecessor
+const chainLockKey = 0x61756469746c6f67
+
+const verifyBatchSize = 1000
+
+// Record is a stored entry. Before, After and Metadata are stored in JSON (not JSONB)
+// columns, which keep the text as written, so the hash can be recomputed from the row.
+type Record struct {
+	ID         uint64          `json:"id" gorm:"primaryKey"`
+	Seq        int64           `json:"seq" gorm:"not null;uniqueIndex"`
+	ActorID    *uint64         `json:"actor_id"`
+	ActorType  string          `json:"actor_type" gorm:"not null;size:20"`
+	Action     string          `json:"action" gorm:"not null;size:100"`
+	TargetType string          `json:"target_type" gorm:"size:50"`
+	TargetID   string          `json:"target_id" gorm:"size:100"`
+	Before     json.RawMessage `json:"before" gorm:"column:before_state;type:json"`
+	After      json.RawMessage `json:"after" gorm:"column:after_state;type:json"`
+	Metadata   json.RawMessage `json:"metadata" gorm:"type:json"`
+	RequestID  string          `json:"request_id" gorm:"size:64"`
+	IPAddress  string          `json:"ip_address" gorm:"size:45"`
+	UserAgent  string          `json:"user_agent"`
+	Method     string          `json:"method" gorm:"size:10"`
+	Path       string          `json:"path"`
+	StatusCode int             `json:"status_code"`
+	PrevHash   *string         `json:"prev_hash" gorm:"size:64"`
+	Hash       *string         `json:"hash" gorm:"size:64"` // Nil for entries written before chaining
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+func (Record) TableName() string {
+	return "audit_log"
+}
+
+// Filter narrows a query; zero fields match everything
+type Filter struct {
+	ActorID    uint64
+	Action     string
+	TargetType string
+	TargetID   string
+	RequestID  string
+	From       *time.Time
+	To         *time.Time
+}
+
+type QueryResult struct {
+	Entries    []Record        `json:"entries"`
+	Pagination pagination.Meta `json:"pagination"`
+}
+
+// VerifyResult describes the first break in the chain, if any
+type VerifyResult struct {
+	Valid     bool   `json:"valid"`
+	Checked   int64  `json:"checked"`
+	Unchained int64  `json:"unchained"` // Entries from before hash chaining
+	BrokenSeq *int64 `json:"broken_seq,omitempty"`
+	Problem   string `json:"problem,omitempty"`
+	LastHash  string `json:"last_hash,omitempty"`
+}
+
+type Store struct {
+	db        *gorm.DB
+	generator *goflakeid.Generator
+}
+
+func NewStore(db *gorm.DB, generator *goflakeid.Generator) *Store {
+	return &Store{db: db, generator: generator}
+}
+
+// Record appends entry to the chain
+func (s *Store) Record(ctx context.Context, entry *Entry) error {
+	if entry.Action == "" {
+		return errors.New("audit entry without action")
+	}
+
+	before, after, err := diff(entry.Before, entry.After)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit snapshots: %w", err)
+	}
+	record := &Record{
+		ActorID:    entry.ActorID,
+		ActorType:  entry.ActorType,
+		Action:     entry.Action,
+		TargetType: entry.TargetType,
+		TargetID:   entry.TargetID,
+		Before:     before,
+		After:      after,
+		StatusCode: entry.StatusCode,
+		// Postgres keeps microseconds; the hash must see the same value that is stored
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+	if len(entry.Metadata) > 0 {
+		if record.Metadata, err = json.Marshal(entry.Metadata); err != nil {
+			return fmt.Errorf("failed to encode audit metadata: %w", err)
+		}
+	}
+
+	request := RequestFrom(ctx)
+	if request != nil {
+		record.RequestID = request.ID
+		record.IPAddress = request.IPAddress
+		record.UserAgent = request.UserAgent
+		record.Method = request.Method
+		record.Path = request.Path
+		if record.ActorID == nil {
+			record.ActorID = request.ActorID
+		}
+		if record.ActorType == "" && request.ActorID != nil {
+			record.ActorType = request.ActorType
+		}
+	}
+	if record.ActorType == "" {
+		record.ActorType = ActorTypeSystem
+	}
+
+	if record.ID, err = s.generator.Generate(); err != nil {
+		return err
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", int64(chainLockKey)).Error; err != nil {
+			return err
+		}
+
+		var last Record
+		err := tx.Select("seq", "hash").Order("seq DESC").Limit(1).Find(&last).Error
+		if err != nil {
+			return err
+		}
+		record.Seq = last.Seq + 1
+		record.PrevHash = last.Hash
+
+		hash := record.computeHash()
+		record.Hash = &hash
+		return tx.Create(record).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	if request != nil {
+		request.recorded.Store(true)
+	}
+	return nil
+}
+
+// Query lists entries, newest first
+func (s *Store) Query(ctx context.Context, filter *Filter, page pagination.Params) (*QueryResult, error) {
+	query := s.db.WithContext(ctx).Model(&Record{})
+	if filter.ActorID != 0 {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.TargetType != "" {
+		query = query.Where("target_type = ?", filter.TargetType)
+	}
+	if filter.TargetID != "" {
+		query = query.Where("target_id = ?", filter.TargetID)
+	}
+	if filter.RequestID != "" {
+		query = query.Where("request_id = ?", filter.RequestID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	entries := []Record{}
+	err := query.Order("seq DESC").Offset(page.Offset()).Limit(page.Limit).Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return &QueryResult{Entries: entries, Pagination: page.Meta(total)}, nil
+}
+
+// Verify walks the whole chain. Entries written before chaining may only precede the
+// first chained one. Removing the newest entries cannot be seen from the chain alone;
+// compare LastHash with a previously exported value for that.
+func (s *Store) Verify(ctx context.Context) (*VerifyResult, error) {
+	verifier := &chainVerifier{result: &VerifyResult{Valid: true}}
+
+	for {
+		var batch []Record
+		err := s.db.WithContext(ctx).Where("seq > ?", verifier.prevSeq).Order("seq ASC").Limit(verifyBatchSize).Find(&batch).Error
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range batch {
+			if !verifier.check(&batch[i]) {
+				return verifier.result, nil
+			}
+		}
+
+		if len(batch) < verifyBatchSize {
+			break
+		}
+	}
+
+	return verifier.finish(), nil
+}
+
+// chainVerifier checks records in seq order against the ones before them
+type chainVerifier struct {
+	result   *VerifyResult
+	prevSeq  int64
+	prevHash *string
+	chained  bool
+}
+
+// check reports whether the chain still holds after record; on a break it records the
+// problem in the result
+func (v *chainVerifier) check(record *Record) bool {
+	v.result.Checked++
+
+	problem := ""
+	switch {
+	case record.Seq != v.prevSeq+1:
+		problem = fmt.Sprintf("entries %d to %d are missing", v.prevSeq+1, record.Seq-1)
+	case record.Hash == nil && v.chained:
+		problem = "entry has no hash"
+	case record.Hash == nil:
+		v.result.Unchained++
+	case !sameHash(record.PrevHash, v.prevHash):
+		problem = "entry does not link to the previous entry"
+	case *record.Hash != record.computeHash():
+		problem = "entry was modified"
+	default:
+		v.chained = true
+	}
+	if problem != "" {
+		seq := record.Seq
+		v.result.Valid = false
+		v.result.BrokenSeq = &seq
+		v.result.Problem = problem
+		return false
+	}
+
+	v.prevSeq = record.Seq
+	v.prevHash = record.Hash
+	return true
+}
+
+func (v *chainVerifier) finish() *VerifyResult {
+	if v.prevHash != nil {
+		v.result.LastHash = *v.prevHash
+	}
+	return v.result
+}
+
+// computeHash covers every stored field except the hash itself
+func (r *Record) computeHash() string {
+	prevHash := ""
+	if r.PrevHash != nil {
+		prevHash = *r.PrevHash
+	}
+	payload, _ := json.Marshal(struct {
+		Seq        int64           `json:"seq"`
+		PrevHash   string          `json:"prev_hash"`
+		ID         uint64          `json:"id"`
+		ActorID    *uint64         `json:"actor_id"`
+		ActorType  string          `json:"actor_type"`
+		Action     string          `json:"action"`
+		TargetType string          `json:"target_type"`
+		TargetID   string          `json:"target_id"`
+		Before     json.RawMessage `json:"before"`
+		After      json.RawMessage `json:"after"`
+		Metadata   json.RawMessage `json:"metadata"`
+		RequestID  string          `json:"request_id"`
+		IPAddress  string          `json:"ip_address"`
+		UserAgent  string          `json:"user_agent"`
+		Method     string          `json:"method"`
+		Path       string          `json:"path"`
+		StatusCode int             `json:"status_code"`
+		CreatedAt  int64           `json:"created_at"`
+	}{
+		r.Seq, prevHash, r.ID, r.ActorID, r.ActorType, r.Action, r.TargetType, r.TargetID,
+		r.Before, r.After, r.Metadata, r.RequestID, r.IPAddress, r.UserAgent, r.Method, r.Path,
+		r.StatusCode, r.CreatedAt.UnixMicro(),
+	})
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
+func sameHash(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
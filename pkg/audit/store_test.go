@@ -0,0 +1,156 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// buildChain returns n records linked the way Store.Record links them, after unchained
+// records written before hash chaining
+func buildChain(unchained, n int) []Record {
+	records := make([]Record, 0, unchained+n)
+	createdAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	var prevHash *string
+	for i := 0; i < unchained+n; i++ {
+		record := Record{
+			ID:        uint64(1000 + i),
+			Seq:       int64(i + 1),
+			ActorType: ActorTypeSystem,
+			Action:    "test.action",
+			After:     json.RawMessage(fmt.Sprintf(`{"step":%d}`, i)),
+			CreatedAt: createdAt.Add(time.Duration(i) * time.Second),
+		}
+		if i >= unchained {
+			record.PrevHash = prevHash
+			hash := record.computeHash()
+			record.Hash = &hash
+			prevHash = record.Hash
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestChainVerifierCheck(t *testing.T) {
+	tests := []struct {
+		name          string
+		records       func() []Record
+		wantValid     bool
+		wantBrokenSeq int64
+		wantProblem   string
+		wantChecked   int64
+		wantUnchained int64
+	}{
+		{
+			name:        "empty log",
+			records:     func() []Record { return nil },
+			wantValid:   true,
+			wantChecked: 0,
+		},
+		{
+			name:        "intact chain",
+			records:     func() []Record { return buildChain(0, 5) },
+			wantValid:   true,
+			wantChecked: 5,
+		},
+		{
+			name:          "unchained entries before the chain",
+			records:       func() []Record { return buildChain(2, 3) },
+			wantValid:     true,
+			wantChecked:   5,
+			wantUnchained: 2,
+		},
+		{
+			name: "entry modified",
+			records: func() []Record {
+				records := buildChain(0, 4)
+				records[2].Action = "test.other"
+				return records
+			},
+			wantBrokenSeq: 3,
+			wantProblem:   "entry was modified",
+			wantChecked:   3,
+		},
+		{
+			name: "entry deleted",
+			records: func() []Record {
+				records := buildChain(0, 4)
+				return append(records[:1], records[2:]...)
+			},
+			wantBrokenSeq: 3,
+			wantProblem:   "entries 2 to 2 are missing",
+			wantChecked:   2,
+		},
+		{
+			name: "entry rehashed without relinking",
+			records: func() []Record {
+				records := buildChain(0, 4)
+				other := "0000000000000000000000000000000000000000000000000000000000000000"
+				records[1].PrevHash = &other
+				hash := records[1].computeHash()
+				records[1].Hash = &hash
+				return records
+			},
+			wantBrokenSeq: 2,
+			wantProblem:   "entry does not link to the previous entry",
+			wantChecked:   2,
+		},
+		{
+			name: "hash removed after chaining started",
+			records: func() []Record {
+				records := buildChain(0, 4)
+				records[3].Hash = nil
+				return records
+			},
+			wantBrokenSeq: 4,
+			wantProblem:   "entry has no hash",
+			wantChecked:   4,
+		},
+		{
+			name: "first entry missing",
+			records: func() []Record {
+				return buildChain(0, 3)[1:]
+			},
+			wantBrokenSeq: 2,
+			wantProblem:   "entries 1 to 1 are missing",
+			wantChecked:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records := tt.records()
+			verifier := &chainVerifier{result: &VerifyResult{Valid: true}}
+			for i := range records {
+				if !verifier.check(&records[i]) {
+					break
+				}
+			}
+			result := verifier.finish()
+
+			if result.Valid != tt.wantValid {
+				t.Fatalf("valid = %t, want %t (problem %q)", result.Valid, tt.wantValid, result.Problem)
+			}
+			if result.Checked != tt.wantChecked || result.Unchained != tt.wantUnchained {
+				t.Fatalf("checked/unchained = %d/%d, want %d/%d", result.Checked, result.Unchained, tt.wantChecked, tt.wantUnchained)
+			}
+			if tt.wantValid {
+				if result.BrokenSeq != nil {
+					t.Fatalf("broken seq = %d, want none", *result.BrokenSeq)
+				}
+				if len(records) > 0 && result.LastHash != *records[len(records)-1].Hash {
+					t.Fatalf("last hash = %q, want the newest entry's hash", result.LastHash)
+				}
+				return
+			}
+			if result.BrokenSeq == nil || *result.BrokenSeq != tt.wantBrokenSeq {
+				t.Fatalf("broken seq = %v, want %d", result.BrokenSeq, tt.wantBrokenSeq)
+			}
+			if result.Problem != tt.wantProblem {
+				t.Fatalf("problem = %q, want %q", result.Problem, tt.wantProblem)
+			}
+		})
+	}
+}
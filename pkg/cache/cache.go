@@ -55,8 +55,8 @@ func (c *Cache) Get(key string) (interface{}, bool) {
 		return nil, false
 	}
 
+	// Expired entries are left for the cleanup loop; deleting here would write under a read lock
 	if !item.ExpiresAt.IsZero() && time.Now().After(item.ExpiresAt) {
-		delete(c.cache, key)
 		return nil, false
 	}
 
@@ -1,6 +1,7 @@
 package context
 
 import (
+	"music-app-backend/pkg/audit"
 	"music-app-backend/pkg/redis"
 	"music-app-backend/pkg/storage"
 
@@ -15,19 +16,22 @@ type ServiceContext struct {
 	IDGenerator    *goflakeid.Generator
 	RedisClient    *redis.Client
 	StorageService *storage.MinIOService
+	AuditLog       *audit.Store
 }
 
 func NewServiceContext(DBContext *gorm.DB,
 	Router *gin.Engine,
 	IDGenerator *goflakeid.Generator,
 	RedisClient *redis.Client,
-	StorageService *storage.MinIOService) *ServiceContext {
+	StorageService *storage.MinIOService,
+	AuditLog *audit.Store) *ServiceContext {
 	return &ServiceContext{
 		DBContext:      DBContext,
 		Router:         Router,
 		IDGenerator:    IDGenerator,
 		RedisClient:    RedisClient,
 		StorageService: StorageService,
+		AuditLog:       AuditLog,
 	}
 }
 
@@ -49,3 +53,7 @@ func (ctx ServiceContext) GetRedisClient() *redis.Client {
 func (ctx ServiceContext) GetStorageService() *storage.MinIOService {
 	return ctx.StorageService
 }
+
+func (ctx ServiceContext) GetAuditLog() *audit.Store {
+	return ctx.AuditLog
+}
@@ -88,6 +88,18 @@ func NewForbiddenError(err error, message string) *AppError {
 	}
 }
 
+func NewTooManyRequestsError(err error, message string) *AppError {
+	if message == "" {
+		message = "Too Many Requests"
+	}
+	return &AppError{
+		Err:        err,
+		StatusCode: http.StatusTooManyRequests,
+		Message:    message,
+		Code:       "TOO_MANY_REQUESTS",
+	}
+}
+
 func NewInternalError(err error, message string) *AppError {
 	if message == "" {
 		message = "Internal Server Error"
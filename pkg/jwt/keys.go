@@ -0,0 +1,386 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/gorm"
+)
+
+const (
+	AlgorithmEdDSA = "EdDSA"
+	AlgorithmRS256 = "RS256"
+
+	rsaKeyBits = 3072
+
+	// keyRotationLockKey serializes rotation so replicas starting together create one key
+	keyRotationLockKey = 0x6a77746b657973
+
+	// Only used when APP_ENV is a development environment
+	developmentKeyEncryptionSecret = "audora-development-key-encryption"
+)
+
+// KeyConfig controls signing keys and their rotation
+type KeyConfig struct {
+	Algorithm        string
+	EncryptionKey    []byte        // AES-256 key the private keys are stored under
+	RotationInterval time.Duration // How long a key signs before the next one takes over
+	PublishLead      time.Duration // How long a key is in the JWKS before it signs anything
+	RefreshInterval  time.Duration // How often replicas reload keys and check for rotation
+}
+
+// KeyConfigFromEnv reads JWT_SIGNING_ALG, JWT_KEY_ENCRYPTION_KEY (base64, 32 bytes) and
+// JWT_KEY_ROTATION_INTERVAL. Outside development it refuses to start without an
+// encryption key or with a default JWT_SECRET still configured.
+func KeyConfigFromEnv() (*KeyConfig, error) {
+	development := isDevelopment(os.Getenv("APP_ENV"))
+	if secret := os.Getenv("JWT_SECRET"); secret != "" {
+		if !development && secret == "your-jwt-secret-here" {
+			return nil, errors.New("JWT_SECRET is set to the default value; remove it, tokens are signed with rotating keys")
+		}
+		log.Println("JWT_SECRET is no longer used; tokens are signed with rotating keys")
+	}
+
+	config := &KeyConfig{
+		Algorithm:        AlgorithmEdDSA,
+		RotationInterval: 30 * 24 * time.Hour,
+		PublishLead:      time.Hour,
+		RefreshInterval:  5 * time.Minute,
+	}
+	if algorithm := os.Getenv("JWT_SIGNING_ALG"); algorithm != "" {
+		if algorithm != AlgorithmEdDSA && algorithm != AlgorithmRS256 {
+			return nil, fmt.Errorf("JWT_SIGNING_ALG must be %s or %s", AlgorithmEdDSA, AlgorithmRS256)
+		}
+		config.Algorithm = algorithm
+	}
+	if value := os.Getenv("JWT_KEY_ROTATION_INTERVAL"); value != "" {
+		interval, err := time.ParseDuration(value)
+		if err != nil || interval < 2*config.PublishLead {
+			return nil, fmt.Errorf("JWT_KEY_ROTATION_INTERVAL must be a duration of at least %s", 2*config.PublishLead)
+		}
+		config.RotationInterval = interval
+	}
+
+	encoded := os.Getenv("JWT_KEY_ENCRYPTION_KEY")
+	if encoded == "" {
+		if !development {
+			return nil, errors.New("JWT_KEY_ENCRYPTION_KEY is required outside development")
+		}
+		log.Println("JWT_KEY_ENCRYPTION_KEY is not set; using the development key")
+		sum := sha256.Sum256([]byte(developmentKeyEncryptionSecret))
+		config.EncryptionKey = sum[:]
+		return config, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(key) != 32 {
+		return nil, errors.New("JWT_KEY_ENCRYPTION_KEY must be 32 bytes, base64 encoded")
+	}
+	config.EncryptionKey = key
+	return config, nil
+}
+
+func isDevelopment(env string) bool {
+	switch strings.ToLower(env) {
+	case "development", "dev", "local":
+		return true
+	}
+	return false
+}
+
+// SigningKeyRecord is a stored key pair. The private key is encrypted with the key
+// encryption key; the public key is PKIX DER.
+type SigningKeyRecord struct {
+	KID         string    `gorm:"column:kid;primaryKey;size:64"`
+	Algorithm   string    `gorm:"not null;size:10"`
+	PrivateKey  []byte    `gorm:"not null"`
+	PublicKey   []byte    `gorm:"not null"`
+	ActivatesAt time.Time `gorm:"not null"`
+	CreatedAt   time.Time
+}
+
+func (SigningKeyRecord) TableName() string {
+	return "jwt_signing_keys"
+}
+
+type signingKey struct {
+	kid         string
+	algorithm   string
+	method      jwt.SigningMethod
+	private     crypto.Signer
+	public      crypto.PublicKey
+	activatesAt time.Time
+}
+
+// KeyManager holds the key set. Every replica reloads it from the database, so keys are
+// created once and shared; a key is published for PublishLead before it signs and stays
+// published for a token lifetime after it stops, so tokens always find their key.
+type KeyManager struct {
+	db            *gorm.DB
+	config        *KeyConfig
+	aead          cipher.AEAD
+	tokenLifetime time.Duration
+
+	mu   sync.RWMutex
+	keys []*signingKey // Ordered by activation
+}
+
+// NewKeyManager loads the key set, creating the first key when there is none
+func NewKeyManager(ctx context.Context, db *gorm.DB, config *KeyConfig, tokenLifetime time.Duration) (*KeyManager, error) {
+	block, err := aes.NewCipher(config.EncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	manager := &KeyManager{db: db, config: config, aead: aead, tokenLifetime: tokenLifetime}
+	if err := manager.Rotate(ctx); err != nil {
+		return nil, err
+	}
+	return manager, nil
+}
+
+// Rotate creates the next key when the current one is due to be replaced, then reloads
+// the key set. The first key signs right away; later keys are published ahead of use.
+func (m *KeyManager) Rotate(ctx context.Context) error {
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", int64(keyRotationLockKey)).Error; err != nil {
+			return err
+		}
+
+		var latest SigningKeyRecord
+		result := tx.Order("activates_at DESC").Limit(1).Find(&latest)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		now := time.Now().UTC()
+		activatesAt := now
+		if result.RowsAffected > 0 {
+			next := latest.ActivatesAt.Add(m.config.RotationInterval)
+			// A changed algorithm is rolled out as soon as the new key is published
+			if latest.Algorithm != m.config.Algorithm {
+				next = now.Add(m.config.PublishLead)
+			}
+			if latest.ActivatesAt.After(now) || now.Add(m.config.PublishLead).Before(next) {
+				return nil
+			}
+			activatesAt = next
+			if minimum := now.Add(m.config.PublishLead); activatesAt.Before(minimum) {
+				activatesAt = minimum
+			}
+		}
+
+		record, err := m.generateKey(activatesAt)
+		if err != nil {
+			return err
+		}
+		log.Printf("Created JWT signing key %s (%s), signing from %s", record.KID, record.Algorithm, activatesAt.Format(time.RFC3339))
+		return tx.Create(record).Error
+	})
+	if err != nil {
+		return fmt.Errorf("rotate signing keys: %w", err)
+	}
+	return m.Reload(ctx)
+}
+
+// Reload reads the published keys from the database and deletes retired ones
+func (m *KeyManager) Reload(ctx context.Context) error {
+	var records []SigningKeyRecord
+	if err := m.db.WithContext(ctx).Order("activates_at ASC").Find(&records).Error; err != nil {
+		return err
+	}
+
+	now := time.Now()
+	keys := make([]*signingKey, 0, len(records))
+	var expired []string
+	for i := range records {
+		if i+1 < len(records) {
+			// A key retires when the next one activates; tokens it signed just before must
+			// still verify until they expire
+			retiresAt := records[i+1].ActivatesAt
+			if retiresAt.Add(m.tokenLifetime + m.config.PublishLead).Before(now) {
+				expired = append(expired, records[i].KID)
+				continue
+			}
+		}
+		key, err := m.decodeKey(&records[i])
+		if err != nil {
+			return fmt.Errorf("signing key %s: %w", records[i].KID, err)
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return errors.New("no signing keys")
+	}
+
+	m.mu.Lock()
+	m.keys = keys
+	m.mu.Unlock()
+
+	if len(expired) > 0 {
+		if err := m.db.WithContext(ctx).Where("kid IN ?", expired).Delete(&SigningKeyRecord{}).Error; err != nil {
+			log.Printf("Failed to delete expired signing keys: %v", err)
+		}
+	}
+	return nil
+}
+
+// StartRotation reloads keys and rotates when due on every refresh interval until ctx is cancelled
+func (m *KeyManager) StartRotation(ctx context.Context) {
+	ticker := time.NewTicker(m.config.RefreshInterval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := m.Rotate(ctx); err != nil {
+					log.Printf("JWT key rotation failed: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// current returns the key that signs new tokens: the newest one already active
+func (m *KeyManager) current() (*signingKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	now := time.Now()
+	for i := len(m.keys) - 1; i >= 0; i-- {
+		if !m.keys[i].activatesAt.After(now) {
+			return m.keys[i], nil
+		}
+	}
+	return nil, errors.New("no active signing key")
+}
+
+func (m *KeyManager) lookup(kid string) (*signingKey, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	index := slices.IndexFunc(m.keys, func(key *signingKey) bool { return key.kid == kid })
+	if index < 0 {
+		return nil, false
+	}
+	return m.keys[index], true
+}
+
+// published returns every key tokens may be signed with, including upcoming ones
+func (m *KeyManager) published() []*signingKey {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return slices.Clone(m.keys)
+}
+
+func (m *KeyManager) generateKey(activatesAt time.Time) (*SigningKeyRecord, error) {
+	var private crypto.Signer
+	switch m.config.Algorithm {
+	case AlgorithmEdDSA:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		private = key
+	case AlgorithmRS256:
+		key, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+		if err != nil {
+			return nil, err
+		}
+		private = key
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm %q", m.config.Algorithm)
+	}
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, err
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(private.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, m.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	// The kid is derived from the public key, so it is stable and unique
+	sum := sha256.Sum256(publicDER)
+	kid := base64.RawURLEncoding.EncodeToString(sum[:16])
+
+	return &SigningKeyRecord{
+		KID:         kid,
+		Algorithm:   m.config.Algorithm,
+		PrivateKey:  m.aead.Seal(nonce, nonce, privateDER, []byte(kid)),
+		PublicKey:   publicDER,
+		ActivatesAt: activatesAt,
+		CreatedAt:   time.Now().UTC(),
+	}, nil
+}
+
+func (m *KeyManager) decodeKey(record *SigningKeyRecord) (*signingKey, error) {
+	nonceSize := m.aead.NonceSize()
+	if len(record.PrivateKey) < nonceSize {
+		return nil, errors.New("private key is truncated")
+	}
+	privateDER, err := m.aead.Open(nil, record.PrivateKey[:nonceSize], record.PrivateKey[nonceSize:], []byte(record.KID))
+	if err != nil {
+		return nil, errors.New("private key cannot be decrypted; check JWT_KEY_ENCRYPTION_KEY")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(privateDER)
+	if err != nil {
+		return nil, err
+	}
+	private, ok := parsed.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("private key cannot sign")
+	}
+
+	var method jwt.SigningMethod
+	switch record.Algorithm {
+	case AlgorithmEdDSA:
+		if _, ok := private.(ed25519.PrivateKey); !ok {
+			return nil, errors.New("key is not an Ed25519 key")
+		}
+		method = jwt.SigningMethodEdDSA
+	case AlgorithmRS256:
+		if _, ok := private.(*rsa.PrivateKey); !ok {
+			return nil, errors.New("key is not an RSA key")
+		}
+		method = jwt.SigningMethodRS256
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm %q", record.Algorithm)
+	}
+
+	return &signingKey{
+		kid:         record.KID,
+		algorithm:   record.Algorithm,
+		method:      method,
+		private:     private,
+		public:      private.Public(),
+		activatesAt: record.ActivatesAt,
+	}, nil
+}
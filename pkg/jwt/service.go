@@ -1,4 +1,4 @@
-// pkg/jwt/service.go - UPDATED with expired token parsing
+// pkg/jwt/service.go - asymmetric signing with rotating keys
 package jwt
 
 import (
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 type Claims struct {
@@ -15,24 +16,32 @@ type Claims struct {
 	UserType         string `json:"user_type"`
 	DisplayName      string `json:"display_name"`
 	IsActive         bool   `json:"is_active"`
+	SessionID        uint64 `json:"sid,omitempty"` // Device session the token was issued for
 	jwt.RegisteredClaims
 }
 
+const audience = "audora-api"
+
 type JWTService struct {
-	secretKey     []byte
+	keys          *KeyManager
 	issuer        string
 	tokenLifetime time.Duration
 }
 
-func NewJWTService(secretKey, issuer string, tokenLifetime time.Duration) *JWTService {
+func NewJWTService(keys *KeyManager, issuer string, tokenLifetime time.Duration) *JWTService {
 	return &JWTService{
-		secretKey:     []byte(secretKey),
+		keys:          keys,
 		issuer:        issuer,
 		tokenLifetime: tokenLifetime,
 	}
 }
 
-func (j *JWTService) GenerateToken(userID uint64, kratosIdentityID, email, userType, displayName string, isActive bool) (string, error) {
+// TokenLifetime is how long issued access tokens are valid
+func (j *JWTService) TokenLifetime() time.Duration {
+	return j.tokenLifetime
+}
+
+func (j *JWTService) GenerateToken(userID uint64, kratosIdentityID, email, userType, displayName string, isActive bool, sessionID uint64) (string, error) {
 	now := time.Now()
 	claims := &Claims{
 		UserID:           userID,
@@ -41,27 +50,45 @@ func (j *JWTService) GenerateToken(userID uint64, kratosIdentityID, email, userT
 		UserType:         userType,
 		DisplayName:      displayName,
 		IsActive:         isActive,
+		SessionID:        sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(), // jti, so a single token can be revoked
 			Issuer:    j.issuer,
 			Subject:   kratosIdentityID,
-			Audience:  []string{"audora-api"},
+			Audience:  []string{audience},
 			ExpiresAt: jwt.NewNumericDate(now.Add(j.tokenLifetime)),
 			NotBefore: jwt.NewNumericDate(now),
 			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(j.secretKey)
+	key, err := j.keys.current()
+	if err != nil {
+		return "", err
+	}
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.kid
+	return token.SignedString(key.private)
 }
 
+// ValidateToken verifies the token with the key named by its kid. The algorithm must be
+// the one that key was created for, so a token cannot pick its own verification method.
 func (j *JWTService) ValidateToken(tokenString string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := j.keys.lookup(kid)
+		if !ok {
+			return nil, errors.New("unknown signing key")
+		}
+		if token.Method.Alg() != key.method.Alg() {
 			return nil, errors.New("invalid signing method")
 		}
-		return j.secretKey, nil
-	})
+		return key.public, nil
+	},
+		jwt.WithValidMethods([]string{AlgorithmEdDSA, AlgorithmRS256}),
+		jwt.WithIssuer(j.issuer),
+		jwt.WithAudience(audience),
+	)
 
 	if err != nil {
 		return nil, err
@@ -73,26 +100,3 @@ func (j *JWTService) ValidateToken(tokenString string) (*Claims, error) {
 
 	return nil, errors.New("invalid token")
 }
-
-// NEW: ValidateTokenIgnoringExpiry for refresh token validation
-func (j *JWTService) ValidateTokenIgnoringExpiry(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("invalid signing method")
-		}
-		return j.secretKey, nil
-	}, jwt.WithoutClaimsValidation()) // This ignores expiry validation
-
-	if err != nil {
-		return nil, err
-	}
-
-	if claims, ok := token.Claims.(*Claims); ok {
-		// Still validate the signature and basic structure, just not expiry
-		return claims, nil
-	}
-
-	return nil, errors.New("invalid token structure")
-}
-
-// REMOVED: The insecure RefreshToken method that didn't validate with Kratos
\ No newline at end of file
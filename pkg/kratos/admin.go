@@ -0,0 +1,146 @@
+package kratos
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// GetIdentity loads an identity through the admin API
+func (c *Client) GetIdentity(ctx context.Context, identityID string) (*Identity, error) {
+	var identity Identity
+	if err := c.doAdmin(ctx, http.MethodGet, "/admin/identities/"+url.PathEscape(identityID), nil, &identity); err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+// UpdateIdentityTraits replaces the identity's traits, keeping its schema and state.
+// Kratos validates the traits against the identity schema and rejects invalid ones.
+func (c *Client) UpdateIdentityTraits(ctx context.Context, identity *Identity, traits map[string]interface{}) (*Identity, error) {
+	body := map[string]interface{}{
+		"schema_id": identity.SchemaID,
+		"state":     identity.State,
+		"traits":    traits,
+	}
+
+	var updated Identity
+	if err := c.doAdmin(ctx, http.MethodPut, "/admin/identities/"+url.PathEscape(identity.ID), body, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// DeleteIdentity permanently deletes the identity with its credentials and sessions. An
+// identity that no longer exists counts as deleted.
+func (c *Client) DeleteIdentity(ctx context.Context, identityID string) error {
+	err := c.doAdmin(ctx, http.MethodDelete, "/admin/identities/"+url.PathEscape(identityID), nil, nil)
+	var kratosErr *KratosError
+	if errors.As(err, &kratosErr) && kratosErr.Code == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+// IdentityPage is one page of the admin identity listing. NextPageToken is empty on the
+// last page.
+type IdentityPage struct {
+	Identities    []Identity
+	NextPageToken string
+}
+
+// ListIdentities returns a page of identities; pass the previous page's NextPageToken to
+// continue, or "" to start from the beginning
+func (c *Client) ListIdentities(ctx context.Context, pageSize int, pageToken string) (*IdentityPage, error) {
+	query := url.Values{}
+	query.Set("page_size", strconv.Itoa(pageSize))
+	if pageToken != "" {
+		query.Set("page_token", pageToken)
+	}
+
+	page := &IdentityPage{}
+	header, err := c.doAdminRequest(ctx, http.MethodGet, "/admin/identities?"+query.Encode(), nil, &page.Identities)
+	if err != nil {
+		return nil, err
+	}
+	page.NextPageToken = nextPageToken(header.Values("Link"))
+	return page, nil
+}
+
+// nextPageToken reads the page_token of the rel="next" link Kratos sends while there are
+// more pages
+func nextPageToken(links []string) string {
+	for _, value := range links {
+		for _, link := range strings.Split(value, ",") {
+			parts := strings.Split(link, ";")
+			if len(parts) < 2 || !strings.Contains(strings.Join(parts[1:], ";"), `rel="next"`) {
+				continue
+			}
+			target, err := url.Parse(strings.Trim(strings.TrimSpace(parts[0]), "<>"))
+			if err != nil {
+				continue
+			}
+			return target.Query().Get("page_token")
+		}
+	}
+	return ""
+}
+
+func (c *Client) doAdmin(ctx context.Context, method, path string, body interface{}, out interface{}) error {
+	_, err := c.doAdminRequest(ctx, method, path, body, out)
+	return err
+}
+
+func (c *Client) doAdminRequest(ctx context.Context, method, path string, body interface{}, out interface{}) (http.Header, error) {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.adminURL+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		// Admin API errors are wrapped in {"error": {...}}
+		var wrapped struct {
+			Error KratosError `json:"error"`
+		}
+		if err := json.Unmarshal(respBody, &wrapped); err != nil || wrapped.Error.Code == 0 {
+			return nil, &KratosError{Code: resp.StatusCode, Status: http.StatusText(resp.StatusCode), Message: string(respBody)}
+		}
+		return nil, &wrapped.Error
+	}
+
+	if out != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+	}
+	return resp.Header, nil
+}
@@ -167,6 +167,15 @@ func (i *Identity) GetDisplayName() string {
 	return ""
 }
 
+// GetArtistName extracts the artist name from traits
+func (i *Identity) GetArtistName() string {
+	traits := i.GetTraits()
+	if artistName, ok := traits["artist_name"].(string); ok {
+		return artistName
+	}
+	return ""
+}
+
 // GetUserType extracts user type from traits
 func (i *Identity) GetUserType() string {
 	traits := i.GetTraits()
@@ -0,0 +1,128 @@
+package kratos
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const (
+	// WebhookAPIKeyHeader carries the shared key Kratos sends with its web_hook auth config
+	WebhookAPIKeyHeader = "X-Kratos-Webhook-Key"
+	// WebhookSignatureHeader carries the hex HMAC-SHA256 of the body, for callers that sign
+	WebhookSignatureHeader = "X-Kratos-Signature"
+)
+
+// WebhookConfig decides which requests to the Kratos webhook endpoints are genuine
+type WebhookConfig struct {
+	APIKey          string
+	SigningSecret   string
+	AllowedNetworks []*net.IPNet // Empty allows any source
+}
+
+// WebhookConfigFromEnv reads KRATOS_WEBHOOK_API_KEY, KRATOS_WEBHOOK_SECRET and
+// KRATOS_WEBHOOK_ALLOWED_NETWORKS (comma separated CIDRs or addresses)
+func WebhookConfigFromEnv() (*WebhookConfig, error) {
+	config := &WebhookConfig{
+		APIKey:        os.Getenv("KRATOS_WEBHOOK_API_KEY"),
+		SigningSecret: os.Getenv("KRATOS_WEBHOOK_SECRET"),
+	}
+
+	for _, entry := range strings.Split(os.Getenv("KRATOS_WEBHOOK_ALLOWED_NETWORKS"), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			if ip := net.ParseIP(entry); ip != nil && ip.To4() != nil {
+				entry += "/32"
+			} else {
+				entry += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid KRATOS_WEBHOOK_ALLOWED_NETWORKS entry %q: %w", entry, err)
+		}
+		config.AllowedNetworks = append(config.AllowedNetworks, network)
+	}
+	return config, nil
+}
+
+// Enabled reports whether any credential is configured; without one every call is rejected
+func (c *WebhookConfig) Enabled() bool {
+	return c.APIKey != "" || c.SigningSecret != ""
+}
+
+// AllowsSource reports whether the request may come from ip
+func (c *WebhookConfig) AllowsSource(ip net.IP) bool {
+	if len(c.AllowedNetworks) == 0 {
+		return true
+	}
+	if ip == nil {
+		return false
+	}
+	for _, network := range c.AllowedNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// Authenticate accepts the request when it carries the API key or a valid body signature
+func (c *WebhookConfig) Authenticate(header http.Header, body []byte) bool {
+	if key := header.Get(WebhookAPIKeyHeader); c.APIKey != "" && key != "" {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(c.APIKey)) == 1 {
+			return true
+		}
+	}
+	if signature := header.Get(WebhookSignatureHeader); c.SigningSecret != "" && signature != "" {
+		mac := hmac.New(sha256.New, []byte(c.SigningSecret))
+		mac.Write(body)
+		expected := hex.EncodeToString(mac.Sum(nil))
+		return hmac.Equal([]byte(expected), []byte(strings.ToLower(signature)))
+	}
+	return false
+}
+
+// Message IDs of errors the API returns to Kratos flows
+const (
+	WebhookMessageInvalid  = 4000001
+	WebhookMessageInternal = 5000001
+)
+
+// WebhookErrorResponse is the body Kratos expects from a webhook that interrupts a flow.
+// Kratos attaches each message to the form field instance_ptr points at ("#/traits/email"),
+// or to the whole form for "#/".
+type WebhookErrorResponse struct {
+	Messages []WebhookFieldMessages `json:"messages"`
+}
+
+type WebhookFieldMessages struct {
+	InstancePtr string           `json:"instance_ptr"`
+	Messages    []WebhookMessage `json:"messages"`
+}
+
+type WebhookMessage struct {
+	ID      int                    `json:"id"`
+	Text    string                 `json:"text"`
+	Type    string                 `json:"type"`
+	Context map[string]interface{} `json:"context"`
+}
+
+// NewWebhookError returns a single error message for the field at instancePtr
+func NewWebhookError(instancePtr string, id int, text string) *WebhookErrorResponse {
+	return &WebhookErrorResponse{
+		Messages: []WebhookFieldMessages{{
+			InstancePtr: instancePtr,
+			Messages:    []WebhookMessage{{ID: id, Text: text, Type: "error", Context: map[string]interface{}{}}},
+		}},
+	}
+}
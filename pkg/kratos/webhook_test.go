@@ -0,0 +1,118 @@
+package kratos
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestWebhookConfigFromEnvAllowedNetworks(t *testing.T) {
+	tests := []struct {
+		name     string
+		networks string
+		want     []string
+		wantErr  bool
+	}{
+		{name: "unset", networks: "", want: nil},
+		{name: "cidr list with spaces", networks: "172.16.0.0/12, 10.0.0.0/8", want: []string{"172.16.0.0/12", "10.0.0.0/8"}},
+		{name: "bare IPv4 address", networks: "10.1.2.3", want: []string{"10.1.2.3/32"}},
+		{name: "bare IPv6 address", networks: "fd00::1", want: []string{"fd00::1/128"}},
+		{name: "empty entries skipped", networks: ",10.0.0.0/8,,", want: []string{"10.0.0.0/8"}},
+		{name: "invalid entry", networks: "10.0.0.0/8,not-a-network", wantErr: true},
+		{name: "invalid prefix", networks: "10.0.0.0/40", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("KRATOS_WEBHOOK_ALLOWED_NETWORKS", tt.networks)
+			config, err := WebhookConfigFromEnv()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := make([]string, 0, len(config.AllowedNetworks))
+			for _, network := range config.AllowedNetworks {
+				got = append(got, network.String())
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Fatalf("networks = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhookConfigAllowsSource(t *testing.T) {
+	_, private, _ := net.ParseCIDR("172.16.0.0/12")
+	restricted := &WebhookConfig{AllowedNetworks: []*net.IPNet{private}}
+
+	tests := []struct {
+		name   string
+		config *WebhookConfig
+		ip     net.IP
+		want   bool
+	}{
+		{name: "no networks allows any source", config: &WebhookConfig{}, ip: net.ParseIP("203.0.113.7"), want: true},
+		{name: "no networks allows unknown source", config: &WebhookConfig{}, ip: nil, want: true},
+		{name: "inside allowed network", config: restricted, ip: net.ParseIP("172.18.0.5"), want: true},
+		{name: "outside allowed network", config: restricted, ip: net.ParseIP("203.0.113.7"), want: false},
+		{name: "unknown source", config: restricted, ip: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.AllowsSource(tt.ip); got != tt.want {
+				t.Fatalf("AllowsSource(%v) = %t, want %t", tt.ip, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhookConfigAuthenticate(t *testing.T) {
+	body := []byte(`{"identity_id":"abc"}`)
+	sign := func(secret string, body []byte) string {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+	both := &WebhookConfig{APIKey: "key", SigningSecret: "secret"}
+
+	tests := []struct {
+		name    string
+		config  *WebhookConfig
+		headers map[string]string
+		want    bool
+	}{
+		{name: "matching key", config: both, headers: map[string]string{WebhookAPIKeyHeader: "key"}, want: true},
+		{name: "wrong key", config: both, headers: map[string]string{WebhookAPIKeyHeader: "other"}, want: false},
+		{name: "no credentials", config: both, headers: nil, want: false},
+		{name: "valid signature", config: both, headers: map[string]string{WebhookSignatureHeader: sign("secret", body)}, want: true},
+		{name: "uppercase signature", config: both, headers: map[string]string{WebhookSignatureHeader: strings.ToUpper(sign("secret", body))}, want: true},
+		{name: "signature over another body", config: both, headers: map[string]string{WebhookSignatureHeader: sign("secret", []byte("{}"))}, want: false},
+		{name: "signature with another secret", config: both, headers: map[string]string{WebhookSignatureHeader: sign("other", body)}, want: false},
+		{name: "wrong key falls back to valid signature", config: both, headers: map[string]string{WebhookAPIKeyHeader: "other", WebhookSignatureHeader: sign("secret", body)}, want: true},
+		{name: "nothing configured rejects empty key", config: &WebhookConfig{}, headers: map[string]string{WebhookAPIKeyHeader: ""}, want: false},
+		{name: "nothing configured rejects any key", config: &WebhookConfig{}, headers: map[string]string{WebhookAPIKeyHeader: "key"}, want: false},
+		{name: "signature ignored without secret", config: &WebhookConfig{APIKey: "key"}, headers: map[string]string{WebhookSignatureHeader: sign("", body)}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			for name, value := range tt.headers {
+				header.Set(name, value)
+			}
+			if got := tt.config.Authenticate(header, body); got != tt.want {
+				t.Fatalf("Authenticate = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Message is a rendered email ready to send
+type Message struct {
+	To       string
+	ToName   string
+	Subject  string
+	TextBody string
+	HTMLBody string
+	Headers  map[string]string
+}
+
+// Sender delivers messages. Implementations must be safe for concurrent use.
+type Sender interface {
+	Send(ctx context.Context, message *Message) error
+}
+
+// From is the envelope and header sender of every message
+type From struct {
+	Address string
+	Name    string
+}
+
+// NewSenderFromEnv returns the sender selected by MAIL_DRIVER: smtp, file or memory. The
+// file driver is the default only outside production; with APP_ENV=production the driver
+// must be chosen explicitly so mail is never written to disk by accident.
+func NewSenderFromEnv() (Sender, error) {
+	from := From{
+		Address: getEnv("MAIL_FROM_ADDRESS", "noreply@audora.com"),
+		Name:    getEnv("MAIL_FROM_NAME", "Audora"),
+	}
+
+	driver := os.Getenv("MAIL_DRIVER")
+	if driver == "" {
+		if strings.EqualFold(os.Getenv("APP_ENV"), "production") {
+			return nil, fmt.Errorf("MAIL_DRIVER must be set when APP_ENV=production")
+		}
+		driver = "file"
+	}
+
+	switch driver {
+	case "smtp":
+		return NewSMTPSender(getEnv("MAIL_SMTP_URL", "smtp://localhost:1025"), from)
+	case "file":
+		return NewFileSender(getEnv("MAIL_FILE_DIR", "./tmp/mail"), from)
+	case "memory":
+		return NewMemorySender(), nil
+	default:
+		return nil, fmt.Errorf("unsupported mail driver: %s", driver)
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,77 @@
+package mail
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Unsubscribe scopes; each maps to one email preference of the user
+const (
+	ScopeNotifications = "notifications"
+	ScopeMarketing     = "marketing"
+)
+
+var ErrInvalidUnsubscribeToken = errors.New("invalid unsubscribe token")
+
+// UnsubscribeTokens signs links that let a recipient opt out without signing in. Tokens
+// do not expire, since old emails must keep working.
+type UnsubscribeTokens struct {
+	secret []byte
+}
+
+func NewUnsubscribeTokens(secret string) *UnsubscribeTokens {
+	return &UnsubscribeTokens{secret: []byte(secret)}
+}
+
+// NewUnsubscribeTokensFromEnv signs with MAIL_UNSUBSCRIBE_SECRET. Without it every token
+// is rejected, since anyone could forge one.
+func NewUnsubscribeTokensFromEnv() *UnsubscribeTokens {
+	secret := os.Getenv("MAIL_UNSUBSCRIBE_SECRET")
+	if secret == "" {
+		log.Println("MAIL_UNSUBSCRIBE_SECRET is not set; unsubscribe links will be rejected")
+	}
+	return NewUnsubscribeTokens(secret)
+}
+
+func (t *UnsubscribeTokens) Generate(userID uint64, scope string) string {
+	payload := strconv.FormatUint(userID, 10) + ":" + scope
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + base64.RawURLEncoding.EncodeToString(t.sign(payload))
+}
+
+// Verify returns the user and scope the token was issued for
+func (t *UnsubscribeTokens) Verify(token string) (uint64, string, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok || len(t.secret) == 0 {
+		return 0, "", ErrInvalidUnsubscribeToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return 0, "", ErrInvalidUnsubscribeToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, t.sign(string(payload))) {
+		return 0, "", ErrInvalidUnsubscribeToken
+	}
+
+	rawUserID, scope, ok := strings.Cut(string(payload), ":")
+	if !ok {
+		return 0, "", ErrInvalidUnsubscribeToken
+	}
+	userID, err := strconv.ParseUint(rawUserID, 10, 64)
+	if err != nil {
+		return 0, "", ErrInvalidUnsubscribeToken
+	}
+	return userID, scope, nil
+}
+
+func (t *UnsubscribeTokens) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, t.secret)
+	mac.Write([]byte("unsubscribe:" + payload))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,77 @@
+package mail
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestUnsubscribeTokensVerify(t *testing.T) {
+	tokens := NewUnsubscribeTokens("test-secret")
+	valid := tokens.Generate(42, ScopeMarketing)
+	encodedPayload, encodedSignature, _ := strings.Cut(valid, ".")
+	forgedPayload := base64.RawURLEncoding.EncodeToString([]byte("43:" + ScopeMarketing))
+
+	tests := []struct {
+		name       string
+		tokens     *UnsubscribeTokens
+		token      string
+		wantUserID uint64
+		wantScope  string
+		wantErr    bool
+	}{
+		{name: "valid token", tokens: tokens, token: valid, wantUserID: 42, wantScope: ScopeMarketing},
+		{name: "other scope", tokens: tokens, token: tokens.Generate(7, ScopeNotifications), wantUserID: 7, wantScope: ScopeNotifications},
+		{name: "signed with another secret", tokens: NewUnsubscribeTokens("other-secret"), token: valid, wantErr: true},
+		{name: "no secret configured", tokens: NewUnsubscribeTokens(""), token: NewUnsubscribeTokens("").Generate(42, ScopeMarketing), wantErr: true},
+		{name: "payload swapped for another user", tokens: tokens, token: forgedPayload + "." + encodedSignature, wantErr: true},
+		{name: "signature removed", tokens: tokens, token: encodedPayload, wantErr: true},
+		{name: "empty signature", tokens: tokens, token: encodedPayload + ".", wantErr: true},
+		{name: "payload not base64", tokens: tokens, token: "!!!." + encodedSignature, wantErr: true},
+		{name: "signature not base64", tokens: tokens, token: encodedPayload + ".!!!", wantErr: true},
+		{name: "empty token", tokens: tokens, token: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID, scope, err := tt.tokens.Verify(tt.token)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidUnsubscribeToken) {
+					t.Fatalf("err = %v, want %v", err, ErrInvalidUnsubscribeToken)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if userID != tt.wantUserID || scope != tt.wantScope {
+				t.Fatalf("got (%d, %q), want (%d, %q)", userID, scope, tt.wantUserID, tt.wantScope)
+			}
+		})
+	}
+}
+
+// A payload that is not "<user id>:<scope>" is rejected even when correctly signed
+func TestUnsubscribeTokensVerifyMalformedPayload(t *testing.T) {
+	tokens := NewUnsubscribeTokens("test-secret")
+
+	tests := []struct {
+		name    string
+		payload string
+	}{
+		{name: "no separator", payload: "42"},
+		{name: "user id not a number", payload: "abc:" + ScopeMarketing},
+		{name: "negative user id", payload: "-1:" + ScopeMarketing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := base64.RawURLEncoding.EncodeToString([]byte(tt.payload)) + "." +
+				base64.RawURLEncoding.EncodeToString(tokens.sign(tt.payload))
+			if _, _, err := tokens.Verify(token); !errors.Is(err, ErrInvalidUnsubscribeToken) {
+				t.Fatalf("err = %v, want %v", err, ErrInvalidUnsubscribeToken)
+			}
+		})
+	}
+}
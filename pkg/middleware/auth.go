@@ -3,8 +3,8 @@ package middleware
 
 import (
 	"music-app-backend/internal/auth/application"
+	"music-app-backend/pkg/audit"
 	jsonResponse "music-app-backend/pkg/json"
-	"music-app-backend/pkg/jwt"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -12,6 +12,7 @@ import (
 
 type AuthMiddleware struct {
 	authService *application.AuthService
+	auditLogger audit.Logger
 }
 
 func NewAuthMiddleware(authService *application.AuthService) *AuthMiddleware {
@@ -39,7 +40,7 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 		}
 
 		// Validate token
-		claims, err := m.authService.ValidateJWT(tokenString)
+		claims, err := m.authService.ValidateJWT(c.Request.Context(), tokenString)
 		if err != nil {
 			jsonResponse.ResponseUnauthorized(c)
 			c.Abort()
@@ -53,6 +54,7 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 		c.Set("user_type", claims.UserType)
 		c.Set("user_email", claims.Email)
 		c.Set("user_tier", "free") // Default to free tier, can be updated later
+		audit.SetActor(c.Request.Context(), claims.UserID, claims.UserType)
 
 		c.Next()
 	}
@@ -60,44 +62,12 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 
 // RequireArtist middleware that requires user to be an artist
 func (m *AuthMiddleware) RequireArtist() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		claims, exists := c.Get("user_claims")
-		if !exists {
-			jsonResponse.ResponseUnauthorized(c)
-			c.Abort()
-			return
-		}
-
-		userClaims := claims.(*jwt.Claims)
-		if userClaims.UserType != "artist" {
-			jsonResponse.ResponseForbidden(c)
-			c.Abort()
-			return
-		}
-
-		c.Next()
-	}
+	return m.RequireRole(RoleArtist)
 }
 
 // RequireListener middleware that requires user to be a listener
 func (m *AuthMiddleware) RequireListener() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		claims, exists := c.Get("user_claims")
-		if !exists {
-			jsonResponse.ResponseUnauthorized(c)
-			c.Abort()
-			return
-		}
-
-		userClaims := claims.(*jwt.Claims)
-		if userClaims.UserType != "listener" {
-			jsonResponse.ResponseForbidden(c)
-			c.Abort()
-			return
-		}
-
-		c.Next()
-	}
+	return m.RequireRole(RoleListener)
 }
 
 // OptionalAuth middleware that optionally validates JWT token
@@ -117,13 +87,14 @@ func (m *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
 		}
 
 		// Try to validate token, but don't fail if invalid
-		claims, err := m.authService.ValidateJWT(tokenString)
+		claims, err := m.authService.ValidateJWT(c.Request.Context(), tokenString)
 		if err == nil {
 			c.Set("user_claims", claims)
 			c.Set("user_id", claims.UserID)
 			c.Set("kratos_identity_id", claims.KratosIdentityID)
 			c.Set("user_type", claims.UserType)
 			c.Set("user_email", claims.Email)
+			audit.SetActor(c.Request.Context(), claims.UserID, claims.UserType)
 		}
 
 		c.Next()
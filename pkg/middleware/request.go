@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"music-app-backend/pkg/audit"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Request IDs from a proxy are reused when they look sane, so logs can be joined up
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestContext gives every request an ID, echoed in X-Request-ID, and puts the request
+// details the audit log needs into the request context. It must run before the routes.
+func RequestContext() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader("X-Request-ID")
+		if !requestIDPattern.MatchString(requestID) {
+			requestID = uuid.NewString()
+		}
+		c.Set("request_id", requestID)
+		c.Header("X-Request-ID", requestID)
+
+		ctx := audit.WithRequest(c.Request.Context(), &audit.Request{
+			ID:        requestID,
+			IPAddress: c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+			Method:    c.Request.Method,
+			Path:      c.Request.URL.Path,
+		})
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
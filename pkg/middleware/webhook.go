@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"log"
+	jsonResponse "music-app-backend/pkg/json"
+	"music-app-backend/pkg/kratos"
+	"net"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxWebhookBody bounds what is read before the caller is authenticated
+const maxWebhookBody = 1 << 20
+
+// KratosWebhook only lets through calls from Kratos: from an allowed network and carrying
+// the shared API key or a valid signature. The source is the peer address, not
+// X-Forwarded-For, so it cannot be spoofed through headers.
+func KratosWebhook(config *kratos.WebhookConfig) gin.HandlerFunc {
+	if !config.Enabled() {
+		log.Println("KRATOS_WEBHOOK_API_KEY and KRATOS_WEBHOOK_SECRET are not set; Kratos webhooks will be rejected")
+	}
+
+	return func(c *gin.Context) {
+		if !config.AllowsSource(net.ParseIP(c.RemoteIP())) {
+			jsonResponse.ResponseForbidden(c)
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxWebhookBody))
+		if err != nil {
+			jsonResponse.ResponseBadRequest(c, "Unable to read request body")
+			c.Abort()
+			return
+		}
+		if !config.Authenticate(c.Request.Header, body) {
+			jsonResponse.ResponseUnauthorized(c)
+			c.Abort()
+			return
+		}
+
+		// The handler binds the body again
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		c.Next()
+	}
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"io"
+	"music-app-backend/pkg/kratos"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestKratosWebhook(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	_, private, _ := net.ParseCIDR("172.16.0.0/12")
+	config := &kratos.WebhookConfig{APIKey: "key", AllowedNetworks: []*net.IPNet{private}}
+	const body = `{"identity_id":"abc"}`
+
+	tests := []struct {
+		name       string
+		config     *kratos.WebhookConfig
+		remoteAddr string
+		forwarded  string
+		key        string
+		wantStatus int
+	}{
+		{name: "allowed source with key", config: config, remoteAddr: "172.18.0.5:41000", key: "key", wantStatus: http.StatusOK},
+		{name: "allowed source without key", config: config, remoteAddr: "172.18.0.5:41000", wantStatus: http.StatusUnauthorized},
+		{name: "allowed source with wrong key", config: config, remoteAddr: "172.18.0.5:41000", key: "other", wantStatus: http.StatusUnauthorized},
+		{name: "outside source with key", config: config, remoteAddr: "203.0.113.7:41000", key: "key", wantStatus: http.StatusForbidden},
+		{name: "forwarded header cannot spoof source", config: config, remoteAddr: "203.0.113.7:41000", forwarded: "172.18.0.5", key: "key", wantStatus: http.StatusForbidden},
+		{name: "no credentials configured", config: &kratos.WebhookConfig{}, remoteAddr: "172.18.0.5:41000", key: "key", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var handlerBody string
+			router := gin.New()
+			router.POST("/hook", KratosWebhook(tt.config), func(c *gin.Context) {
+				raw, _ := io.ReadAll(c.Request.Body)
+				handlerBody = string(raw)
+				c.Status(http.StatusOK)
+			})
+
+			request := httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader(body))
+			request.RemoteAddr = tt.remoteAddr
+			if tt.forwarded != "" {
+				request.Header.Set("X-Forwarded-For", tt.forwarded)
+			}
+			if tt.key != "" {
+				request.Header.Set(kratos.WebhookAPIKeyHeader, tt.key)
+			}
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusOK && handlerBody != body {
+				t.Fatalf("handler read body %q, want %q", handlerBody, body)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CursorParams pages through a list ordered by (time, id) descending. Unlike offsets, a
+// cursor stays stable while rows are inserted or removed between requests.
+type CursorParams struct {
+	After *Cursor `json:"-"`
+	Limit int     `json:"limit"`
+}
+
+// Cursor is the position of the last row of the previous page
+type Cursor struct {
+	Time time.Time
+	ID   uint64
+}
+
+type CursorMeta struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
+// CursorFromQuery reads cursor and limit from the query string. An invalid cursor is an
+// error rather than silently restarting from the first page.
+func CursorFromQuery(c *gin.Context) (CursorParams, error) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(DefaultLimit)))
+	if err != nil || limit < 1 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	params := CursorParams{Limit: limit}
+	if raw := c.Query("cursor"); raw != "" {
+		cursor, err := DecodeCursor(raw)
+		if err != nil {
+			return params, err
+		}
+		params.After = cursor
+	}
+	return params, nil
+}
+
+func EncodeCursor(t time.Time, id uint64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%d", t.UnixNano(), id)))
+}
+
+func DecodeCursor(value string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	i, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	return &Cursor{Time: time.Unix(0, n).UTC(), ID: i}, nil
+}
+
+// Meta builds the page metadata. Repositories fetch Limit+1 rows; fetched is how many came
+// back and last is the position of the last row that is returned to the client.
+func (p CursorParams) Meta(fetched int, last Cursor) CursorMeta {
+	meta := CursorMeta{Limit: p.Limit, HasMore: fetched > p.Limit}
+	if meta.HasMore {
+		meta.NextCursor = EncodeCursor(last.Time, last.ID)
+	}
+	return meta
+}
@@ -0,0 +1,56 @@
+package pagination
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+type Params struct {
+	Page  int `json:"page"`
+	Limit int `json:"limit"`
+}
+
+type Meta struct {
+	Page       int   `json:"page"`
+	Limit      int   `json:"limit"`
+	Total      int64 `json:"total"`
+	TotalPages int   `json:"total_pages"`
+}
+
+// FromQuery reads page and limit from the query string, falling back to sane defaults
+func FromQuery(c *gin.Context) Params {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(DefaultLimit)))
+	if err != nil || limit < 1 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	return Params{Page: page, Limit: limit}
+}
+
+func (p Params) Offset() int {
+	return (p.Page - 1) * p.Limit
+}
+
+func (p Params) Meta(total int64) Meta {
+	totalPages := int((total + int64(p.Limit) - 1) / int64(p.Limit))
+	return Meta{
+		Page:       p.Page,
+		Limit:      p.Limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+}
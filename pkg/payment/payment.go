@@ -0,0 +1,108 @@
+// pkg/payment/payment.go
+package payment
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StatusSucceeded = "succeeded"
+	StatusPending   = "pending"
+	StatusFailed    = "failed"
+)
+
+// Provider abstracts the card processor used to collect tips
+type Provider interface {
+	CreatePayment(ctx context.Context, request *PaymentRequest) (*Payment, error)
+	RefundPayment(ctx context.Context, request *RefundRequest) (*Refund, error)
+}
+
+type PaymentRequest struct {
+	AmountCents    int               `json:"amount_cents"`
+	Currency       string            `json:"currency"`
+	Description    string            `json:"description"`
+	IdempotencyKey string            `json:"idempotency_key"`
+	Metadata       map[string]string `json:"metadata,omitempty"`
+}
+
+type Payment struct {
+	IntentID  string    `json:"intent_id"`
+	ChargeID  string    `json:"charge_id"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type RefundRequest struct {
+	PaymentIntentID string `json:"payment_intent_id"`
+	AmountCents     int    `json:"amount_cents"`
+	Reason          string `json:"reason"`
+	IdempotencyKey  string `json:"idempotency_key"`
+}
+
+type Refund struct {
+	RefundID  string    `json:"refund_id"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewProviderFromEnv returns the provider selected by PAYMENT_PROVIDER. The sandbox
+// credits tips without collecting money, so it is the default only outside production;
+// with APP_ENV=production a real provider must be chosen.
+func NewProviderFromEnv() (Provider, error) {
+	providerName := os.Getenv("PAYMENT_PROVIDER")
+	production := strings.EqualFold(os.Getenv("APP_ENV"), "production")
+	if providerName == "" {
+		if production {
+			return nil, fmt.Errorf("PAYMENT_PROVIDER must be set when APP_ENV=production")
+		}
+		providerName = "sandbox"
+	}
+
+	switch providerName {
+	case "sandbox":
+		if production {
+			return nil, fmt.Errorf("the sandbox payment provider cannot be used when APP_ENV=production")
+		}
+		return NewSandboxProvider(), nil
+	default:
+		return nil, fmt.Errorf("unsupported payment provider: %s", providerName)
+	}
+}
+
+// SandboxProvider settles every payment immediately; used for local development
+type SandboxProvider struct{}
+
+func NewSandboxProvider() *SandboxProvider {
+	return &SandboxProvider{}
+}
+
+func (p *SandboxProvider) CreatePayment(ctx context.Context, request *PaymentRequest) (*Payment, error) {
+	if request.AmountCents <= 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+
+	return &Payment{
+		IntentID:  "pi_sandbox_" + uuid.NewString(),
+		ChargeID:  "ch_sandbox_" + uuid.NewString(),
+		Status:    StatusSucceeded,
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+func (p *SandboxProvider) RefundPayment(ctx context.Context, request *RefundRequest) (*Refund, error) {
+	if request.AmountCents <= 0 {
+		return nil, fmt.Errorf("refund amount must be positive")
+	}
+
+	return &Refund{
+		RefundID:  "re_sandbox_" + uuid.NewString(),
+		Status:    StatusSucceeded,
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
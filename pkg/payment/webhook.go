@@ -0,0 +1,45 @@
+// pkg/payment/webhook.go
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+const (
+	WebhookSignatureHeader = "X-Payment-Signature"
+
+	EventPaymentSucceeded = "payment_intent.succeeded"
+	EventPaymentFailed    = "payment_intent.payment_failed"
+	EventChargeRefunded   = "charge.refunded"
+	EventChargebackOpened = "charge.dispute.created"
+)
+
+// WebhookEvent is the provider-neutral payload accepted on the payment webhook
+type WebhookEvent struct {
+	ID              string `json:"id"`
+	Type            string `json:"type"`
+	PaymentIntentID string `json:"payment_intent_id"`
+	ChargeID        string `json:"charge_id,omitempty"`  // payment_intent.succeeded
+	RefundID        string `json:"refund_id,omitempty"`  // charge.refunded
+	DisputeID       string `json:"dispute_id,omitempty"` // charge.dispute.created
+	AmountCents     int    `json:"amount_cents"`         // Zero means the full remaining amount
+	Reason          string `json:"reason"`
+}
+
+// SignWebhookPayload returns the hex HMAC-SHA256 of body under secret
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature compares signature against the expected HMAC in constant time
+func VerifyWebhookSignature(secret string, body []byte, signature string) bool {
+	if secret == "" || signature == "" {
+		return false
+	}
+	expected := SignWebhookPayload(secret, body)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
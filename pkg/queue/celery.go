@@ -126,7 +126,7 @@ type AudioAnalysis struct {
 func NewCeleryClient(redisClient *redis.Client) *CeleryClient {
 	return &CeleryClient{
 		redisClient: redisClient,
-		broker:      fmt.Sprintf("redis://%s", redisClient), // Will be properly formatted
+		broker:      fmt.Sprintf("redis://%v", redisClient), // Will be properly formatted
 	}
 }
 
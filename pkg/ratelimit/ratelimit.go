@@ -0,0 +1,72 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"music-app-backend/pkg/redis"
+	"time"
+)
+
+// Limit allows at most Max hits per fixed Window
+type Limit struct {
+	Max    int64
+	Window time.Duration
+}
+
+type Result struct {
+	Allowed    bool
+	Limit      Limit
+	Remaining  int64
+	RetryAfter time.Duration
+}
+
+// Limiter counts hits in Redis so every replica shares the same windows. Each limit is
+// checked in order; a hit rejected by one limit still counts towards the earlier ones.
+type Limiter struct {
+	client *redis.Client
+	prefix string
+	limits []Limit
+}
+
+func NewLimiter(client *redis.Client, prefix string, limits ...Limit) *Limiter {
+	return &Limiter{
+		client: client,
+		prefix: prefix,
+		limits: limits,
+	}
+}
+
+// Allow records a hit for key and reports whether it fits in every limit
+func (l *Limiter) Allow(ctx context.Context, key string) (*Result, error) {
+	now := time.Now().UTC()
+	result := &Result{Allowed: true, Remaining: -1}
+
+	for _, limit := range l.limits {
+		if limit.Max <= 0 || limit.Window <= 0 {
+			continue
+		}
+
+		window := now.Truncate(limit.Window)
+		counterKey := fmt.Sprintf("ratelimit:%s:%s:%d:%d", l.prefix, key, int64(limit.Window.Seconds()), window.Unix())
+		count, err := l.client.IncrWithExpire(ctx, counterKey, limit.Window)
+		if err != nil {
+			return nil, err
+		}
+
+		remaining := limit.Max - count
+		if remaining < 0 {
+			return &Result{
+				Allowed:    false,
+				Limit:      limit,
+				Remaining:  0,
+				RetryAfter: window.Add(limit.Window).Sub(now),
+			}, nil
+		}
+		if result.Remaining < 0 || remaining < result.Remaining {
+			result.Limit = limit
+			result.Remaining = remaining
+		}
+	}
+
+	return result, nil
+}
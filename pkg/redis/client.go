@@ -102,6 +102,16 @@ func (c *Client) Get(ctx context.Context, key string) (string, error) {
 	return c.rdb.Get(ctx, key).Result()
 }
 
+// GetDel reads the key and deletes it in one step, so only one caller gets the value; a
+// missing key returns an empty string
+func (c *Client) GetDel(ctx context.Context, key string) (string, error) {
+	value, err := c.rdb.GetDel(ctx, key).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	return value, err
+}
+
 func (c *Client) Del(ctx context.Context, keys ...string) error {
 	return c.rdb.Del(ctx, keys...).Err()
 }
@@ -160,6 +170,132 @@ func (c *Client) ProcessDelayedJobs(ctx context.Context, queueName string) error
 	return nil
 }
 
+func (c *Client) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	return c.rdb.SetNX(ctx, key, value, expiration).Result()
+}
+
+func (c *Client) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	return c.rdb.Expire(ctx, key, expiration).Err()
+}
+
+// IncrWithExpire increments the counter and (re)sets its TTL in one round trip
+func (c *Client) IncrWithExpire(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	pipe := c.rdb.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, expiration)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+// setIfUnchangedScript sets KEYS[1] only while KEYS[2] holds ARGV[3], "" meaning missing
+var setIfUnchangedScript = redis.NewScript(`
+local current = redis.call('GET', KEYS[2]) or ''
+if current ~= ARGV[3] then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+return 1
+`)
+
+// SetIfUnchanged sets key only while guardKey still holds guardValue ("" for a missing
+// key), so a value computed before a concurrent change of guardKey is not written back
+func (c *Client) SetIfUnchanged(ctx context.Context, key string, value interface{}, expiration time.Duration, guardKey, guardValue string) (bool, error) {
+	set, err := setIfUnchangedScript.Run(ctx, c.rdb, []string{key, guardKey}, value, expiration.Milliseconds(), guardValue).Int()
+	if err != nil {
+		return false, err
+	}
+	return set == 1, nil
+}
+
+// HGetAllMany fetches several hashes in one round trip; missing keys yield empty maps
+func (c *Client) HGetAllMany(ctx context.Context, keys []string) ([]map[string]string, error) {
+	pipe := c.rdb.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, len(keys))
+	for i, key := range keys {
+		cmds[i] = pipe.HGetAll(ctx, key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	results := make([]map[string]string, len(keys))
+	for i, cmd := range cmds {
+		results[i] = cmd.Val()
+	}
+	return results, nil
+}
+
+// GetMany fetches several string keys in one round trip; missing keys yield ""
+func (c *Client) GetMany(ctx context.Context, keys ...string) ([]string, error) {
+	values, err := c.rdb.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]string, len(keys))
+	for i, value := range values {
+		if str, ok := value.(string); ok {
+			results[i] = str
+		}
+	}
+	return results, nil
+}
+
+// Sorted set operations
+func (c *Client) ZAdd(ctx context.Context, key string, score float64, member string) error {
+	return c.rdb.ZAdd(ctx, key, redis.Z{Score: score, Member: member}).Err()
+}
+
+func (c *Client) ZRem(ctx context.Context, key string, members ...interface{}) error {
+	return c.rdb.ZRem(ctx, key, members...).Err()
+}
+
+func (c *Client) ZCard(ctx context.Context, key string) (int64, error) {
+	return c.rdb.ZCard(ctx, key).Result()
+}
+
+func (c *Client) ZCount(ctx context.Context, key, min, max string) (int64, error) {
+	return c.rdb.ZCount(ctx, key, min, max).Result()
+}
+
+func (c *Client) ZRangeByScore(ctx context.Context, key, min, max string) ([]string, error) {
+	return c.rdb.ZRangeByScore(ctx, key, &redis.ZRangeBy{Min: min, Max: max}).Result()
+}
+
+func (c *Client) ZRemRangeByScore(ctx context.Context, key, min, max string) (int64, error) {
+	return c.rdb.ZRemRangeByScore(ctx, key, min, max).Result()
+}
+
+// Stream operations
+// XAdd appends to the stream, trimming it to roughly maxLen entries, and returns the entry ID
+func (c *Client) XAdd(ctx context.Context, stream string, maxLen int64, values map[string]interface{}) (string, error) {
+	return c.rdb.XAdd(ctx, &redis.XAddArgs{
+		Stream: stream,
+		MaxLen: maxLen,
+		Approx: true,
+		Values: values,
+	}).Result()
+}
+
+func (c *Client) XRange(ctx context.Context, stream, start, stop string, count int64) ([]redis.XMessage, error) {
+	return c.rdb.XRangeN(ctx, stream, start, stop, count).Result()
+}
+
+// Pub/Sub operations
+func (c *Client) Publish(ctx context.Context, channel string, message interface{}) error {
+	return c.rdb.Publish(ctx, channel, message).Err()
+}
+
+func (c *Client) PSubscribe(ctx context.Context, patterns ...string) *redis.PubSub {
+	return c.rdb.PSubscribe(ctx, patterns...)
+}
+
+func (c *Client) Subscribe(ctx context.Context, channels ...string) *redis.PubSub {
+	return c.rdb.Subscribe(ctx, channels...)
+}
+
 // Utility functions
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
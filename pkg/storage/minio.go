@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -47,6 +48,15 @@ type UploadResult struct {
 	URL        string    `json:"url"`
 }
 
+// PresignedPostPolicy is a browser form upload: POST the fields, then the file, to URL.
+// The storage side rejects files of another content type or outside the size range.
+type PresignedPostPolicy struct {
+	URL       string            `json:"url"`
+	Method    string            `json:"method"`
+	Fields    map[string]string `json:"fields"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
 type PresignedUploadURL struct {
 	URL       string            `json:"url"`
 	Method    string            `json:"method"`
@@ -145,6 +155,37 @@ func (s *MinIOService) GetPresignedUploadURL(ctx context.Context, objectName str
 	}, nil
 }
 
+// GetPresignedPostPolicy generates a form upload for exactly objectName, signed for
+// contentType and limited to minBytes..maxBytes
+func (s *MinIOService) GetPresignedPostPolicy(ctx context.Context, bucketType, objectName, contentType string, minBytes, maxBytes int64, expiry time.Duration) (*PresignedPostPolicy, error) {
+	expiresAt := time.Now().Add(expiry)
+
+	policy := minio.NewPostPolicy()
+	for _, err := range []error{
+		policy.SetBucket(s.getBucketName(bucketType)),
+		policy.SetKey(objectName),
+		policy.SetContentType(contentType),
+		policy.SetContentLengthRange(minBytes, maxBytes),
+		policy.SetExpires(expiresAt.UTC()),
+	} {
+		if err != nil {
+			return nil, fmt.Errorf("failed to build upload policy: %w", err)
+		}
+	}
+
+	presigned, fields, err := s.client.PresignedPostPolicy(ctx, policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate upload policy: %w", err)
+	}
+
+	return &PresignedPostPolicy{
+		URL:       presigned.String(),
+		Method:    "POST",
+		Fields:    fields,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
 // UploadFile uploads a file to MinIO
 func (s *MinIOService) UploadFile(ctx context.Context, bucketType, objectName string, reader io.Reader, size int64, contentType string) (*UploadResult, error) {
 	bucket := s.getBucketName(bucketType)
@@ -214,6 +255,20 @@ func (s *MinIOService) GetStreamingURL(ctx context.Context, objectName string, e
 	return url.String(), nil
 }
 
+// GetPresignedDownloadURL generates a presigned URL that downloads a private object as
+// filename
+func (s *MinIOService) GetPresignedDownloadURL(ctx context.Context, bucketType, objectName, filename string, expiry time.Duration) (string, error) {
+	params := url.Values{}
+	params.Set("response-content-disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	presigned, err := s.client.PresignedGetObject(ctx, s.getBucketName(bucketType), objectName, expiry, params)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate download URL: %w", err)
+	}
+
+	return presigned.String(), nil
+}
+
 // DeleteFile deletes a file from MinIO
 func (s *MinIOService) DeleteFile(ctx context.Context, bucketType, objectName string) error {
 	bucket := s.getBucketName(bucketType)
@@ -300,6 +355,11 @@ func (s *MinIOService) GenerateProcessedPath(artistID uint64, songID uint64, for
 	return fmt.Sprintf("processed/%d/%s/%d/%s/%s", artistID, timestamp, songID, format, quality)
 }
 
+// GetObjectURL returns the direct URL of an object, for buckets served publicly
+func (s *MinIOService) GetObjectURL(bucketType, objectName string) string {
+	return s.getObjectURL(s.getBucketName(bucketType), objectName)
+}
+
 // Helper methods
 func (s *MinIOService) getBucketName(bucketType string) string {
 	switch bucketType {
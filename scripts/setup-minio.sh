@@ -102,7 +102,7 @@ mc ilm import audora-minio/processed-tracks < /tmp/lifecycle-policy.json 2>/dev/
 # Create some test folders structure
 echo "📂 Creating folder structure..."
 mc cp /dev/null audora-minio/audora/public/.keep 2>/dev/null || true
-mc cp /dev/null audora-minio/audora/avatars/.keep 2>/dev/null || true
+mc cp /dev/null audora-minio/audora/public/avatars/.keep 2>/dev/null || true
 mc cp /dev/null audora-minio/audora/artwork/.keep 2>/dev/null || true
 mc cp /dev/null audora-minio/audora-tracks/uploads/.keep 2>/dev/null || true
 mc cp /dev/null audora-minio/processed-tracks/mp3/.keep 2>/dev/null || true